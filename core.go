@@ -2,30 +2,22 @@ package main
 
 import (
 	"documentApi/data"
+	"documentApi/documenters"
+	"documentApi/ncrontab"
+	"documentApi/parsers"
 	"documentApi/utils"
 	"encoding/json"
+	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
-// TODO: some of these regex (\w)s should probably be [a-zA-Z0-9_] or similar
-// TODO: It's important to note that code that is commented out in the target files will still be parsed as active...this should probably be fixed
-// TODO: consider parsing the top level route path for a given controller if exists
-
-const DefaultAuth = "DocsToken"
-
-var functionRegex = regexp.MustCompile(`\[Function\((?:nameof\()?"?(?<fname>\w+)"?\)?\)\]`)
-var authenticationRegex = regexp.MustCompile(`\[Require(?<type>DocsTokenGroups|S2SToken|DocsToken|PlatformApiAuth|IdToken)(?:\((?<groups>[^)]*)\))?\]`)
-
-// TODO: parse the whole function/method body?
-var classFunctionRegex = regexp.MustCompile(`(?:public|protected|private)\s+(?:async\s+)?[\w<>]+\s+(?<fname>\w+)\(`)
-var httpTriggerRegex = regexp.MustCompile(`\[HttpTrigger\([\w.]+,\s*(?<methods>"\w+"\s*,\s*)+\s*Route\s*=\s*(?:"(?<route>[^"]+)"|(?<route>[^])]+))\)\]`)
-var timeTrigger = regexp.MustCompile(`\[TimerTrigger\("(?<cron>[^"]+)"[^)]*\)\]`)
-
 // read host json file to get the path prepended to all endpoints in a given package
 func getApiPrefixes(repoPath string, logger *logrus.Logger) map[string]string {
 	jsonEntries, err := utils.GetFiles(repoPath, []string{".json"}, false, true, true)
@@ -55,151 +47,206 @@ func getApiPrefixes(repoPath string, logger *logrus.Logger) map[string]string {
 	return prefixes
 }
 
-func parseFunctionHeader(str string, endpoint *data.EndpointMetaData) int {
-	var m = classFunctionRegex.FindStringSubmatchIndex(str)[0]
-	var begin = m + len(classFunctionRegex.FindStringSubmatch(str)[0])
-	var stop = len(str)
-	var openers = 0
-	var skipped = 0
+// appSetting is the value of an app setting and the settings file it was read from
+type appSetting struct {
+	Value  string
+	Source string
+}
 
-	for i := begin; i < len(str); i++ {
-		if str[i] == '(' {
-			openers++
-		}
-		if str[i] == ')' {
-			if openers == 0 {
-				stop = i
-				break
+var settingPlaceholderRegex = regexp.MustCompile(`%([^%\s]+)%`)
+
+// settingKey normalizes a setting name the way the configuration does, names are case insensitive
+// and the environment variable style separator (__) is the same as the section separator (:)
+func settingKey(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "__", ":"))
+}
+
+// settingsFileRank orders the settings files of a project, the settings of a higher rank override the lower ones,
+// 0 means the file doesn't hold settings (or is the overlay of another environment)
+func settingsFileRank(fileName string, settingsEnv string) int {
+	switch {
+	case strings.EqualFold(fileName, "appsettings.json"):
+		return 1
+	case len(settingsEnv) > 0 && strings.EqualFold(fileName, "appsettings."+settingsEnv+".json"):
+		return 2
+	case fileName == "local.settings.json":
+		return 3
+	}
+	return 0
+}
+
+// flattenSettings adds the (nested) settings to the map, the nested sections are joined with ':', e.g. Storage:Queues:Orders
+func flattenSettings(prefix string, value any, source string, settings map[string]appSetting) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, child := range value {
+			if len(prefix) > 0 {
+				key = prefix + ":" + key
 			}
-			openers--
+			flattenSettings(key, child, source, settings)
 		}
-		if str[i] == '\n' {
-			skipped++
+	case []any:
+		for i, child := range value {
+			flattenSettings(prefix+":"+strconv.Itoa(i), child, source, settings)
 		}
+	case nil:
+		// a null setting doesn't have a value to resolve to
+	default:
+		settings[settingKey(prefix)] = appSetting{Value: fmt.Sprint(value), Source: source}
 	}
+}
 
-	str = str[begin:stop]
-
-	// get the trigger type
-	// Maybe this should just be set from the individual trigger if statements (but there are only 2 atm)
-	// TODO: move this regex so it is only created once
-	var triggerKeys = make([]string, 0, len(data.TriggerType))
-	for k := range data.TriggerType {
-		triggerKeys = append(triggerKeys, k)
-	}
-	var triggerTypeRegex = regexp.MustCompile(`\[(?<triggerType>` + strings.Join(triggerKeys, "|") + `)Trigger[\S\s]*?\]`)
-	triggerTypeMatch := triggerTypeRegex.FindStringSubmatch(str)
-	if len(triggerTypeMatch) > 0 {
-		endpoint.TriggerType = data.TriggerType[triggerTypeMatch[1]]
-	} else {
-		endpoint.TriggerType = data.TriggerType["UNKNOWN"]
+// read the local.settings.json (Values) and appsettings(.{settingsEnv}).json files to get the app settings of each project
+func getAppSettings(repoPath string, settingsEnv string, logger *logrus.Logger) map[string]map[string]appSetting {
+	jsonEntries, err := utils.GetFiles(repoPath, []string{".json"}, false, true, true)
+	if err != nil {
+		logger.Warn("Error reading repo '" + repoPath + "' to locate settings files: " + err.Error())
 	}
 
-	// pull out the route and path vars (if any)
-	httpTriggerRegexMatch := httpTriggerRegex.FindStringSubmatch(str)
-	if len(httpTriggerRegexMatch) > 0 {
-		// TODO: write function to split by regex
-		var noSpaces = strings.ReplaceAll(httpTriggerRegexMatch[1][0:len(httpTriggerRegexMatch[1])-2], " ", "")
-		var noQuotes = strings.ReplaceAll(noSpaces, "\"", "")
-		endpoint.Methods = strings.Split(noQuotes, ",")
-		endpoint.Route = httpTriggerRegexMatch[2] // this will not resolve paths that are built from variables
-		if len(endpoint.Route) < 1 && len(httpTriggerRegexMatch) > 3 {
-			// TODO: consider replacing "Route=null" with empty string or making as an inaccessible path
-			endpoint.Route = httpTriggerRegexMatch[3] // blame go for not having named capture groups
+	var settingsEntries = []data.FileMetaData{}
+	for _, entry := range jsonEntries {
+		if settingsFileRank(entry.Name, settingsEnv) > 0 {
+			settingsEntries = append(settingsEntries, entry)
 		}
-		endpoint.PathParameters = utils.ExtractPathVars(endpoint.Route)
 	}
+	sort.SliceStable(settingsEntries, func(i, j int) bool {
+		return settingsFileRank(settingsEntries[i].Name, settingsEnv) < settingsFileRank(settingsEntries[j].Name, settingsEnv)
+	})
+
+	var settings = make(map[string]map[string]appSetting)
+	for _, entry := range settingsEntries {
+		var prefixKey = utils.Base(utils.Dir(entry.Path))
+		settingsFileData, err := os.ReadFile(entry.Path)
+		if err != nil {
+			logger.Warn("Error reading settings file: " + entry.Path + ": " + err.Error())
+			continue
+		}
+		var settingsData map[string]any
+		if err := json.Unmarshal(settingsFileData, &settingsData); err != nil {
+			logger.Warn("Error parsing settings file: " + entry.Path + ": " + err.Error())
+			continue
+		}
+		if entry.Name == "local.settings.json" {
+			values, _ := settingsData["Values"].(map[string]any)
+			settingsData = values
+		}
 
-	// pull out the cron expression if this is a time trigger
-	timeTriggerMatch := timeTrigger.FindStringSubmatch(str)
-	if len(timeTriggerMatch) > 0 {
-		endpoint.Interval = timeTriggerMatch[1]
+		if _, exists := settings[prefixKey]; !exists {
+			settings[prefixKey] = make(map[string]appSetting)
+		}
+		flattenSettings("", settingsData, entry.Path, settings[prefixKey])
 	}
 
-	if skipped > 0 {
-		return skipped - 1
-	}
-	return skipped
+	return settings
 }
 
-func searchAuthentication(line string, endpoint *data.EndpointMetaData) {
-	var authenticationMatch = authenticationRegex.FindStringSubmatch(line)
-	if len(authenticationMatch) > 1 {
-		if len(authenticationMatch) > 2 && len(authenticationMatch[2]) > 0 {
-			// TODO: write function to split by regex
-			var noCommaSpace = strings.ReplaceAll(authenticationMatch[2], ", ", ",")
-			var noSpace = strings.ReplaceAll(noCommaSpace, " ", ",")
-			var noQuotes = strings.ReplaceAll(noSpace, "\"", "") // this will make it hard to determine if is a docs token group vs "arbitrary string" ... if that's a concern
-			var modes = strings.Split(noQuotes, ",")
-			endpoint.Authentication = append(endpoint.Authentication, modes...)
-		} else {
-			endpoint.Authentication = append(endpoint.Authentication, authenticationMatch[1])
-		}
-	}
+// settingField is an endpoint value that can hold %AppSetting% placeholders
+type settingField struct {
+	name  string
+	value *string
 }
 
-// TODO: break this up into smaller functions to write separate unit tests for each?
-func parse(targetFile data.FileMetaData, logger *logrus.Logger) []data.EndpointMetaData {
-	fileData, err := os.ReadFile(targetFile.Path)
-	if err != nil {
-		logger.Error("Error reading file: " + targetFile.Path + ": " + err.Error())
-		return []data.EndpointMetaData{}
+// resolveSettings replaces the %AppSetting% placeholders in the route, interval and binding of the endpoint with the
+// value of the setting and records where it came from, returns the names of the settings that could not be found
+func resolveSettings(endpoint *data.EndpointMetaData, settings map[string]appSetting) []string {
+	var fields = []settingField{
+		{"route", &endpoint.Route},
+		{"interval", &endpoint.Interval},
 	}
-
-	var fileDataString string = string(fileData)
-	var functionMatches = functionRegex.FindAllStringSubmatch(fileDataString, -1)
-	if len(functionMatches) == 0 {
-		logger.Debug("No functions found in file: " + targetFile.Path)
-		return []data.EndpointMetaData{}
+	if endpoint.Binding != nil {
+		fields = append(fields, []settingField{
+			{"binding.queueName", &endpoint.Binding.QueueName},
+			{"binding.topicName", &endpoint.Binding.TopicName},
+			{"binding.subscriptionName", &endpoint.Binding.SubscriptionName},
+			{"binding.eventHubName", &endpoint.Binding.EventHubName},
+			{"binding.consumerGroup", &endpoint.Binding.ConsumerGroup},
+			{"binding.path", &endpoint.Binding.Path},
+			{"binding.databaseName", &endpoint.Binding.DatabaseName},
+			{"binding.containerName", &endpoint.Binding.ContainerName},
+			{"binding.leaseContainerName", &endpoint.Binding.LeaseContainerName},
+			{"binding.name", &endpoint.Binding.Name},
+		}...)
 	}
-	logger.Debug("Found " + strconv.Itoa(len(functionMatches)) + " functions in file: " + targetFile.Path)
-
-	fileDataString = strings.ReplaceAll(fileDataString, "\r\n", "\n") // In case windows is using \r\n
-	lines := strings.Split(fileDataString, "\n")                      // TODO: this works in linux, but will it in windows?
-
-	var endpoints = []data.EndpointMetaData{}
-	var currentEndpoint data.EndpointMetaData
-	var runningLength = 0
-
-	for lineNumber := 0; lineNumber < len(lines); lineNumber++ { // need this be closer to a counter for loop, because I will probably need to jump through lines
-		line := lines[lineNumber]
-
-		// when you encounter the function header, no more decorators to parse for this function
-		// save the collected metadata so far
-		var classFunctionMatch = classFunctionRegex.FindStringSubmatch(line)
-		if len(classFunctionMatch) > 0 {
-			// if this function has no name, it's probably a regular function/method
-			if len(currentEndpoint.Name) > 1 {
-				var skipped = parseFunctionHeader(fileDataString[runningLength:], &currentEndpoint)
-				for i := lineNumber; i < lineNumber+skipped-1; i++ {
-					runningLength += len(lines[i])
-				}
-				lineNumber += skipped
-				currentEndpoint.FilePath = targetFile.Path
-				endpoints = append(endpoints, currentEndpoint)
+
+	var unresolved = []string{}
+	for _, field := range fields {
+		*field.value = settingPlaceholderRegex.ReplaceAllStringFunc(*field.value, func(placeholder string) string {
+			var name = strings.Trim(placeholder, "%")
+			setting, exists := settings[settingKey(name)]
+			if !exists {
+				unresolved = append(unresolved, name)
+				return placeholder
 			}
-			currentEndpoint = data.EndpointMetaData{}
-		}
+			endpoint.Settings = append(endpoint.Settings, data.SettingReference{Field: field.name, Setting: name, Source: setting.Source})
+			return setting.Value
+		})
+	}
+	return unresolved
+}
 
-		// function name
-		var functionMatch = functionRegex.FindStringSubmatch(line)
-		if len(functionMatch) > 0 {
-			currentEndpoint.Name = functionMatch[1]
+// describeSchedules describes when the timer triggers run and computes their next runs after from, in the location of from.
+// Intervals with a placeholder that could not be resolved are skipped, the invalid ones are returned as diagnostics
+func describeSchedules(endpoints []data.EndpointMetaData, from time.Time, runs int) []parsers.Diagnostic {
+	var diagnostics = []parsers.Diagnostic{}
+	for i := range endpoints {
+		var endpoint = &endpoints[i]
+		if endpoint.TriggerType != data.TriggerType["Timer"] || len(endpoint.Interval) < 1 || settingPlaceholderRegex.MatchString(endpoint.Interval) {
+			continue
 		}
 
-		searchAuthentication(line, &currentEndpoint)
+		var schedule, err = ncrontab.Parse(endpoint.Interval)
+		if err != nil {
+			diagnostics = append(diagnostics, parsers.Diagnostic{Severity: parsers.SeverityWarning, FilePath: endpoint.FilePath, Message: "invalid schedule '" + endpoint.Interval + "' for '" + endpoint.Name + "': " + err.Error()})
+			continue
+		}
 
-		runningLength += len(line) // this can probably added in the 'for' header
+		endpoint.Schedule = &data.ScheduleMetaData{Description: schedule.Describe(), TimeZone: from.Location().String()}
+		for _, next := range schedule.Next(from, runs) {
+			endpoint.Schedule.NextRuns = append(endpoint.Schedule.NextRuns, next.Format(time.RFC3339))
+		}
 	}
+	return diagnostics
+}
 
-	if len(currentEndpoint.Name) > 0 {
-		endpoints = append(endpoints, currentEndpoint)
+// reportDuplicates warns about the endpoints that share a function name (which would have overwritten each other
+// in the past) or an id (which means the endpoint can't be told apart from another), returns the number of duplicated names
+func reportDuplicates(endpoints []data.EndpointMetaData, logger *logrus.Logger) int {
+	var byName = make(map[string][]string)
+	var byId = make(map[string]int)
+	var names = []string{}
+	for _, endpoint := range endpoints {
+		if _, exists := byName[endpoint.Name]; !exists {
+			names = append(names, endpoint.Name)
+		}
+		byName[endpoint.Name] = append(byName[endpoint.Name], endpoint.Id)
+		byId[endpoint.Id]++
 	}
 
-	if len(functionMatches) != len(endpoints) {
-		// should this be a hard fail?
-		logger.Warn("Error parsing file '" + targetFile.Path + "'. Documented " + strconv.Itoa(len(endpoints)) + " functions, but expected " + strconv.Itoa(len(functionMatches)))
+	var duplicates = 0
+	for _, name := range names {
+		if len(byName[name]) > 1 {
+			duplicates++
+			logger.Warn("Found " + strconv.Itoa(len(byName[name])) + " endpoints named '" + name + "': " + strings.Join(byName[name], ", "))
+		}
+	}
+	for _, name := range names {
+		for _, id := range byName[name] {
+			if byId[id] > 1 {
+				logger.Warn("Found " + strconv.Itoa(byId[id]) + " endpoints with the id '" + id + "'")
+				byId[id] = 0 // only report it once
+			}
+		}
 	}
 
-	return endpoints
+	return duplicates
+}
+
+// configureDocumenter returns the documenter with the options of the run. The registered documenters are shared by the
+// runs served concurrently over mcp, so they are copied rather than changed
+func configureDocumenter(documenter documenters.Documenter, params data.RunParams) documenters.Documenter {
+	if schedule, isSchedule := documenter.(documenters.ScheduleDocumenter); isSchedule && params.MaxRunsPerHour != nil {
+		schedule.MaxRunsPerHour = *params.MaxRunsPerHour
+		return schedule
+	}
+	return documenter
 }
@@ -2,203 +2,180 @@ package main
 
 import (
 	"documentApi/data"
+	"documentApi/documenters"
 	"documentApi/utils"
 	"os"
-	"strings"
 	"testing"
+	"time"
 )
 
 var _ = os.Setenv("ENV", "test")
 var _, testLogger = utils.SetupLogger("test.log")
 
-func readTestFile(filePath string) []string {
-	fileData, _ := os.ReadFile(filePath)
-	var fileDataString string = string(fileData)
-	fileDataString = strings.ReplaceAll(fileDataString, "\r\n", "\n") // In case windows is using \r\n
-	return strings.Split(fileDataString, "\n")
-}
-
-func Test_parseFunctionHeader_ReturnsCorrectNumberOfSkippedLines(t *testing.T) {
+func Test_reportDuplicates_ReturnsDuplicatedNames(t *testing.T) {
 	// Arrange
-	codeFileLines := readTestFile("test_assets/one_endpoint.cs")
-	codeFileData := strings.Join(codeFileLines, "\n")
-	var endpoint data.EndpointMetaData
+	var endpoints = []data.EndpointMetaData{
+		{Name: "HealthCheck", Project: "Catalog", ClassName: "Health"},
+		{Name: "HealthCheck", Project: "Orders", ClassName: "Health"},
+		{Name: "GetItems", Project: "Catalog", ClassName: "Items"},
+		{Name: "GetItems", Project: "Catalog", ClassName: "Items"},
+		{Name: "GetOrders", Project: "Orders", ClassName: "Orders"},
+	}
+	for i := range endpoints {
+		endpoints[i].Id = endpoints[i].GenerateId()
+	}
 
 	// Act
-	var skipped = parseFunctionHeader(codeFileData[1440:], &endpoint)
+	var duplicates = reportDuplicates(endpoints, testLogger)
 
 	// Assert
-	utils.AssertEqual(t, 2, skipped)
+	utils.AssertEqual(t, 2, duplicates)
+	utils.AssertStringEqual(t, "Orders/Health/HealthCheck", endpoints[1].Id)
 }
 
-func Test_parseFunctionHeader_ReturnsZeroWhenNoLinesSkipped(t *testing.T) {
-	// Arrange
-	codeFileLines := readTestFile("test_assets/one_endpoint_one_line_header.cs")
-	codeFileData := strings.Join(codeFileLines, "\n")
-	var endpoint data.EndpointMetaData
-
-	// Act
-	var skipped = parseFunctionHeader(codeFileData[470:], &endpoint)
+func Test_getParsers_ReturnsSelectedParsers(t *testing.T) {
+	initParsers()
+	var tests = []struct {
+		names    string
+		expected []string
+		isError  bool
+	}{
+		{"all", []string{"csharp", "functionjson", "node", "python"}, false},
+		{"python, csharp", []string{"csharp", "python"}, false},
+		{"node,node", []string{"node"}, false},
+		{"csharp,java", []string{}, true},
+	}
 
-	// Assert
-	utils.AssertEqual(t, 0, skipped)
+	for _, test := range tests {
+		t.Run(test.names, func(t *testing.T) {
+			// Act
+			var selected, err = getParsers(test.names)
+
+			// Assert
+			if (err != nil) != test.isError {
+				t.Fatalf("expected error: %v, got: %v", test.isError, err)
+			}
+			var names = []string{}
+			for _, parser := range selected {
+				names = append(names, parser.Name())
+			}
+			utils.AssertSliceEqual(t, test.expected, names)
+		})
+	}
 }
 
-func Test_parseFunctionHeader_ReturnsExpectedFunctionMetaData(t *testing.T) {
-	// Arrange
-	codeFileLines := readTestFile("test_assets/one_endpoint.cs")
-	codeFileData := strings.Join(codeFileLines, "\n")
-	var endpoint data.EndpointMetaData
-
-	// Act
-	parseFunctionHeader(codeFileData[1440:], &endpoint)
-
-	// Assert
-	utils.AssertStringEqual(t, data.TriggerType["Http"], endpoint.TriggerType)
-	utils.AssertEqual(t, 1, len(endpoint.Methods))
-	utils.AssertStringEqual(t, "get", endpoint.Methods[0])
-	utils.AssertStringEqual(t, "dashboard-summary/{param}", endpoint.Route)
-	utils.AssertEqual(t, 1, len(endpoint.PathParameters))
-	utils.AssertMapContains(t, endpoint.PathParameters, "param")
+func Test_getAppSettings_ReturnsProjectSettings(t *testing.T) {
+	var tests = []struct {
+		settingsEnv       string
+		expectedContainer string
+	}{
+		{"", "invoices"},
+		{"Development", "invoices-dev"},
+		{"development", "invoices-dev"},
+		{"Production", "invoices"},
+	}
 
+	for _, test := range tests {
+		t.Run("settingsEnv '"+test.settingsEnv+"'", func(t *testing.T) {
+			// Act
+			var settings = getAppSettings("test_assets/settings_app", test.settingsEnv, testLogger)
+
+			// Assert
+			utils.AssertEqual(t, 1, len(settings))
+			var project = settings["settings_app"]
+			utils.AssertStringEqual(t, test.expectedContainer, project["invoices:container"].Value)
+			utils.AssertStringEqual(t, "0 0 2 * * *", project["cleanupschedule"].Value)
+			utils.AssertStringEqual(t, "order-events", project["orders:topic"].Value)
+			// local.settings.json overrides appsettings.json
+			utils.AssertStringEqual(t, "orders-local", project["orders:queue"].Value)
+			utils.AssertStringEqual(t, "test_assets/settings_app/local.settings.json", project["orders:queue"].Source)
+		})
+	}
 }
 
-func Test_parseFunctionHeader_ReturnsExpectedFunctionMetaDataFromSingleLineHeader(t *testing.T) {
+func Test_resolveSettings_ReplacesPlaceholders(t *testing.T) {
 	// Arrange
-	codeFileLines := readTestFile("test_assets/one_endpoint_one_line_header.cs")
-	codeFileData := strings.Join(codeFileLines, "\n")
-	var endpoint data.EndpointMetaData
+	var settings = map[string]appSetting{
+		"reportsroute":       {Value: "reports", Source: "appsettings.json"},
+		"cleanupschedule":    {Value: "0 0 2 * * *", Source: "local.settings.json"},
+		"orders:queue":       {Value: "orders", Source: "local.settings.json"},
+		"invoices:prefix":    {Value: "inv", Source: "appsettings.json"},
+		"invoices:container": {Value: "invoices", Source: "appsettings.json"},
+	}
+	var endpoint = data.EndpointMetaData{
+		Route:    "%ReportsRoute%/{id}",
+		Interval: "%CleanupSchedule%",
+		Binding:  &data.TriggerBinding{QueueName: "%Orders__Queue%", Path: "%Invoices:Container%/%Invoices:Prefix%-{name}", Connection: "%Storage%"},
+	}
 
 	// Act
-	parseFunctionHeader(codeFileData[470:], &endpoint)
+	var unresolved = resolveSettings(&endpoint, settings)
 
 	// Assert
-	utils.AssertStringEqual(t, data.TriggerType["Http"], endpoint.TriggerType)
-	utils.AssertEqual(t, 1, len(endpoint.Methods))
-	utils.AssertStringEqual(t, "get", endpoint.Methods[0])
-	utils.AssertStringEqual(t, "dashboard-summary", endpoint.Route)
+	utils.AssertEqual(t, 0, len(unresolved))
+	utils.AssertStringEqual(t, "reports/{id}", endpoint.Route)
+	utils.AssertStringEqual(t, "0 0 2 * * *", endpoint.Interval)
+	utils.AssertStringEqual(t, "orders", endpoint.Binding.QueueName)
+	utils.AssertStringEqual(t, "invoices/inv-{name}", endpoint.Binding.Path)
+	utils.AssertStringEqual(t, "%Storage%", endpoint.Binding.Connection) // connections are setting names already
+	utils.AssertEqual(t, 5, len(endpoint.Settings))
+	utils.AssertStringEqual(t, "binding.queueName", endpoint.Settings[2].Field)
+	utils.AssertStringEqual(t, "Orders__Queue", endpoint.Settings[2].Setting)
+	utils.AssertStringEqual(t, "local.settings.json", endpoint.Settings[2].Source)
 }
 
-func Test_parse_ReturnsAllExistingHttpTriggers(t *testing.T) {
+func Test_resolveSettings_ReturnsUnresolvedSettings(t *testing.T) {
 	// Arrange
-	var testFile = data.FileMetaData{
-		Name: "http_endpoints_and_helpers.cs",
-		Path: "test_assets/http_endpoints_and_helpers.cs",
-	}
+	var endpoint = data.EndpointMetaData{Interval: "%ArchiveSchedule%"}
 
 	// Act
-	var endpoints = parse(testFile, testLogger)
+	var unresolved = resolveSettings(&endpoint, nil)
 
 	// Assert
-	utils.AssertEqual(t, 4, len(endpoints))
+	utils.AssertEqual(t, 1, len(unresolved))
+	utils.AssertStringEqual(t, "ArchiveSchedule", unresolved[0])
+	utils.AssertStringEqual(t, "%ArchiveSchedule%", endpoint.Interval)
+	utils.AssertEqual(t, 0, len(endpoint.Settings))
 }
 
-func Test_parse_ReturnsAllDataOnExistingHttpTriggers(t *testing.T) {
+func Test_describeSchedules_DescribesTimerTriggers(t *testing.T) {
 	// Arrange
-	var testFile = data.FileMetaData{
-		Name: "http_endpoints_and_helpers.cs",
-		Path: "test_assets/http_endpoints_and_helpers.cs",
+	var endpoints = []data.EndpointMetaData{
+		{Name: "Cleanup", TriggerType: "timer", Interval: "0 */5 9-17 * * 1-5", FilePath: "Cleanup.cs"},
+		{Name: "Archive", TriggerType: "timer", Interval: "%ArchiveSchedule%", FilePath: "Archive.cs"},
+		{Name: "Broken", TriggerType: "timer", Interval: "*/5 * * * *", FilePath: "Broken.cs"},
+		{Name: "GetItems", TriggerType: "http", Route: "items"},
 	}
-
-	var expectedEndpoints []data.EndpointMetaData = make([]data.EndpointMetaData, 0, 4)
-	expectedEndpoints = append(expectedEndpoints, data.EndpointMetaData{
-		Name:           "GetInitialInfoAsync",
-		Route:          "sandbox/{moduleId}/info",
-		Methods:        []string{"get"},
-		PathParameters: map[string]string{"moduleId": ""},
-		TriggerType:    data.TriggerType["Http"],
-	})
-	expectedEndpoints = append(expectedEndpoints, data.EndpointMetaData{
-		Name:           "GetAsync",
-		Authentication: []string{"OperationType.Read"},
-		Route:          "sandbox/{moduleId}",
-		Methods:        []string{"get"},
-		PathParameters: map[string]string{"moduleId": ""},
-		TriggerType:    data.TriggerType["Http"],
-	})
-	expectedEndpoints = append(expectedEndpoints, data.EndpointMetaData{
-		Name:           "PreprovisionSandboxAsync",
-		Authentication: []string{"DocsToken"},
-		Route:          "sandbox/preprovision/{moduleId}",
-		Methods:        []string{"post"},
-		PathParameters: map[string]string{"moduleId": ""},
-		TriggerType:    data.TriggerType["Http"],
-	})
-	expectedEndpoints = append(expectedEndpoints, data.EndpointMetaData{
-		Name:        "VerifyModules",
-		Route:       "sandbox/verify",
-		Methods:     []string{"get"},
-		TriggerType: data.TriggerType["Http"],
-	})
+	var from = time.Date(2026, 10, 23, 16, 58, 0, 0, time.UTC)
 
 	// Act
-	var endpoints = parse(testFile, testLogger)
+	var diagnostics = describeSchedules(endpoints, from, 2)
 
 	// Assert
-	for i := range expectedEndpoints {
-		utils.AssertStringEqual(t, expectedEndpoints[i].Name, endpoints[i].Name)
-		utils.AssertStringEqual(t, expectedEndpoints[i].Route, endpoints[i].Route)
-		utils.AssertStringEqual(t, expectedEndpoints[i].TriggerType, endpoints[i].TriggerType)
-		utils.AssertEqual(t, len(expectedEndpoints[i].Methods), len(endpoints[i].Methods))
-		utils.AssertEqual(t, len(expectedEndpoints[i].PathParameters), len(endpoints[i].PathParameters))
-		utils.AssertEqual(t, len(expectedEndpoints[i].Authentication), len(endpoints[i].Authentication))
-		for j := range expectedEndpoints[i].Methods {
-			utils.AssertStringEqual(t, expectedEndpoints[i].Methods[j], endpoints[i].Methods[j])
-		}
-		for j := range expectedEndpoints[i].PathParameters {
-			utils.AssertStringEqual(t, expectedEndpoints[i].PathParameters[j], endpoints[i].PathParameters[j])
-		}
-		for j := range expectedEndpoints[i].Authentication {
-			utils.AssertStringEqual(t, expectedEndpoints[i].Authentication[j], endpoints[i].Authentication[j])
-		}
+	utils.AssertEqual(t, 1, len(diagnostics))
+	utils.AssertStringEqual(t, "Broken.cs", diagnostics[0].FilePath)
+	utils.AssertStringEqual(t, "invalid schedule '*/5 * * * *' for 'Broken': expected 6 fields ({second} {minute} {hour} {day} {month} {day-of-week}), found 5", diagnostics[0].Message)
+	utils.AssertStringEqual(t, "every 5 minutes between 09:00 and 17:55, Mon–Fri", endpoints[0].Schedule.Description)
+	utils.AssertStringEqual(t, "UTC", endpoints[0].Schedule.TimeZone)
+	utils.AssertEqual(t, 2, len(endpoints[0].Schedule.NextRuns))
+	utils.AssertStringEqual(t, "2026-10-23T17:00:00Z", endpoints[0].Schedule.NextRuns[0])
+	if endpoints[1].Schedule != nil || endpoints[2].Schedule != nil || endpoints[3].Schedule != nil {
+		t.Fatalf("Expected only the valid schedule to be described")
 	}
 }
 
-func Test_searchAuthentication_ReturnsExpectedAuthentication(t *testing.T) {
+func Test_configureDocumenter_CopiesTheScheduleDocumenter(t *testing.T) {
 	// Arrange
-	tests := []struct {
-		name          string
-		expectedAuths []string
-		authString    string
-	}{
-		{
-			name:          "DocsTokenGroups",
-			expectedAuths: []string{"Learn", "Dirt-box", "Config", "Admin", "SG"},
-			authString:    "[RequireDocsTokenGroups(\"Learn Dirt-box Config Admin SG\")]",
-		},
-		{
-			name:          "DocsToken Read",
-			expectedAuths: []string{"OperationType.Read"},
-			authString:    "[RequireDocsToken(OperationType.Read)]",
-		},
-		{
-			name:          "S2SToken",
-			expectedAuths: []string{"S2S.SkillLessons"},
-			authString:    "[RequireS2SToken(S2S.SkillLessons)]",
-		},
-		{
-			name:          "S2SToken Multiple",
-			expectedAuths: []string{"WLW", "S2S.Percentile"},
-			authString:    "[RequireS2SToken(\"WLW\", S2S.Percentile)]",
-		},
-		{
-			name:          "DocsToken",
-			expectedAuths: []string{"DocsToken"},
-			authString:    "[RequireDocsToken]",
-		},
-		{
-			name:          "PlatformToken",
-			expectedAuths: []string{"PlatformApiAuth"},
-			authString:    "[RequirePlatformApiAuth]",
-		},
-	}
-	var results = make([]data.EndpointMetaData, len(tests))
+	var registered = map[string]documenters.Documenter{"schedule": documenters.ScheduleDocumenter{}, "raw": documenters.RawDocumenter{}}
+	var maxRunsPerHour = 4
+	var params = data.RunParams{MaxRunsPerHour: &maxRunsPerHour}
 
-	// Act && Assert
-	for i, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			searchAuthentication(test.authString, &results[i])
-			utils.AssertSliceEqual(t, test.expectedAuths, results[i].Authentication)
-		})
-	}
+	// Act
+	var schedule = configureDocumenter(registered["schedule"], params)
+	var raw = configureDocumenter(registered["raw"], params)
+
+	// Assert
+	utils.AssertEqual(t, 4, schedule.(documenters.ScheduleDocumenter).MaxRunsPerHour)
+	utils.AssertEqual(t, 0, registered["schedule"].(documenters.ScheduleDocumenter).MaxRunsPerHour) // shared by concurrent runs
+	utils.AssertStringEqual(t, "raw", raw.Name())
 }
@@ -0,0 +1,296 @@
+package csharp
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// symbols that are treated as undefined when evaluating #if directives,
+// any other symbol is assumed to be defined
+var undefinedSymbols = map[string]bool{
+	"false": true,
+	"DEBUG": true,
+}
+
+type conditionalRegion struct {
+	active      bool // whether the current branch of the region is compiled
+	branchTaken bool // whether any branch of the region has been compiled so far
+}
+
+// StripComments replaces c# comments (// and /* */), preprocessor directives and the code in disabled
+// #if regions (#if false, #if DEBUG) with whitespace.
+//
+// Line breaks are kept and every other removed char is replaced by a space, so the returned
+// string has the same length and line offsets as the source. Comment markers within strings are ignored.
+func StripComments(src string) string {
+	var out = []byte(src)
+	var regions = []conditionalRegion{}
+
+	for _, token := range Lex(src) {
+		if token.Kind == Directive {
+			regions = evaluateDirective(token.Text[1:], regions)
+		} else if token.Kind != Comment && regionsActive(regions) {
+			continue
+		}
+
+		for k := token.Offset; k < token.Offset+len(token.Text); k++ {
+			if out[k] != '\n' && out[k] != '\r' {
+				out[k] = ' '
+			}
+		}
+	}
+
+	return string(out)
+}
+
+func regionsActive(regions []conditionalRegion) bool {
+	for _, region := range regions {
+		if !region.active {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluateDirective updates the stack of conditional regions for a preprocessor directive (without the #)
+func evaluateDirective(directive string, regions []conditionalRegion) []conditionalRegion {
+	directive = strings.TrimSpace(directive)
+	if i := strings.Index(directive, "//"); i > -1 {
+		directive = strings.TrimSpace(directive[:i])
+	}
+	var keyword, condition, _ = strings.Cut(directive, " ")
+	var last = len(regions) - 1
+
+	switch keyword {
+	case "if":
+		var active = evaluateCondition(condition)
+		regions = append(regions, conditionalRegion{active: active, branchTaken: active})
+	case "elif":
+		if last > -1 {
+			var active = !regions[last].branchTaken && evaluateCondition(condition)
+			regions[last] = conditionalRegion{active: active, branchTaken: regions[last].branchTaken || active}
+		}
+	case "else":
+		if last > -1 {
+			regions[last] = conditionalRegion{active: !regions[last].branchTaken, branchTaken: true}
+		}
+	case "endif":
+		if last > -1 {
+			regions = regions[:last]
+		}
+	}
+
+	return regions
+}
+
+// evaluateCondition evaluates a simple #if condition supporting !, &&, || and parenthesis
+func evaluateCondition(condition string) bool {
+	condition = strings.TrimSpace(condition)
+	for strings.HasPrefix(condition, "(") && strings.HasSuffix(condition, ")") {
+		condition = strings.TrimSpace(condition[1 : len(condition)-1])
+	}
+
+	if parts := strings.Split(condition, "||"); len(parts) > 1 {
+		for _, part := range parts {
+			if evaluateCondition(part) {
+				return true
+			}
+		}
+		return false
+	}
+	if parts := strings.Split(condition, "&&"); len(parts) > 1 {
+		for _, part := range parts {
+			if !evaluateCondition(part) {
+				return false
+			}
+		}
+		return true
+	}
+	if strings.HasPrefix(condition, "!") {
+		return !evaluateCondition(condition[1:])
+	}
+
+	return !undefinedSymbols[condition]
+}
+
+// CommentBlock is a block of line comments on consecutive lines (or a block comment) that isn't preceded by code on its first line,
+// e.g. the /// xml doc comment above a method
+type CommentBlock struct {
+	Text    string // without the comment markers, the lines of a block are joined with \n
+	IsDoc   bool   // an xml doc comment (///)
+	Line    int
+	EndLine int
+}
+
+// CommentBlocks collects the comment blocks of the source, comments following code on the same line
+// and the comments in disabled #if regions are left out
+func CommentBlocks(src string) []CommentBlock {
+	var comments = []CommentBlock{}
+	var regions = []conditionalRegion{}
+	for _, token := range Lex(src) {
+		if token.Kind == Directive {
+			regions = evaluateDirective(token.Text[1:], regions)
+			continue
+		}
+		if token.Kind != Comment || !regionsActive(regions) {
+			continue
+		}
+		var lineStart = strings.LastIndex(src[:token.Offset], "\n") + 1
+		if len(strings.TrimSpace(src[lineStart:token.Offset])) > 0 {
+			continue
+		}
+
+		var text, isDoc = commentText(token.Text)
+		var endLine = token.Line + strings.Count(token.Text, "\n")
+		var last = len(comments) - 1
+		if strings.HasPrefix(token.Text, "//") && last > -1 && comments[last].EndLine == token.Line-1 && comments[last].IsDoc == isDoc {
+			comments[last].Text += "\n" + text
+			comments[last].EndLine = endLine
+			continue
+		}
+		comments = append(comments, CommentBlock{Text: text, IsDoc: isDoc, Line: token.Line, EndLine: endLine})
+	}
+	return comments
+}
+
+// commentText removes the comment markers, e.g. // or the /* */ and the leading * of the lines in between
+func commentText(comment string) (string, bool) {
+	if strings.HasPrefix(comment, "///") && !strings.HasPrefix(comment, "////") {
+		return strings.TrimSpace(strings.TrimPrefix(comment, "///")), true
+	}
+	if strings.HasPrefix(comment, "//") {
+		return strings.TrimSpace(strings.TrimPrefix(comment, "//")), false
+	}
+
+	var isDoc = strings.HasPrefix(comment, "/**") && !strings.HasPrefix(comment, "/**/")
+	comment = strings.TrimSuffix(strings.TrimPrefix(comment, "/*"), "*/")
+	var lines = []string{}
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "*"))
+		if len(line) > 0 || len(lines) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), isDoc
+}
+
+// CommentBlocksBefore returns the comment blocks directly above the line (without blank lines in between), in the order they are written
+func CommentBlocksBefore(comments []CommentBlock, line int) []CommentBlock {
+	var before = []CommentBlock{}
+	for i := len(comments) - 1; i >= 0; i-- {
+		if comments[i].EndLine == line-1 {
+			before = append([]CommentBlock{comments[i]}, before...)
+			line = comments[i].Line
+		} else if comments[i].EndLine < line-1 {
+			break
+		}
+	}
+	return before
+}
+
+// DocComment is the documentation of an xml doc comment, e.g. /// <summary>Gets an item</summary>
+type DocComment struct {
+	Summary    string
+	Remarks    string
+	Returns    string
+	Parameters map[string]string // the <param> descriptions keyed by the parameter name
+}
+
+// ParseDocComment parses the text of an xml doc comment (without the ///). References (<see cref="Item"/>, <paramref name="id"/>)
+// are replaced with the name they reference, paragraphs are separated by a blank line. Text outside of the tags is part of the summary
+func ParseDocComment(text string) DocComment {
+	var doc = DocComment{Parameters: make(map[string]string)}
+	var decoder = xml.NewDecoder(strings.NewReader("<doc>" + text + "</doc>"))
+	decoder.Strict = false
+	decoder.AutoClose = []string{"br"}
+	decoder.Entity = xml.HTMLEntity
+
+	var sections = make(map[string]*strings.Builder) // the text of the top level elements, params are keyed as param:name
+	var order = []string{}
+	var section = "summary"
+	var depth = 0
+	var write = func(text string) {
+		if _, exists := sections[section]; !exists {
+			sections[section] = &strings.Builder{}
+			order = append(order, section)
+		}
+		sections[section].WriteString(text)
+	}
+	for {
+		var token, err = decoder.Token()
+		if err != nil {
+			break
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 2 {
+				section = element.Name.Local
+				if section == "param" {
+					section += ":" + xmlAttribute(element, "name")
+				}
+				continue
+			}
+			switch element.Name.Local {
+			case "see", "seealso", "paramref", "typeparamref":
+				// links (<see href="...">text</see>) keep their text
+				for _, name := range []string{"cref", "name", "langword"} {
+					if reference := xmlAttribute(element, name); len(reference) > 0 {
+						// e.g. T:Repo.Models.Item or M:Repo.Items.Get(System.String)
+						reference, _, _ = strings.Cut(reference[strings.Index(reference, ":")+1:], "(")
+						write(reference[strings.LastIndex(reference, ".")+1:])
+						decoder.Skip()
+						depth--
+						break
+					}
+				}
+			case "para", "br":
+				write("\x00")
+			}
+		case xml.EndElement:
+			if depth == 2 {
+				section = "summary"
+			} else if element.Name.Local == "para" {
+				write("\x00")
+			}
+			depth--
+		case xml.CharData:
+			write(string(element))
+		}
+	}
+
+	for _, name := range order {
+		var value = normalizeDocText(sections[name].String())
+		switch {
+		case name == "summary":
+			doc.Summary = value
+		case name == "remarks":
+			doc.Remarks = value
+		case name == "returns":
+			doc.Returns = value
+		case strings.HasPrefix(name, "param:"):
+			doc.Parameters[strings.TrimPrefix(name, "param:")] = value
+		}
+	}
+	return doc
+}
+
+func xmlAttribute(element xml.StartElement, name string) string {
+	for _, attribute := range element.Attr {
+		if attribute.Name.Local == name {
+			return attribute.Value
+		}
+	}
+	return ""
+}
+
+// normalizeDocText collapses the whitespace of the doc comment lines, paragraphs (marked with \x00) are separated by a blank line
+func normalizeDocText(text string) string {
+	var paragraphs = []string{}
+	for _, paragraph := range strings.Split(text, "\x00") {
+		if words := strings.Fields(paragraph); len(words) > 0 {
+			paragraphs = append(paragraphs, strings.Join(words, " "))
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
@@ -0,0 +1,150 @@
+package csharp
+
+import (
+	"documentApi/utils"
+	"testing"
+)
+
+func Test_StripComments_RemovesCommentsAndDisabledCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			name:     "No comments",
+			src:      "var a = 1;\nvar b = 2;",
+			expected: "var a = 1;\nvar b = 2;",
+		},
+		{
+			name:     "Line comment",
+			src:      "var a = 1; // one\nvar b = 2;",
+			expected: "var a = 1;       \nvar b = 2;",
+		},
+		{
+			name:     "Block comment",
+			src:      "var a /* one\ntwo */ = 1;",
+			expected: "var a       \n       = 1;",
+		},
+		{
+			name:     "Comment markers in strings",
+			src:      `var a = "//"; var b = @"/*"""; var c = $"{"//"}/*";`,
+			expected: `var a = "//"; var b = @"/*"""; var c = $"{"//"}/*";`,
+		},
+		{
+			name:     "Comment markers in raw string",
+			src:      "var a = \"\"\"\n// not a comment \" \n\"\"\"; // comment",
+			expected: "var a = \"\"\"\n// not a comment \" \n\"\"\";           ",
+		},
+		{
+			name:     "Char literals",
+			src:      `var a = '"'; // comment`,
+			expected: `var a = '"';           `,
+		},
+		{
+			name:     "If false region",
+			src:      "a\n#if false\nb\n#endif\nc",
+			expected: "a\n         \n \n      \nc",
+		},
+		{
+			name:     "If debug else region",
+			src:      "#if DEBUG\na\n#else\nb\n#endif",
+			expected: "         \n \n     \nb\n      ",
+		},
+		{
+			name:     "Unknown symbol region",
+			src:      "#if NET8_0\na\n#else\nb\n#endif",
+			expected: "          \na\n     \n \n      ",
+		},
+		{
+			name:     "Nested regions",
+			src:      "#if !DEBUG\na\n#if false\nb\n#endif\nc\n#endif",
+			expected: "          \na\n         \n \n      \nc\n      ",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := StripComments(test.src)
+			if len(result) != len(test.src) {
+				t.Errorf("expected length %d, got %d", len(test.src), len(result))
+			}
+			if result != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, result)
+			}
+		})
+	}
+}
+
+func Test_CommentBlocks_MergesConsecutiveLineComments(t *testing.T) {
+	// Arrange
+	var src = `// GET /api/items/{id}
+/// <summary>
+/// Gets an item
+/// </summary>
+[Function("GetItem")]
+public void GetItem() { var a = 1; // trailing
+}
+
+/*
+ * Block comment
+ */
+#if DEBUG
+// disabled
+#endif
+public void Other() { }`
+
+	// Act
+	var comments = CommentBlocks(src)
+
+	// Assert
+	utils.AssertEqual(t, 3, len(comments))
+	utils.AssertStringEqual(t, "GET /api/items/{id}", comments[0].Text)
+	utils.AssertStringEqual(t, "<summary>\nGets an item\n</summary>", comments[1].Text)
+	if !comments[1].IsDoc || comments[0].IsDoc {
+		t.Error("expected only the /// comment to be a doc comment")
+	}
+	utils.AssertEqual(t, 2, comments[1].Line)
+	utils.AssertEqual(t, 4, comments[1].EndLine)
+	utils.AssertStringEqual(t, "Block comment", comments[2].Text)
+	utils.AssertEqual(t, 11, comments[2].EndLine)
+
+	var before = CommentBlocksBefore(comments, 5)
+	utils.AssertEqual(t, 2, len(before))
+	utils.AssertEqual(t, 0, len(CommentBlocksBefore(comments, 15)))
+}
+
+func Test_ParseDocComment_ReturnsTheSections(t *testing.T) {
+	var tests = []struct {
+		name     string
+		text     string
+		expected DocComment
+	}{
+		{"summary and params", `<summary>
+Gets the <see cref="T:Repo.Models.Item"/> with the
+<paramref name="id"/>.
+</summary>
+<param name="id">The item id</param>
+<param name="req">The request</param>
+<returns>The item, or <see langword="null"/></returns>`, DocComment{Summary: "Gets the Item with the id.", Returns: "The item, or null", Parameters: map[string]string{"id": "The item id", "req": "The request"}}},
+		{"paragraphs and remarks", `<summary>First<para>Second &amp; <c>code</c></para></summary><remarks>See <see href="https://example.com">the docs</see></remarks>`, DocComment{Summary: "First\n\nSecond & code", Remarks: "See the docs", Parameters: map[string]string{}}},
+		{"plain text", "Gets the items", DocComment{Summary: "Gets the items", Parameters: map[string]string{}}},
+		{"unclosed tags", "<summary>Gets <b>the items", DocComment{Summary: "Gets the items", Parameters: map[string]string{}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			var doc = ParseDocComment(test.text)
+
+			// Assert
+			utils.AssertStringEqual(t, test.expected.Summary, doc.Summary)
+			utils.AssertStringEqual(t, test.expected.Remarks, doc.Remarks)
+			utils.AssertStringEqual(t, test.expected.Returns, doc.Returns)
+			utils.AssertEqual(t, len(test.expected.Parameters), len(doc.Parameters))
+			for name, description := range test.expected.Parameters {
+				utils.AssertStringEqual(t, description, doc.Parameters[name])
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package csharp
+
+import (
+	"strings"
+)
+
+// Constants is an index of the string constants (const string and static readonly string fields)
+// declared across a repo, used to evaluate expressions that reference them (e.g. Route = Routes.Items)
+type Constants struct {
+	fields map[string][]*constant // keyed by the field name
+}
+
+type constant struct {
+	typeName    string
+	initializer []Token
+	value       string
+	state       int
+}
+
+const (
+	notEvaluated = iota
+	evaluating
+	evaluated
+	failed
+)
+
+var stringTypes = map[string]bool{
+	"string": true, "String": true, "System.String": true,
+}
+
+func NewConstants() *Constants {
+	return &Constants{fields: make(map[string][]*constant)}
+}
+
+// Add indexes the string constants declared in the file
+func (c *Constants) Add(file File) {
+	for _, field := range file.Fields {
+		if !stringTypes[field.Type] || len(field.Initializer) < 1 || field.IsProperty {
+			continue
+		}
+		if !field.HasModifier("const") && !(field.HasModifier("static") && field.HasModifier("readonly")) {
+			continue
+		}
+
+		c.fields[field.Name] = append(c.fields[field.Name], &constant{
+			typeName:    field.TypeName,
+			initializer: field.Initializer,
+		})
+	}
+}
+
+// Len returns the number of indexed constants
+func (c *Constants) Len() int {
+	if c == nil {
+		return 0
+	}
+	var count = 0
+	for _, candidates := range c.fields {
+		count += len(candidates)
+	}
+	return count
+}
+
+// lookup finds the constant referenced by name, optionally qualified by its type.
+// Unqualified references prefer constants declared in the current type
+func (c *Constants) lookup(name string, qualifier string, currentType string) (*constant, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	var candidates = c.fields[name]
+	if len(qualifier) > 0 {
+		for _, candidate := range candidates {
+			if candidate.typeName == qualifier {
+				return candidate, true
+			}
+		}
+		return nil, false
+	}
+
+	for _, candidate := range candidates {
+		if candidate.typeName == currentType {
+			return candidate, true
+		}
+	}
+	// could be a "using static" import, only resolve it if it is not ambiguous
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+	return nil, false
+}
+
+func (c *Constants) resolve(constant *constant) (string, bool) {
+	switch constant.state {
+	case evaluated:
+		return constant.value, true
+	case evaluating, failed: // circular reference or known failure
+		return "", false
+	}
+
+	constant.state = evaluating
+	value, ok := c.Evaluate(constant.initializer, constant.typeName)
+	if !ok {
+		constant.state = failed
+		return "", false
+	}
+	constant.value = value
+	constant.state = evaluated
+	return value, true
+}
+
+// Evaluate evaluates a string expression made up of literals, interpolated strings, nameof(),
+// references to constants and concatenation. currentType is the type the expression is declared in,
+// used to resolve unqualified references. Returns false if the expression can't be evaluated
+func (c *Constants) Evaluate(tokens []Token, currentType string) (string, bool) {
+	var value = ""
+	for _, term := range split(tokens, "+", false) {
+		var termValue, ok = c.evaluateTerm(term, currentType)
+		if !ok {
+			return "", false
+		}
+		value += termValue
+	}
+	return value, len(tokens) > 0
+}
+
+func (c *Constants) evaluateTerm(tokens []Token, currentType string) (string, bool) {
+	if len(tokens) < 1 {
+		return "", false
+	}
+
+	// parenthesized expression
+	if tokens[0].Is("(") && matching(tokens, 0) == len(tokens)-1 {
+		return c.Evaluate(tokens[1:len(tokens)-1], currentType)
+	}
+
+	if len(tokens) == 1 {
+		switch tokens[0].Kind {
+		case String:
+			if strings.HasPrefix(tokens[0].Text, "$") || strings.HasPrefix(tokens[0].Text, "@$") {
+				return c.evaluateInterpolated(tokens[0], currentType)
+			}
+			return StringValue(tokens[0])
+		case Number:
+			return tokens[0].Text, true
+		}
+	}
+
+	// nameof(Foo.Bar) -> Bar
+	if len(tokens) > 3 && tokens[0].Is("nameof") && tokens[1].Is("(") && tokens[len(tokens)-1].Is(")") {
+		return tokens[len(tokens)-2].Text, true
+	}
+
+	// a (possibly qualified) reference to a constant, e.g. Bar, Foo.Bar or Namespace.Foo.Bar
+	var names = []string{}
+	for i, token := range tokens {
+		if i%2 == 0 && token.Kind == Identifier {
+			names = append(names, strings.TrimPrefix(token.Text, "@"))
+		} else if i%2 == 1 && token.Is(".") {
+			continue
+		} else {
+			return "", false
+		}
+	}
+	if len(names) < 1 || len(tokens)%2 == 0 {
+		return "", false
+	}
+
+	var name = names[len(names)-1]
+	var qualifier = ""
+	if len(names) > 1 {
+		qualifier = names[len(names)-2]
+	}
+	if (qualifier == "string" || qualifier == "String") && name == "Empty" {
+		return "", true
+	}
+
+	constant, exists := c.lookup(name, qualifier, currentType)
+	if !exists {
+		return "", false
+	}
+	return c.resolve(constant)
+}
+
+// evaluateInterpolated evaluates an interpolated string, each interpolation hole is evaluated as an expression
+func (c *Constants) evaluateInterpolated(token Token, currentType string) (string, bool) {
+	var text = token.Text
+	var verbatim = strings.Contains(text[:strings.Index(text, `"`)], "@")
+	text = text[strings.Index(text, `"`):]
+	if strings.HasPrefix(text, `"""`) || len(text) < 2 {
+		return "", false // interpolated raw strings are not supported
+	}
+	text = text[1 : len(text)-1]
+
+	var builder strings.Builder
+	var literalStart = 0
+	var writeLiteral = func(literal string) {
+		if verbatim {
+			literal = strings.ReplaceAll(literal, `""`, `"`)
+		} else {
+			literal = unescape(literal)
+		}
+		builder.WriteString(literal)
+	}
+
+	for i := 0; i < len(text); i++ {
+		switch {
+		case strings.HasPrefix(text[i:], "{{") || strings.HasPrefix(text[i:], "}}"):
+			writeLiteral(text[literalStart:i])
+			builder.WriteByte(text[i])
+			i++
+			literalStart = i + 1
+		case text[i] == '{':
+			writeLiteral(text[literalStart:i])
+			var end = skipInterpolationHole(text, i)
+			var holeTokens = codeTokens(text[i+1 : end-1])
+			// drop the alignment and format specifier, e.g. {value,10:N2}
+			for j, holeToken := range holeTokens {
+				if holeToken.Is(",") || holeToken.Is(":") {
+					holeTokens = holeTokens[:j]
+					break
+				}
+			}
+			var holeValue, ok = c.Evaluate(holeTokens, currentType)
+			if !ok {
+				return "", false
+			}
+			builder.WriteString(holeValue)
+			i = end - 1
+			literalStart = end
+		}
+	}
+	writeLiteral(text[literalStart:])
+
+	return builder.String(), true
+}
@@ -0,0 +1,93 @@
+package csharp
+
+import (
+	"documentApi/utils"
+	"os"
+	"testing"
+)
+
+func Test_Constants_EvaluateResolvesRouteExpressions(t *testing.T) {
+	// Arrange
+	fileData, _ := os.ReadFile("../test_assets/route_constants.cs")
+	var constants = NewConstants()
+	constants.Add(Parse(string(fileData)))
+
+	tests := []struct {
+		name        string
+		expression  string
+		currentType string
+		expected    string
+		ok          bool
+	}{
+		{
+			name:        "Literal",
+			expression:  `"catalog/items"`,
+			currentType: "RouteTriggers",
+			expected:    "catalog/items",
+			ok:          true,
+		},
+		{
+			name:        "Qualified constant built from a constant",
+			expression:  "Routes.Items",
+			currentType: "RouteTriggers",
+			expected:    "catalog/items",
+			ok:          true,
+		},
+		{
+			name:        "Static readonly interpolated constant and concatenation",
+			expression:  `Routes.Item + "/details"`,
+			currentType: "RouteTriggers",
+			expected:    "catalog/items/{itemId}/details",
+			ok:          true,
+		},
+		{
+			name:        "Interpolated with local constant and nameof",
+			expression:  `$"{Routes.Prefix}/{Local}/{nameof(GetLocal)}/{{id}}"`,
+			currentType: "RouteTriggers",
+			expected:    "catalog/local/GetLocal/{id}",
+			ok:          true,
+		},
+		{
+			name:        "Unqualified constant from the current type",
+			expression:  "Items",
+			currentType: "Routes",
+			expected:    "catalog/items",
+			ok:          true,
+		},
+		{
+			name:        "Parenthesized and string.Empty",
+			expression:  `("a" + string.Empty) + "/b"`,
+			currentType: "RouteTriggers",
+			expected:    "a/b",
+			ok:          true,
+		},
+		{
+			name:        "Circular reference",
+			expression:  "Routes.Circular",
+			currentType: "RouteTriggers",
+			ok:          false,
+		},
+		{
+			name:        "Unknown constant",
+			expression:  "Routes.Unknown",
+			currentType: "RouteTriggers",
+			ok:          false,
+		},
+		{
+			name:        "Method call",
+			expression:  "GetRoute()",
+			currentType: "RouteTriggers",
+			ok:          false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, ok := constants.Evaluate(codeTokens(test.expression), test.currentType)
+			if ok != test.ok {
+				t.Errorf("expected ok to be %t, got %t", test.ok, ok)
+			}
+			utils.AssertStringEqual(t, test.expected, value)
+		})
+	}
+}
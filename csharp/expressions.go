@@ -0,0 +1,154 @@
+package csharp
+
+// Expression is a value expression, parsed as far as object creation and initializers go,
+// e.g. new Item { Name = "a", Tags = { "x" } }, new[] { 1, 2 } or ["a", "b"]. Any other expression is only kept as its tokens
+type Expression struct {
+	Tokens    []Token
+	IsNew     bool         // an object creation, e.g. new Item(...) { ... }, new() { ... } or new[] { ... }
+	TypeName  string       // the type created, empty for target typed new() and new[]
+	Arguments []Expression // the constructor arguments
+	Members   []Member     // the object initializer, e.g. { Name = "a" } or { ["key"] = "a" }
+	Items     []Expression // the collection initializer or collection expression elements, e.g. { "a", "b" } or { { "key", "a" } }
+}
+
+// Member is an assignment in an object initializer, either to a property (Name = value) or an index ([key] = value)
+type Member struct {
+	Name  string
+	Index []Token // the key of an index assignment
+	Value Expression
+}
+
+// IsInitializer reports whether the expression is an initializer without a new, e.g. the { "x" } in Tags = { "x" }
+func (e Expression) IsInitializer() bool {
+	return len(e.Tokens) > 1 && e.Tokens[0].Is("{") && matching(e.Tokens, 0) == len(e.Tokens)-1
+}
+
+// IsCollection reports whether the expression is a collection expression, e.g. ["a", "b"]
+func (e Expression) IsCollection() bool {
+	return len(e.Tokens) > 1 && e.Tokens[0].Is("[") && matching(e.Tokens, 0) == len(e.Tokens)-1
+}
+
+// ParseExpression parses the tokens of a single expression
+func ParseExpression(tokens []Token) Expression {
+	var p = parser{tokens: tokens, file: &File{}}
+	var expression = Expression{Tokens: tokens}
+	switch {
+	case expression.IsInitializer():
+		parseInitializer(tokens[1:len(tokens)-1], &expression)
+	case expression.IsCollection():
+		for _, item := range split(tokens[1:len(tokens)-1], ",", true) {
+			if len(item) > 0 {
+				expression.Items = append(expression.Items, ParseExpression(item))
+			}
+		}
+	case len(tokens) > 1 && tokens[0].Is("new"):
+		expression.IsNew = true
+		p.pos = 1
+		if p.at("[") { // new[] { ... }
+			p.pos = matching(tokens, p.pos) + 1
+		} else if !p.at("(") && !p.at("{") {
+			var start = p.pos
+			for !p.done() && !p.at("(") && !p.at("{") {
+				switch {
+				case p.at("<"):
+					p.pos = matchingAngle(tokens, p.pos) + 1
+				case p.at("["): // array creation, e.g. new string[] { ... } or new int[3]
+					p.pos = matching(tokens, p.pos) + 1
+				default:
+					p.pos++
+				}
+			}
+			expression.TypeName = joinTokens(tokens[start:p.pos])
+		}
+		if p.at("(") {
+			var end = matching(tokens, p.pos)
+			for _, argument := range split(tokens[p.pos+1:end], ",", true) {
+				if len(argument) > 0 {
+					expression.Arguments = append(expression.Arguments, ParseExpression(argumentValue(argument)))
+				}
+			}
+			p.pos = end + 1
+		}
+		if p.at("{") {
+			var end = matching(tokens, p.pos)
+			parseInitializer(tokens[p.pos+1:end], &expression)
+		}
+	}
+	return expression
+}
+
+// NewExpressions finds the object creations in the tokens (e.g. a method body), in the order they are written.
+// Object creations nested in another one are only returned as part of it
+func NewExpressions(tokens []Token) []Expression {
+	var expressions = []Expression{}
+	for i := 0; i < len(tokens); i++ {
+		if !tokens[i].Is("new") {
+			continue
+		}
+		var end = i + 1
+		for end < len(tokens) && !tokens[end].Is("(") && !tokens[end].Is("{") && !tokens[end].Is(";") && !tokens[end].Is(")") && !tokens[end].Is(",") {
+			switch {
+			case tokens[end].Is("<"):
+				end = matchingAngle(tokens, end) + 1
+			case tokens[end].Is("["):
+				end = matching(tokens, end) + 1
+			default:
+				end++
+			}
+		}
+		if end < len(tokens) && tokens[end].Is("(") {
+			end = matching(tokens, end) + 1
+		}
+		if end < len(tokens) && tokens[end].Is("{") {
+			end = matching(tokens, end) + 1
+		}
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+		expressions = append(expressions, ParseExpression(tokens[i:end]))
+		i = end - 1
+	}
+	return expressions
+}
+
+// parseInitializer parses the contents of an object or collection initializer. Object initializers assign members
+// (Name = value or [key] = value), anything else is a collection initializer
+func parseInitializer(tokens []Token, expression *Expression) {
+	for _, item := range split(tokens, ",", true) {
+		if len(item) < 1 {
+			continue
+		}
+		if len(item) > 2 && item[0].Kind == Identifier && item[1].Is("=") {
+			expression.Members = append(expression.Members, Member{Name: item[0].Text, Value: ParseExpression(item[2:])})
+			continue
+		}
+		if item[0].Is("[") {
+			var end = matching(item, 0)
+			if end+1 < len(item) && item[end+1].Is("=") {
+				expression.Members = append(expression.Members, Member{Index: item[1:end], Value: ParseExpression(item[end+2:])})
+				continue
+			}
+		}
+		expression.Items = append(expression.Items, ParseExpression(item))
+	}
+}
+
+// argumentValue drops the name of a named argument, e.g. quantity: 2
+func argumentValue(tokens []Token) []Token {
+	if len(tokens) > 2 && tokens[0].Kind == Identifier && tokens[1].Is(":") {
+		return tokens[2:]
+	}
+	return tokens
+}
+
+// joinTokens rebuilds the source of a type name from its tokens, e.g. Dictionary<string, int>
+func joinTokens(tokens []Token) string {
+	var text = ""
+	for _, token := range tokens {
+		text += token.Text
+		if token.Is(",") {
+			text += " "
+		}
+	}
+	return text
+}
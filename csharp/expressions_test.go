@@ -0,0 +1,81 @@
+package csharp
+
+import (
+	"documentApi/utils"
+	"testing"
+)
+
+func Test_ParseExpression_ParsesObjectCreation(t *testing.T) {
+	// Arrange
+	var tokens = codeTokens(`new Order(id, quantity: 2) { Name = "a", Lines = { new Line() }, ["key"] = 1, Tags = ["x", "y"] }`)
+
+	// Act
+	var expression = ParseExpression(tokens)
+
+	// Assert
+	if !expression.IsNew {
+		t.Fatal("expected an object creation")
+	}
+	utils.AssertStringEqual(t, "Order", expression.TypeName)
+	utils.AssertEqual(t, 2, len(expression.Arguments))
+	utils.AssertStringEqual(t, "2", expression.Arguments[1].Tokens[0].Text)
+	utils.AssertEqual(t, 4, len(expression.Members))
+	utils.AssertStringEqual(t, "Name", expression.Members[0].Name)
+
+	var lines = expression.Members[1].Value
+	if !lines.IsInitializer() {
+		t.Error("expected Lines to be assigned a collection initializer")
+	}
+	utils.AssertEqual(t, 1, len(lines.Items))
+	utils.AssertStringEqual(t, "Line", lines.Items[0].TypeName)
+
+	utils.AssertStringEqual(t, `"key"`, expression.Members[2].Index[0].Text)
+	if !expression.Members[3].Value.IsCollection() {
+		t.Error("expected Tags to be assigned a collection expression")
+	}
+	utils.AssertEqual(t, 2, len(expression.Members[3].Value.Items))
+}
+
+func Test_ParseExpression_ReturnsTypeNames(t *testing.T) {
+	var tests = []struct {
+		name     string
+		src      string
+		typeName string
+		items    int
+	}{
+		{"generic", `new Dictionary<string, int> { { "a", 1 } }`, "Dictionary<string, int>", 1},
+		{"array", `new string[] { "a", "b" }`, "string[]", 2},
+		{"implicit array", `new[] { 1, 2, 3 }`, "", 3},
+		{"target typed", `new() { }`, "", 0},
+		{"qualified", `new Models.Item()`, "Models.Item", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			var expression = ParseExpression(codeTokens(test.src))
+
+			// Assert
+			utils.AssertStringEqual(t, test.typeName, expression.TypeName)
+			utils.AssertEqual(t, test.items, len(expression.Items))
+		})
+	}
+}
+
+func Test_NewExpressions_ReturnsTopLevelObjectCreations(t *testing.T) {
+	// Arrange
+	var tokens = codeTokens(`var a = new Item { Child = new Child() };
+this.Examples.Add(Resolve("b", new Other(1), strategy));
+return new List<int>();`)
+
+	// Act
+	var expressions = NewExpressions(tokens)
+
+	// Assert
+	var typeNames = []string{}
+	for _, expression := range expressions {
+		typeNames = append(typeNames, expression.TypeName)
+	}
+	utils.AssertSliceEqual(t, []string{"Item", "Other", "List<int>"}, typeNames)
+	utils.AssertEqual(t, 1, len(expressions[0].Members))
+}
@@ -0,0 +1,309 @@
+package csharp
+
+import (
+	"slices"
+)
+
+// Invocation is a method call in a chain, e.g. MapGet("/items", GetItems) in app.MapGet("/items", GetItems).WithName("Items")
+type Invocation struct {
+	Name          string
+	TypeArguments string // the source of the generic arguments, e.g. Produces<Item>(200) -> Item
+	Arguments     []Argument
+	Line          int
+}
+
+// Chain is a chain of invocations on a variable (or type), e.g. app.MapGroup("/api").MapGet("/items", GetItems)
+type Chain struct {
+	Root        string // the variable (or type) the chain starts on, e.g. app
+	Assignee    string // the variable the result of the chain is assigned to, if any
+	Invocations []Invocation
+	Offset      int // the byte offset of the root in the source
+	Line        int
+}
+
+// Lambda is a lambda expression, e.g. async ([FromBody] Item item, ItemDb db) => { ... }
+type Lambda struct {
+	Attributes []Attribute
+	Parameters []Parameter // untyped parameters have an empty type
+	Body       []Token
+}
+
+var lambdaModifiers = map[string]bool{
+	"async": true, "static": true,
+}
+
+// Chains finds the invocation chains in the source, in the order they are written.
+// Chains nested in the arguments of another chain (e.g. in a lambda) are returned separately
+func Chains(src string) []Chain {
+	var p = parser{src: src, tokens: codeTokens(src), file: &File{}}
+	var tokens = p.tokens
+	var chains = []Chain{}
+
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i].Kind != Identifier || !isMemberAccess(tokens[i+1]) || (i > 0 && isMemberAccess(tokens[i-1])) {
+			continue
+		}
+
+		var chain = Chain{Root: tokens[i].Text, Offset: tokens[i].Offset, Line: tokens[i].Line}
+		if i > 1 && tokens[i-1].Is("=") && tokens[i-2].Kind == Identifier && (i < 3 || !isMemberAccess(tokens[i-3])) {
+			chain.Assignee = tokens[i-2].Text
+		}
+
+		for pos := i + 1; pos+1 < len(tokens) && isMemberAccess(tokens[pos]) && tokens[pos+1].Kind == Identifier; {
+			var invocation = Invocation{Name: tokens[pos+1].Text, Line: tokens[pos+1].Line}
+			pos += 2
+			if pos < len(tokens) && tokens[pos].Is("<") {
+				if close := matchingAngle(tokens, pos); close < len(tokens) && tokens[close].Is(">") {
+					invocation.TypeArguments = p.text(tokens[pos+1 : close])
+					pos = close + 1
+				}
+			}
+			if pos >= len(tokens) || !tokens[pos].Is("(") {
+				if len(chain.Invocations) > 0 {
+					break // a member access on the result, e.g. app.MapGet(...).Something, the chain ends here
+				}
+				chain.Root += "." + invocation.Name // a member access on the root, e.g. builder.Services.AddSomething()
+				continue
+			}
+
+			var close = matching(tokens, pos)
+			invocation.Arguments = p.parseArguments(tokens[pos+1 : close])
+			chain.Invocations = append(chain.Invocations, invocation)
+			pos = close + 1
+		}
+
+		if len(chain.Invocations) > 0 {
+			chains = append(chains, chain)
+		}
+	}
+
+	return chains
+}
+
+func isMemberAccess(token Token) bool {
+	return token.Is(".") || token.Is("?.")
+}
+
+// ParseLambda parses the lambda expression in the argument, src is the source the argument was parsed from.
+// Returns false if the argument is not a lambda
+func ParseLambda(src string, argument Argument) (Lambda, bool) {
+	var p = parser{src: src, tokens: argument.Tokens, file: &File{}}
+	var lambda = Lambda{}
+
+	for p.at("[") {
+		lambda.Attributes = append(lambda.Attributes, p.parseAttributeSection()...)
+	}
+	for !p.done() && p.current().Kind == Identifier && lambdaModifiers[p.current().Text] {
+		p.pos++
+	}
+
+	var arrow = indexOf(p.tokens, "=>")
+	if arrow < p.pos {
+		return Lambda{}, false
+	}
+	lambda.Body = p.tokens[arrow+1:]
+
+	// a single untyped parameter, e.g. x => x.Id
+	if arrow == p.pos+1 && p.current().Kind == Identifier {
+		lambda.Parameters = []Parameter{{Name: p.current().Text}}
+		return lambda, true
+	}
+
+	// the parameter list is the parentheses right before the arrow, anything before it is an explicit return type
+	if !p.tokens[arrow-1].Is(")") {
+		return Lambda{}, false
+	}
+	for open := p.pos; open < arrow; open++ {
+		if p.tokens[open].Is("(") && matching(p.tokens, open) == arrow-1 {
+			p.pos = open
+			lambda.Parameters = p.parseParameters()
+			return lambda, true
+		}
+		if p.tokens[open].Kind == Punctuation && closers[p.tokens[open].Text] != "" {
+			open = matching(p.tokens, open)
+		}
+	}
+	return Lambda{}, false
+}
+
+// GenericArgument returns the type arguments of the first call to one of the generic methods in the tokens,
+// e.g. Item for await req.ReadFromJsonAsync<Item>()
+func GenericArgument(tokens []Token, names ...string) (string, bool) {
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i].Kind != Identifier || !tokens[i+1].Is("<") || !slices.Contains(names, tokens[i].Text) {
+			continue
+		}
+		var close = matchingAngle(tokens, i+1)
+		if close >= len(tokens) || !tokens[close].Is(">") || close+1 >= len(tokens) || !tokens[close+1].Is("(") {
+			continue
+		}
+		return joinTokens(tokens[i+2 : close]), true
+	}
+	return "", false
+}
+
+// KeyAccess is a lookup by key on a collection member, e.g. req.Query["id"], req.Query.Get("id")
+// or req.Headers.TryGetValues("X-Id", out var values)
+type KeyAccess struct {
+	Member   string  // the member holding the collection, e.g. Query, also for the locals it is assigned to
+	Receiver string  // the expression the member is accessed on, e.g. req
+	Method   string  // the method called with the key, empty for an indexer
+	Key      []Token // the key (the first argument of the method)
+	IsWrite  bool    // an indexer assignment, e.g. response.Headers["X-Id"] = id
+	Line     int
+}
+
+// KeyAccesses finds the lookups by key on the members in the tokens (e.g. a method body), in the order they are written.
+// Locals assigned the member (or the result of invoking it, e.g. var query = HttpUtility.ParseQueryString(req.Url.Query))
+// are followed, their lookups are returned as lookups on the member
+func KeyAccesses(tokens []Token, members ...string) []KeyAccess {
+	var aliases = make(map[string]KeyAccess) // the member and receiver of the locals
+	var accesses = []KeyAccess{}
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].Kind != Identifier {
+			continue
+		}
+
+		// the start of the member access chain, e.g. req in req.HttpContext.Request.Query
+		var start = i
+		for start > 1 && isMemberAccess(tokens[start-1]) && tokens[start-2].Kind == Identifier {
+			start -= 2
+		}
+
+		var access KeyAccess
+		switch alias, isAlias := aliases[tokens[i].Text]; {
+		case start < i && slices.Contains(members, tokens[i].Text):
+			access = KeyAccess{Member: tokens[i].Text, Receiver: joinTokens(tokens[start : i-1])}
+		case start == i && isAlias && (i < 1 || !isMemberAccess(tokens[i-1])):
+			access = alias
+		default:
+			continue
+		}
+		access.Line = tokens[i].Line
+
+		var pos = i + 1
+		if pos < len(tokens) && tokens[pos].Is("(") {
+			pos = matching(tokens, pos) + 1
+		}
+		switch {
+		case pos < len(tokens) && tokens[pos].Is(";"):
+			// var query = req.Query; or query = req.Query;
+			if start > 1 && tokens[start-1].Is("=") && tokens[start-2].Kind == Identifier {
+				aliases[tokens[start-2].Text] = KeyAccess{Member: access.Member, Receiver: access.Receiver}
+			}
+		case pos < len(tokens) && tokens[pos].Is("["):
+			var close = matching(tokens, pos)
+			if !tokens[close].Is("]") {
+				continue
+			}
+			access.Key = tokens[pos+1 : close]
+			access.IsWrite = close+1 < len(tokens) && tokens[close+1].Is("=")
+			accesses = append(accesses, access)
+		case pos+2 < len(tokens) && isMemberAccess(tokens[pos]) && tokens[pos+1].Kind == Identifier && tokens[pos+2].Is("("):
+			var close = matching(tokens, pos+2)
+			if !tokens[close].Is(")") {
+				continue
+			}
+			var arguments = split(tokens[pos+3:close], ",", true)
+			if len(arguments) < 1 || len(arguments[0]) < 1 {
+				continue
+			}
+			access.Method = tokens[pos+1].Text
+			access.Key = argumentValue(arguments[0])
+			accesses = append(accesses, access)
+		}
+	}
+	return accesses
+}
+
+// Call is a method call or an object creation, e.g. req.BadRequest("InvalidModule", "invalid module") or throw new NotFoundException(id)
+type Call struct {
+	Receiver  string // the expression the method is called on, e.g. req or this, empty for unqualified calls and object creations
+	Name      string // the method, or the type created without its qualifier
+	Arguments []Argument
+	IsNew     bool
+	IsThrown  bool // an object creation that is thrown
+	Line      int
+}
+
+// keywords that are followed by parentheses like a method call
+var callKeywords = map[string]bool{
+	"if": true, "for": true, "foreach": true, "while": true, "switch": true, "catch": true, "using": true, "lock": true,
+	"return": true, "await": true, "nameof": true, "typeof": true, "sizeof": true, "default": true, "when": true, "fixed": true,
+}
+
+// Calls finds the method calls and object creations in the tokens (e.g. a method body), in the order they are written.
+// Calls nested in the arguments of another call are returned as well
+func Calls(tokens []Token) []Call {
+	var p = parser{tokens: tokens, file: &File{}}
+	var calls = []Call{}
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i].Kind != Identifier || callKeywords[tokens[i].Text] {
+			continue
+		}
+		var open = i + 1
+		if tokens[open].Is("<") {
+			if close := matchingAngle(tokens, open); close+1 < len(tokens) && tokens[close].Is(">") {
+				open = close + 1
+			}
+		}
+		if !tokens[open].Is("(") {
+			continue
+		}
+
+		// the start of the receiver, e.g. req in req.Headers.Add or items in items.Where(x => x.Valid).Select
+		var start = i
+		for start > 1 && isMemberAccess(tokens[start-1]) {
+			var end = start - 2
+			if tokens[end].Is(")") || tokens[end].Is("]") {
+				end = matchingBackwards(tokens, end)
+				if end > 0 && tokens[end-1].Kind == Identifier {
+					end--
+				}
+			}
+			if end < 0 || tokens[end].Kind != Identifier && !tokens[end].Is("(") && !tokens[end].Is("[") {
+				break
+			}
+			start = end
+		}
+
+		var close = matching(tokens, open)
+		var call = Call{Name: tokens[i].Text, Arguments: p.parseArguments(tokens[open+1 : close]), Line: tokens[i].Line}
+		if len(call.Arguments) == 1 && len(call.Arguments[0].Tokens) < 1 {
+			call.Arguments = []Argument{}
+		}
+		// the start of the qualified type name of object creations, e.g. Models in new Models.Item()
+		var nameStart = i
+		for nameStart > 1 && tokens[nameStart-1].Is(".") && tokens[nameStart-2].Kind == Identifier {
+			nameStart -= 2
+		}
+		if nameStart > 0 && tokens[nameStart-1].Is("new") {
+			call.IsNew = true
+			call.IsThrown = nameStart > 1 && tokens[nameStart-2].Is("throw")
+		} else if start < i {
+			call.Receiver = joinTokens(tokens[start : i-1])
+		}
+		calls = append(calls, call)
+	}
+	return calls
+}
+
+// matchingBackwards returns the index of the token opening the bracket closed at index i, -1 if it isn't opened
+func matchingBackwards(tokens []Token, i int) int {
+	var depth = 0
+	for j := i; j >= 0; j-- {
+		if tokens[j].Kind != Punctuation {
+			continue
+		}
+		switch tokens[j].Text {
+		case ")", "]", "}":
+			depth++
+		case "(", "[", "{":
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,155 @@
+package csharp
+
+import (
+	"documentApi/utils"
+	"testing"
+)
+
+func Test_Chains_ReturnsInvocationChains(t *testing.T) {
+	// Arrange
+	var src = `var items = app.MapGroup("/items").RequireAuthorization();
+builder.Services.AddAuthorization();
+items.MapGet("/{id}", (int id) => db.Find(id)).Produces<Item>(200).WithName("GetItem");`
+
+	// Act
+	var chains = Chains(src)
+
+	// Assert
+	var roots = []string{}
+	for _, chain := range chains {
+		roots = append(roots, chain.Root)
+	}
+	utils.AssertSliceEqual(t, []string{"app", "builder.Services", "items", "db"}, roots)
+	utils.AssertStringEqual(t, "items", chains[0].Assignee)
+	utils.AssertEqual(t, 2, len(chains[0].Invocations))
+
+	var mapGet = chains[2]
+	utils.AssertStringEqual(t, "", mapGet.Assignee)
+	utils.AssertEqual(t, 3, mapGet.Line)
+	utils.AssertEqual(t, 3, len(mapGet.Invocations))
+	utils.AssertStringEqual(t, "MapGet", mapGet.Invocations[0].Name)
+	utils.AssertEqual(t, 2, len(mapGet.Invocations[0].Arguments))
+	utils.AssertStringEqual(t, "Item", mapGet.Invocations[1].TypeArguments)
+	utils.AssertStringEqual(t, `"GetItem"`, mapGet.Invocations[2].Arguments[0].Text)
+}
+
+func Test_ParseLambda_ReturnsParametersAndAttributes(t *testing.T) {
+	var tests = []struct {
+		name       string
+		src        string
+		isLambda   bool
+		parameters []string
+		attributes int
+	}{
+		{"typed parameters", `async ([FromBody] Item item, ItemDb db) => { return Results.Ok(); }`, true, []string{"Item item", "ItemDb db"}, 0},
+		{"attributes and return type", `[Authorize] static IResult (int id) => Results.Ok(id)`, true, []string{"int id"}, 1},
+		{"single untyped parameter", `id => Results.Ok(id)`, true, []string{" id"}, 0},
+		{"no parameters", `() => "ok"`, true, []string{}, 0},
+		{"method group", `ItemHandlers.GetItem`, false, []string{}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			var src = "Map(" + test.src + ")"
+			var p = parser{src: src, tokens: codeTokens(src), file: &File{}}
+			var argument = p.parseArguments(p.tokens[2 : len(p.tokens)-1])[0]
+
+			// Act
+			lambda, isLambda := ParseLambda(src, argument)
+
+			// Assert
+			if test.isLambda != isLambda {
+				t.Fatalf("expected isLambda to be %v, got %v", test.isLambda, isLambda)
+			}
+			var parameters = []string{}
+			for _, parameter := range lambda.Parameters {
+				parameters = append(parameters, parameter.Type+" "+parameter.Name)
+			}
+			utils.AssertSliceEqual(t, test.parameters, parameters)
+			utils.AssertEqual(t, test.attributes, len(lambda.Attributes))
+		})
+	}
+}
+
+func Test_GenericArgument_ReturnsTheTypeArgumentsOfTheCall(t *testing.T) {
+	var tests = []struct {
+		name     string
+		src      string
+		expected string
+		exists   bool
+	}{
+		{"generic call", `var item = await req.ReadFromJsonAsync<Item>();`, "Item", true},
+		{"nested type arguments", `var items = await req.ReadBodyAs<Dictionary<string, List<Item>>>();`, "Dictionary<string, List<Item>>", true},
+		{"other methods", `var items = await store.List<Item>(); var count = a < b;`, "", false},
+		{"comparison", `if (ReadBodyAs < limit) { }`, "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			typeArguments, exists := GenericArgument(codeTokens(test.src), "ReadFromJsonAsync", "ReadBodyAs")
+
+			// Assert
+			if test.exists != exists {
+				t.Fatalf("expected exists to be %v, got %v", test.exists, exists)
+			}
+			utils.AssertStringEqual(t, test.expected, typeArguments)
+		})
+	}
+}
+
+func Test_KeyAccesses_ReturnsLookupsOnTheMembers(t *testing.T) {
+	// Arrange
+	var tokens = codeTokens(`var locale = req.Query.Get("locale");
+var term = req.Query["q"];
+var query = HttpUtility.ParseQueryString(req.Url.Query);
+var page = query[Keys.Page];
+req.Headers.TryGetValues("X-SID", out var values);
+response.Headers["X-Total"] = "1";
+req.Query.Count();`)
+
+	// Act
+	var accesses = KeyAccesses(tokens, "Query", "ParseQueryString", "Headers")
+
+	// Assert
+	var keys = []string{}
+	for _, access := range accesses {
+		keys = append(keys, access.Member+":"+access.Method+":"+joinTokens(access.Key))
+	}
+	utils.AssertSliceEqual(t, []string{`Query:Get:"locale"`, `Query::"q"`, "ParseQueryString::Keys.Page", `Headers:TryGetValues:"X-SID"`, `Headers::"X-Total"`}, keys)
+	utils.AssertStringEqual(t, "req", accesses[0].Receiver)
+	utils.AssertStringEqual(t, "HttpUtility", accesses[2].Receiver)
+	utils.AssertEqual(t, 4, accesses[2].Line)
+	utils.AssertStringEqual(t, "response", accesses[4].Receiver)
+	if !accesses[4].IsWrite {
+		t.Error("expected the response header assignment to be a write")
+	}
+}
+
+func Test_Calls_ReturnsCallsAndObjectCreations(t *testing.T) {
+	// Arrange
+	var tokens = codeTokens(`if (string.IsNullOrWhiteSpace(id)) { return req.BadRequest("Invalid", "invalid id"); }
+var item = await this.Find<Item>(id);
+throw new Errors.ApiException(HttpStatusCode.NotFound, "Missing");
+return items.Where(x => x.Valid).Select(x => new Dto(x)).ToList();`)
+
+	// Act
+	var calls = Calls(tokens)
+
+	// Assert
+	var names = []string{}
+	for _, call := range calls {
+		names = append(names, call.Receiver+"|"+call.Name)
+	}
+	utils.AssertSliceEqual(t, []string{"string|IsNullOrWhiteSpace", "req|BadRequest", "this|Find", "|ApiException", "items|Where", "items.Where(x=>x.Valid)|Select", "|Dto", "items.Where(x=>x.Valid).Select(x=>newDto(x))|ToList"}, names)
+	utils.AssertEqual(t, 2, len(calls[1].Arguments))
+	utils.AssertStringEqual(t, `"invalid id"`, calls[1].Arguments[1].Text)
+	if !calls[3].IsNew || !calls[3].IsThrown {
+		t.Error("expected the exception to be thrown")
+	}
+	if !calls[6].IsNew || calls[6].IsThrown {
+		t.Error("expected the dto to be created")
+	}
+	utils.AssertEqual(t, 3, calls[3].Line)
+}
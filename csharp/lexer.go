@@ -0,0 +1,339 @@
+package csharp
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type TokenKind int
+
+const (
+	Identifier TokenKind = iota // includes keywords and verbatim identifiers (@class)
+	String                      // regular, verbatim, interpolated and raw string literals
+	Char
+	Number
+	Punctuation
+	Comment
+	Directive // a preprocessor directive line, e.g. #if DEBUG
+)
+
+type Token struct {
+	Kind   TokenKind
+	Text   string // the source text of the token
+	Offset int    // the byte offset of the token in the source
+	Line   int    // the 1 based line the token starts on
+}
+
+// is reports whether the token is punctuation or an identifier with the given text
+func (t Token) Is(text string) bool {
+	return (t.Kind == Punctuation || t.Kind == Identifier) && t.Text == text
+}
+
+// multi char operators that are kept as a single token, everything else is lexed one char at a time
+// (so the closing >> of nested generics are two separate tokens)
+var operators = []string{"=>", "==", "!=", "&&", "||", "??", "::", "?."}
+
+// Lex splits the c# source into tokens. Whitespace is dropped, comments and preprocessor
+// directives are kept as tokens so consumers can decide what to do with them
+func Lex(src string) []Token {
+	var tokens = []Token{}
+	var line = 1
+	var lineStart = true
+
+	for i := 0; i < len(src); {
+		var c = src[i]
+
+		if c == '\n' {
+			line++
+			lineStart = true
+			i++
+			continue
+		}
+		if c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v' {
+			i++
+			continue
+		}
+
+		var start = i
+		var kind TokenKind
+		switch {
+		case c == '#' && lineStart:
+			kind = Directive
+			i = lineEnd(src, i)
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			kind = Comment
+			i = lineEnd(src, i)
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			kind = Comment
+			var end = strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				i = len(src)
+			} else {
+				i += end + 4
+			}
+		case c == '\'':
+			kind = Char
+			i = skipCharLiteral(src, i)
+		case c == '"' || ((c == '$' || c == '@') && isStringStart(src, i)):
+			kind = String
+			i = skipString(src, i)
+		case c >= '0' && c <= '9' || (c == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9'):
+			kind = Number
+			i = skipNumber(src, i)
+		case c == '@' || c == '_' || isLetter(src, i):
+			kind = Identifier
+			i = skipIdentifier(src, i)
+		default:
+			kind = Punctuation
+			i++
+			for _, operator := range operators {
+				if strings.HasPrefix(src[start:], operator) {
+					i = start + len(operator)
+					break
+				}
+			}
+		}
+
+		if i <= start {
+			i = start + 1 // never get stuck on a malformed token
+		}
+		var text = src[start:i]
+		tokens = append(tokens, Token{Kind: kind, Text: strings.TrimRight(text, "\r"), Offset: start, Line: line})
+		line += strings.Count(text, "\n")
+		lineStart = false
+	}
+
+	return tokens
+}
+
+// lineEnd returns the index of the line break that ends the line containing index i (or the length of src)
+func lineEnd(src string, i int) int {
+	var end = strings.IndexByte(src[i:], '\n')
+	if end < 0 {
+		return len(src)
+	}
+	return i + end
+}
+
+func isLetter(src string, i int) bool {
+	var r, _ = utf8.DecodeRuneInString(src[i:])
+	return unicode.IsLetter(r)
+}
+
+func skipIdentifier(src string, i int) int {
+	if src[i] == '@' {
+		i++
+	}
+	for i < len(src) {
+		var r, size = utf8.DecodeRuneInString(src[i:])
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+func skipNumber(src string, i int) int {
+	for i < len(src) {
+		var c = src[i]
+		var isDigitChar = (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+		// only treat the dot as part of the number if it is followed by a digit (1.5 vs 1.ToString())
+		var isDecimalPoint = c == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9'
+		if !isDigitChar && !isDecimalPoint {
+			break
+		}
+		i++
+	}
+	return i
+}
+
+// skipCharLiteral returns the index after the char literal starting at i
+func skipCharLiteral(src string, i int) int {
+	var j = i + 1
+	if j < len(src) && src[j] == '\\' {
+		j++
+	}
+	j++
+	for j < len(src) && src[j] != '\'' && src[j] != '\n' {
+		j++
+	}
+	if j < len(src) && src[j] == '\'' {
+		j++
+	}
+	return j
+}
+
+// isStringStart reports whether a string literal starts at i, including its $ and @ prefixes
+func isStringStart(src string, i int) bool {
+	for i < len(src) && (src[i] == '$' || src[i] == '@') {
+		i++
+	}
+	return i < len(src) && src[i] == '"'
+}
+
+// skipString returns the index after the string literal starting at i.
+// Handles regular, verbatim (@), interpolated ($) and raw (""") strings
+func skipString(src string, i int) int {
+	var j = i
+	var interpolated = false
+	var verbatim = false
+	for j < len(src) && (src[j] == '$' || src[j] == '@') {
+		if src[j] == '$' {
+			interpolated = true
+		} else {
+			verbatim = true
+		}
+		j++
+	}
+
+	// raw string literals start with 3 or more quotes and end with the same amount
+	var quotes = 0
+	for j+quotes < len(src) && src[j+quotes] == '"' {
+		quotes++
+	}
+	if quotes >= 3 {
+		var end = strings.Index(src[j+quotes:], strings.Repeat(`"`, quotes))
+		if end < 0 {
+			return len(src)
+		}
+		return j + quotes + end + quotes
+	}
+
+	for j++; j < len(src); j++ {
+		switch src[j] {
+		case '\\':
+			if !verbatim {
+				j++
+			}
+		case '"':
+			if verbatim && j+1 < len(src) && src[j+1] == '"' {
+				j++
+				continue
+			}
+			return j + 1
+		case '\n':
+			if !verbatim {
+				return j // unterminated string
+			}
+		case '{':
+			if interpolated {
+				if j+1 < len(src) && src[j+1] == '{' {
+					j++
+					continue
+				}
+				j = skipInterpolationHole(src, j) - 1
+			}
+		}
+	}
+
+	return len(src)
+}
+
+// skipInterpolationHole returns the index after the closing brace of the interpolation starting at i
+func skipInterpolationHole(src string, i int) int {
+	var depth = 0
+	for j := i; j < len(src); {
+		switch {
+		case src[j] == '{':
+			depth++
+		case src[j] == '}':
+			depth--
+			if depth == 0 {
+				return j + 1
+			}
+		case src[j] == '\'':
+			j = skipCharLiteral(src, j)
+			continue
+		case isStringStart(src, j):
+			j = skipString(src, j)
+			continue
+		}
+		j++
+	}
+	return len(src)
+}
+
+// StringValue returns the content of a string literal token, with escapes resolved.
+// Interpolation holes are kept as they are written (e.g. {nameof(Foo)}).
+// Returns false if the token is not a string literal
+func StringValue(token Token) (string, bool) {
+	if token.Kind != String {
+		return "", false
+	}
+
+	var text = token.Text
+	var interpolated = false
+	var verbatim = false
+	for len(text) > 0 && (text[0] == '$' || text[0] == '@') {
+		if text[0] == '$' {
+			interpolated = true
+		} else {
+			verbatim = true
+		}
+		text = text[1:]
+	}
+
+	var quotes = 0
+	for quotes < len(text) && text[quotes] == '"' {
+		quotes++
+	}
+	if quotes >= 3 {
+		if len(text) < quotes*2 {
+			return "", true
+		}
+		return rawStringValue(text[quotes : len(text)-quotes]), true
+	}
+
+	text = strings.TrimPrefix(text, `"`)
+	text = strings.TrimSuffix(text, `"`)
+	if verbatim {
+		text = strings.ReplaceAll(text, `""`, `"`)
+	} else {
+		text = unescape(text)
+	}
+	if interpolated {
+		text = strings.ReplaceAll(strings.ReplaceAll(text, "{{", "{"), "}}", "}")
+	}
+
+	return text, true
+}
+
+// rawStringValue strips the leading line break and the common indentation of multi line raw strings
+func rawStringValue(text string) string {
+	if !strings.Contains(text, "\n") {
+		return text
+	}
+
+	var lines = strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var indentation = lines[len(lines)-1] // the whitespace before the closing quotes
+	lines = lines[1 : len(lines)-1]
+	for i := range lines {
+		lines[i] = strings.TrimPrefix(lines[i], indentation)
+	}
+	return strings.Join(lines, "\n")
+}
+
+var escapes = map[byte]string{
+	'n': "\n", 't': "\t", 'r': "\r", '0': "\x00", '\\': "\\", '"': "\"", '\'': "'", 'a': "\a", 'b': "\b", 'f': "\f", 'v': "\v",
+}
+
+func unescape(text string) string {
+	if !strings.Contains(text, `\`) {
+		return text
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) {
+			if escaped, exists := escapes[text[i+1]]; exists {
+				builder.WriteString(escaped)
+				i++
+				continue
+			}
+		}
+		builder.WriteByte(text[i])
+	}
+	return builder.String()
+}
@@ -0,0 +1,115 @@
+package csharp
+
+import (
+	"documentApi/utils"
+	"testing"
+)
+
+func Test_Lex_ReturnsExpectedTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected []string
+	}{
+		{
+			name:     "Nested generics",
+			src:      "Task<IActionResult<Foo>>",
+			expected: []string{"Task", "<", "IActionResult", "<", "Foo", ">", ">"},
+		},
+		{
+			name:     "Strings containing brackets",
+			src:      `Route = ")]" + @"a""]"`,
+			expected: []string{"Route", "=", `")]"`, "+", `@"a""]"`},
+		},
+		{
+			name:     "Raw and interpolated strings",
+			src:      `$"{nameof(Foo)}/{"}"}" """a"b"""`,
+			expected: []string{`$"{nameof(Foo)}/{"}"}"`, `"""a"b"""`},
+		},
+		{
+			name:     "Chars and numbers",
+			src:      `')' 1.5 0xFF x.ToString()`,
+			expected: []string{`')'`, "1.5", "0xFF", "x", ".", "ToString", "(", ")"},
+		},
+		{
+			name:     "Comments and directives",
+			src:      "#if DEBUG\na // b\n/* c */ d",
+			expected: []string{"#if DEBUG", "a", "// b", "/* c */", "d"},
+		},
+		{
+			name:     "Operators",
+			src:      "a => b ?? c?.d",
+			expected: []string{"a", "=>", "b", "??", "c", "?.", "d"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var texts = []string{}
+			for _, token := range Lex(test.src) {
+				texts = append(texts, token.Text)
+			}
+			utils.AssertSliceEqual(t, test.expected, texts)
+		})
+	}
+}
+
+func Test_Lex_ReturnsTokenLines(t *testing.T) {
+	// Arrange
+	var src = "a\n@\"b\nc\"\nd"
+
+	// Act
+	var tokens = Lex(src)
+
+	// Assert
+	utils.AssertEqual(t, 3, len(tokens))
+	utils.AssertEqual(t, 1, tokens[0].Line)
+	utils.AssertEqual(t, 2, tokens[1].Line)
+	utils.AssertEqual(t, 4, tokens[2].Line)
+}
+
+func Test_StringValue_ReturnsLiteralContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			name:     "Regular",
+			src:      `"a\"b\\c"`,
+			expected: `a"b\c`,
+		},
+		{
+			name:     "Verbatim",
+			src:      `@"C:\temp\""file"""`,
+			expected: `C:\temp\"file"`,
+		},
+		{
+			name:     "Interpolated",
+			src:      `$"{{literal}} {nameof(Foo)}"`,
+			expected: `{literal} {nameof(Foo)}`,
+		},
+		{
+			name:     "Raw single line",
+			src:      `"""a "quoted" b"""`,
+			expected: `a "quoted" b`,
+		},
+		{
+			name:     "Raw multi line",
+			src:      "\"\"\"\n    {\n      \"a\": 1\n    }\n    \"\"\"",
+			expected: "{\n  \"a\": 1\n}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tokens = Lex(test.src)
+			utils.AssertEqual(t, 1, len(tokens))
+			value, isString := StringValue(tokens[0])
+			if !isString {
+				t.Errorf("expected %s to be a string", test.src)
+			}
+			utils.AssertStringEqual(t, test.expected, value)
+		})
+	}
+}
@@ -0,0 +1,691 @@
+package csharp
+
+import (
+	"strings"
+)
+
+// File is the declarations found in a c# source file. It is not a full syntax tree,
+// only the parts needed to document the endpoints (types, their members, attributes and signatures)
+type File struct {
+	Types   []TypeDeclaration
+	Methods []Method
+	Fields  []Field // fields, constants and properties
+
+	// the comment blocks of the source, Parse is given the source without comments (see StripComments)
+	// so they are collected separately with CommentBlocks
+	Comments []CommentBlock
+}
+
+type TypeDeclaration struct {
+	Name           string
+	Kind           string // class, struct, interface, enum or record
+	Namespace      string
+	Parent         string // the enclosing type for nested types
+	Modifiers      []string
+	Attributes     []Attribute
+	TypeParameters []string // the generic type parameters, e.g. T in PagedResult<T>
+	BaseTypes      []string
+	Parameters     []Parameter // primary constructor parameters
+	EnumMembers    []string
+	Line           int
+}
+
+type Method struct {
+	Name          string
+	ReturnType    string
+	Namespace     string
+	TypeName      string // the enclosing type
+	Modifiers     []string
+	Attributes    []Attribute
+	Parameters    []Parameter
+	Body          []Token // the tokens between the braces, or the expression of an expression bodied method
+	IsConstructor bool
+	Line          int
+}
+
+type Field struct {
+	Name        string
+	Type        string
+	Namespace   string
+	TypeName    string // the enclosing type
+	Modifiers   []string
+	Attributes  []Attribute
+	Initializer []Token // the tokens after the = (excluding the ;)
+	IsProperty  bool
+	Line        int
+}
+
+type Parameter struct {
+	Name       string
+	Type       string
+	Modifiers  []string // this, ref, out, in, params
+	Attributes []Attribute
+	Default    []Token
+}
+
+type Attribute struct {
+	Name      string // as written, e.g. Function or Microsoft.Azure.Functions.Worker.Function
+	Arguments []Argument
+	Text      string // the source of the attribute in brackets, e.g. [Function("Name")]
+	Line      int
+}
+
+type Argument struct {
+	Name       string // empty for positional arguments
+	IsProperty bool   // named with "=" (a property setter) rather than ":" (a named constructor argument)
+	Tokens     []Token
+	Text       string // the source of the argument value
+}
+
+var modifiers = map[string]bool{
+	"public": true, "private": true, "protected": true, "internal": true, "static": true, "async": true,
+	"abstract": true, "virtual": true, "override": true, "sealed": true, "readonly": true, "const": true,
+	"extern": true, "unsafe": true, "volatile": true, "new": true, "partial": true, "required": true, "file": true,
+}
+
+var typeKinds = map[string]bool{
+	"class": true, "struct": true, "interface": true, "enum": true, "record": true,
+}
+
+var parameterModifiers = map[string]bool{
+	"this": true, "ref": true, "out": true, "in": true, "params": true, "scoped": true, "readonly": true,
+}
+
+var closers = map[string]string{
+	"(": ")", "[": "]", "{": "}",
+}
+
+// ShortName returns the attribute name without namespace qualification or the "Attribute" suffix
+func (a Attribute) ShortName() string {
+	var name = a.Name
+	if i := strings.LastIndex(name, "."); i > -1 {
+		name = name[i+1:]
+	}
+	if name != "Attribute" {
+		name = strings.TrimSuffix(name, "Attribute")
+	}
+	return name
+}
+
+// Argument returns an argument by either its constructor position or its name.
+// Properties (Name = value) do not count towards the position of constructor arguments
+func (a Attribute) Argument(position int, name string) (Argument, bool) {
+	var index = 0
+	for _, argument := range a.Arguments {
+		if len(name) > 0 && strings.EqualFold(argument.Name, name) {
+			return argument, true
+		}
+		if argument.IsProperty {
+			continue
+		}
+		if index == position && len(argument.Name) == 0 {
+			return argument, true
+		}
+		index++
+	}
+	return Argument{}, false
+}
+
+// Attribute returns the first attribute matching one of the names (see Attribute.ShortName)
+func (m Method) Attribute(names ...string) (Attribute, bool) {
+	return findAttribute(m.Attributes, names)
+}
+
+// Attribute returns the first attribute matching one of the names (see Attribute.ShortName)
+func (p Parameter) Attribute(names ...string) (Attribute, bool) {
+	return findAttribute(p.Attributes, names)
+}
+
+// Attribute returns the first attribute matching one of the names (see Attribute.ShortName)
+func (f Field) Attribute(names ...string) (Attribute, bool) {
+	return findAttribute(f.Attributes, names)
+}
+
+// Attribute returns the first attribute matching one of the names (see Attribute.ShortName)
+func (t TypeDeclaration) Attribute(names ...string) (Attribute, bool) {
+	return findAttribute(t.Attributes, names)
+}
+
+func findAttribute(attributes []Attribute, names []string) (Attribute, bool) {
+	for _, attribute := range attributes {
+		for _, name := range names {
+			if attribute.ShortName() == name {
+				return attribute, true
+			}
+		}
+	}
+	return Attribute{}, false
+}
+
+// HasModifier reports whether the method is declared with the modifier
+func (m Method) HasModifier(modifier string) bool {
+	for _, m := range m.Modifiers {
+		if m == modifier {
+			return true
+		}
+	}
+	return false
+}
+
+// HasModifier reports whether the field is declared with the modifier
+func (f Field) HasModifier(modifier string) bool {
+	for _, m := range f.Modifiers {
+		if m == modifier {
+			return true
+		}
+	}
+	return false
+}
+
+// HasModifier reports whether the type is declared with the modifier
+func (t TypeDeclaration) HasModifier(modifier string) bool {
+	for _, m := range t.Modifiers {
+		if m == modifier {
+			return true
+		}
+	}
+	return false
+}
+
+type parser struct {
+	src    string
+	tokens []Token
+	pos    int
+	file   *File
+}
+
+// Parse extracts the type and member declarations of the c# source.
+// Comments and preprocessor directives are ignored, use StripComments first to drop disabled code
+func Parse(src string) File {
+	var p = parser{src: src, tokens: codeTokens(src), file: &File{}}
+	p.parseDeclarations("", "", false)
+	return *p.file
+}
+
+// ParseAttribute parses the source of a single attribute, e.g. [Function("Name")] or Function("Name")
+func ParseAttribute(src string) (Attribute, bool) {
+	var p = parser{src: src, tokens: codeTokens(src), file: &File{}}
+	if p.at("[") {
+		var attributes = p.parseAttributeSection()
+		if len(attributes) > 0 {
+			return attributes[0], true
+		}
+		return Attribute{}, false
+	}
+	return p.parseAttribute(p.tokens)
+}
+
+// codeTokens lexes the source, dropping the comments and preprocessor directives
+func codeTokens(src string) []Token {
+	var tokens = []Token{}
+	for _, token := range Lex(src) {
+		if token.Kind != Comment && token.Kind != Directive {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) current() Token {
+	if p.done() {
+		return Token{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) at(text string) bool {
+	return !p.done() && p.tokens[p.pos].Is(text)
+}
+
+// text returns the source text spanned by the tokens, rebuilt from the tokens when only they are parsed (e.g. a method body)
+func (p *parser) text(tokens []Token) string {
+	if len(tokens) < 1 {
+		return ""
+	}
+	if len(p.src) < 1 {
+		return joinTokens(tokens)
+	}
+	var last = tokens[len(tokens)-1]
+	return p.src[tokens[0].Offset : last.Offset+len(last.Text)]
+}
+
+// matching returns the index of the token closing the bracket at index i
+func matching(tokens []Token, i int) int {
+	var depth = 0
+	for j := i; j < len(tokens); j++ {
+		if tokens[j].Kind != Punctuation {
+			continue
+		}
+		switch tokens[j].Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+// matchingAngle returns the index of the > closing the generic argument list at index i
+func matchingAngle(tokens []Token, i int) int {
+	var depth = 0
+	for j := i; j < len(tokens); j++ {
+		switch {
+		case tokens[j].Is("<"):
+			depth++
+		case tokens[j].Is(">"):
+			depth--
+			if depth == 0 {
+				return j
+			}
+		case tokens[j].Is("(") || tokens[j].Is("["):
+			j = matching(tokens, j)
+		case tokens[j].Is(";") || tokens[j].Is("{") || tokens[j].Is("}") || tokens[j].Is(")"):
+			return j - 1 // not a generic argument list
+		}
+	}
+	return len(tokens) - 1
+}
+
+// split splits the tokens on the separator at depth 0, optionally treating <> as brackets
+func split(tokens []Token, separator string, angles bool) [][]Token {
+	var parts = [][]Token{}
+	var start = 0
+	for i := 0; i < len(tokens); i++ {
+		switch {
+		case tokens[i].Kind == Punctuation && closers[tokens[i].Text] != "":
+			i = matching(tokens, i)
+		case angles && tokens[i].Is("<"):
+			i = matchingAngle(tokens, i)
+		case tokens[i].Is(separator):
+			parts = append(parts, tokens[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		parts = append(parts, tokens[start:])
+	}
+	return parts
+}
+
+// skipUntil advances to the first of the tokens at depth 0, skipping balanced brackets
+func (p *parser) skipUntil(texts ...string) {
+	for !p.done() {
+		for _, text := range texts {
+			if p.at(text) {
+				return
+			}
+		}
+		if p.current().Kind == Punctuation && closers[p.current().Text] != "" {
+			p.pos = matching(p.tokens, p.pos)
+		}
+		p.pos++
+	}
+}
+
+// skipStatement skips a (top level) statement, ending at a ; or after a block
+func (p *parser) skipStatement() {
+	for !p.done() {
+		if p.at(";") {
+			p.pos++
+			return
+		}
+		if p.at("{") {
+			p.pos = matching(p.tokens, p.pos) + 1
+			return
+		}
+		if p.at("}") {
+			return
+		}
+		if p.current().Kind == Punctuation && closers[p.current().Text] != "" {
+			p.pos = matching(p.tokens, p.pos)
+		}
+		p.pos++
+	}
+}
+
+func (p *parser) parseDeclarations(namespace string, typeName string, endAtBrace bool) {
+	var attributes = []Attribute{}
+	var declarationModifiers = []string{}
+
+	for !p.done() {
+		var token = p.current()
+		switch {
+		case token.Is("}"):
+			p.pos++
+			if endAtBrace {
+				return
+			}
+		case token.Is(";"):
+			p.pos++
+		case token.Is("["):
+			attributes = append(attributes, p.parseAttributeSection()...)
+		case token.Is("using") && len(typeName) < 1, token.Is("extern") && len(typeName) < 1, token.Is("delegate"):
+			p.skipUntil(";")
+		case token.Is("namespace"):
+			p.pos++
+			var start = p.pos
+			p.skipUntil("{", ";")
+			var name = strings.ReplaceAll(p.text(p.tokens[start:p.pos]), " ", "")
+			if p.at(";") { // file scoped namespace
+				namespace = name
+				p.pos++
+			} else {
+				p.pos++
+				p.parseDeclarations(name, "", true)
+			}
+			attributes, declarationModifiers = []Attribute{}, []string{}
+		case token.Kind == Identifier && modifiers[token.Text] && !(token.Is("new") && len(typeName) < 1):
+			declarationModifiers = append(declarationModifiers, token.Text)
+			p.pos++
+		case token.Kind == Identifier && typeKinds[token.Text]:
+			p.parseType(attributes, declarationModifiers, namespace, typeName)
+			attributes, declarationModifiers = []Attribute{}, []string{}
+		case len(typeName) < 1 && len(declarationModifiers) < 1:
+			// only types can be declared outside of a type, anything else is a top level statement
+			p.skipStatement()
+			attributes = []Attribute{}
+		default:
+			p.parseMember(attributes, declarationModifiers, namespace, typeName)
+			attributes, declarationModifiers = []Attribute{}, []string{}
+		}
+	}
+}
+
+func (p *parser) parseType(attributes []Attribute, typeModifiers []string, namespace string, parent string) {
+	var declaration = TypeDeclaration{
+		Kind:       p.current().Text,
+		Namespace:  namespace,
+		Parent:     parent,
+		Modifiers:  typeModifiers,
+		Attributes: attributes,
+		Line:       p.current().Line,
+	}
+	p.pos++
+	if declaration.Kind == "record" && (p.at("class") || p.at("struct")) {
+		p.pos++
+	}
+	if p.current().Kind == Identifier {
+		declaration.Name = p.current().Text
+		p.pos++
+	}
+	if p.at("<") {
+		var end = matchingAngle(p.tokens, p.pos)
+		for _, typeParameter := range split(p.tokens[p.pos+1:end], ",", true) {
+			// drop the variance, e.g. out T
+			if len(typeParameter) > 0 {
+				declaration.TypeParameters = append(declaration.TypeParameters, typeParameter[len(typeParameter)-1].Text)
+			}
+		}
+		p.pos = end + 1
+	}
+	if p.at("(") {
+		declaration.Parameters = p.parseParameters()
+	}
+	if p.at(":") {
+		p.pos++
+		var start = p.pos
+		p.skipUntil("{", ";", "where")
+		for _, baseType := range split(p.tokens[start:p.pos], ",", true) {
+			// drop the arguments passed to the base record constructor
+			if i := indexOf(baseType, "("); i > -1 {
+				baseType = baseType[:i]
+			}
+			declaration.BaseTypes = append(declaration.BaseTypes, p.text(baseType))
+		}
+	}
+	p.skipUntil("{", ";")
+
+	if p.at("{") {
+		p.pos++
+		if declaration.Kind == "enum" {
+			var end = matching(p.tokens, p.pos-1)
+			for _, member := range split(p.tokens[p.pos:end], ",", false) {
+				// skip any attributes on the member
+				for len(member) > 0 && member[0].Is("[") {
+					member = member[matching(member, 0)+1:]
+				}
+				if len(member) > 0 && member[0].Kind == Identifier {
+					declaration.EnumMembers = append(declaration.EnumMembers, member[0].Text)
+				}
+			}
+			p.pos = end + 1
+		} else {
+			p.file.Types = append(p.file.Types, declaration)
+			p.parseDeclarations(namespace, declaration.Name, true)
+			return
+		}
+	} else {
+		p.pos++
+	}
+	p.file.Types = append(p.file.Types, declaration)
+}
+
+func indexOf(tokens []Token, text string) int {
+	for i, token := range tokens {
+		if token.Is(text) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *parser) parseMember(attributes []Attribute, memberModifiers []string, namespace string, typeName string) {
+	var head = []Token{}
+	var nameIndex = -1
+	var line = p.current().Line
+
+	// collect the return type and name, up to the token that decides what kind of member this is
+	for !p.done() {
+		var token = p.current()
+		if token.Is("(") && nameIndex < 0 {
+			// tuple return type
+			var end = matching(p.tokens, p.pos)
+			head = append(head, p.tokens[p.pos:end+1]...)
+			p.pos = end + 1
+			continue
+		}
+		if token.Is("<") {
+			var end = matchingAngle(p.tokens, p.pos)
+			head = append(head, p.tokens[p.pos:end+1]...)
+			p.pos = end + 1
+			continue
+		}
+		if token.Is("[") && !(len(head) > 0 && head[len(head)-1].Is("this")) {
+			// array type
+			var end = matching(p.tokens, p.pos)
+			head = append(head, p.tokens[p.pos:end+1]...)
+			p.pos = end + 1
+			continue
+		}
+		if token.Is("(") || token.Is("[") || token.Is("{") || token.Is("=") || token.Is(";") || token.Is("=>") || token.Is(",") || token.Is("}") {
+			break
+		}
+		if token.Kind == Identifier {
+			nameIndex = len(head)
+			line = token.Line
+		}
+		head = append(head, token)
+		p.pos++
+	}
+
+	if nameIndex < 0 || p.done() || indexOf(head, "operator") > -1 || p.at("}") {
+		// operators and anything we don't understand are skipped
+		p.skipStatement()
+		return
+	}
+
+	var name = head[nameIndex].Text
+	var memberType = p.text(head[:nameIndex])
+
+	if p.at("(") {
+		var method = Method{
+			Name:          name,
+			ReturnType:    memberType,
+			Namespace:     namespace,
+			TypeName:      typeName,
+			Modifiers:     memberModifiers,
+			Attributes:    attributes,
+			IsConstructor: nameIndex == 0 && name == typeName,
+			Line:          line,
+		}
+		method.Parameters = p.parseParameters()
+		// skip generic constraints and constructor initializers
+		p.skipUntil("{", "=>", ";")
+		if p.at("{") {
+			var end = matching(p.tokens, p.pos)
+			method.Body = p.tokens[p.pos+1 : end]
+			p.pos = end + 1
+		} else if p.at("=>") {
+			p.pos++
+			var start = p.pos
+			p.skipUntil(";")
+			method.Body = p.tokens[start:p.pos]
+			p.pos++
+		} else {
+			p.pos++
+		}
+		p.file.Methods = append(p.file.Methods, method)
+		return
+	}
+
+	var field = Field{
+		Name:       name,
+		Type:       memberType,
+		Namespace:  namespace,
+		TypeName:   typeName,
+		Modifiers:  memberModifiers,
+		Attributes: attributes,
+		Line:       line,
+	}
+	if p.at("[") { // indexer
+		p.pos = matching(p.tokens, p.pos) + 1
+	}
+	if p.at("{") { // property accessors
+		field.IsProperty = true
+		p.pos = matching(p.tokens, p.pos) + 1
+		if !p.at("=") { // no initializer, the property ends at the accessors
+			p.file.Fields = append(p.file.Fields, field)
+			return
+		}
+	}
+	if p.at("=>") { // expression bodied property
+		field.IsProperty = true
+		p.skipUntil(";")
+		p.pos++
+	} else if p.at("=") {
+		p.pos++
+		var start = p.pos
+		p.skipUntil(";", "}")
+		field.Initializer = p.tokens[start:p.pos]
+		if p.at(";") {
+			p.pos++
+		}
+	} else if !p.at("}") {
+		p.skipUntil(";", "}")
+		if p.at(";") {
+			p.pos++
+		}
+	}
+	p.file.Fields = append(p.file.Fields, field)
+}
+
+// parseParameters parses the parameter list starting at the current (
+func (p *parser) parseParameters() []Parameter {
+	var end = matching(p.tokens, p.pos)
+	var parameters = []Parameter{}
+
+	for _, tokens := range split(p.tokens[p.pos+1:end], ",", true) {
+		var parameter = Parameter{}
+		for len(tokens) > 0 && tokens[0].Is("[") {
+			var close = matching(tokens, 0)
+			var section = parser{src: p.src, tokens: tokens[:close+1], file: p.file}
+			parameter.Attributes = append(parameter.Attributes, section.parseAttributeSection()...)
+			tokens = tokens[close+1:]
+		}
+		for len(tokens) > 0 && parameterModifiers[tokens[0].Text] && tokens[0].Kind == Identifier {
+			parameter.Modifiers = append(parameter.Modifiers, tokens[0].Text)
+			tokens = tokens[1:]
+		}
+		if i := indexOf(tokens, "="); i > -1 {
+			parameter.Default = tokens[i+1:]
+			tokens = tokens[:i]
+		}
+		if len(tokens) > 0 {
+			parameter.Name = tokens[len(tokens)-1].Text
+			parameter.Type = p.text(tokens[:len(tokens)-1])
+		}
+		parameters = append(parameters, parameter)
+	}
+
+	p.pos = end + 1
+	return parameters
+}
+
+// parseAttributeSection parses all the attributes in the [] at the current position
+func (p *parser) parseAttributeSection() []Attribute {
+	var end = matching(p.tokens, p.pos)
+	var tokens = p.tokens[p.pos+1 : end]
+	p.pos = end + 1
+
+	// drop the attribute target, e.g. [return: ...]
+	if len(tokens) > 1 && tokens[0].Kind == Identifier && tokens[1].Is(":") {
+		tokens = tokens[2:]
+	}
+
+	var attributes = []Attribute{}
+	for _, attributeTokens := range split(tokens, ",", false) {
+		if attribute, ok := p.parseAttribute(attributeTokens); ok {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return attributes
+}
+
+// parseAttribute parses a single attribute (without the brackets), e.g. Function("Name")
+func (p *parser) parseAttribute(tokens []Token) (Attribute, bool) {
+	if len(tokens) < 1 || tokens[0].Kind != Identifier {
+		return Attribute{}, false
+	}
+
+	var attribute = Attribute{
+		Text: "[" + p.text(tokens) + "]",
+		Line: tokens[0].Line,
+	}
+
+	var open = indexOf(tokens, "(")
+	if open < 0 {
+		attribute.Name = p.text(tokens)
+		return attribute, true
+	}
+	attribute.Name = p.text(tokens[:open])
+
+	var close = matching(tokens, open)
+	attribute.Arguments = p.parseArguments(tokens[open+1 : close])
+
+	return attribute, true
+}
+
+// parseArguments parses the comma separated arguments of an attribute or invocation (without the parentheses)
+func (p *parser) parseArguments(tokens []Token) []Argument {
+	var arguments = []Argument{}
+	for _, argumentTokens := range split(tokens, ",", false) {
+		var argument = Argument{}
+		if len(argumentTokens) > 2 && argumentTokens[0].Kind == Identifier && (argumentTokens[1].Is("=") || argumentTokens[1].Is(":")) {
+			argument.Name = argumentTokens[0].Text
+			argument.IsProperty = argumentTokens[1].Is("=")
+			argumentTokens = argumentTokens[2:]
+		}
+		argument.Tokens = argumentTokens
+		argument.Text = p.text(argumentTokens)
+		arguments = append(arguments, argument)
+	}
+	return arguments
+}
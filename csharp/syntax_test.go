@@ -0,0 +1,73 @@
+package csharp
+
+import (
+	"documentApi/utils"
+	"os"
+	"testing"
+)
+
+func Test_Parse_ReturnsMethodsWithAttributesAndSignatures(t *testing.T) {
+	// Arrange
+	fileData, _ := os.ReadFile("../test_assets/complex_signatures.cs")
+
+	// Act
+	var file = Parse(string(fileData))
+
+	// Assert
+	utils.AssertEqual(t, 5, len(file.Methods))
+
+	var generic = file.Methods[0]
+	utils.AssertStringEqual(t, "GetGeneric", generic.Name)
+	utils.AssertStringEqual(t, "ComplexTriggers", generic.TypeName)
+	utils.AssertStringEqual(t, "Repo.Functions", generic.Namespace)
+	utils.AssertStringEqual(t, "Task<ActionResult<IEnumerable<Dictionary<string, List<int>>>>>", generic.ReturnType)
+	utils.AssertSliceEqual(t, []string{"public", "async"}, generic.Modifiers)
+	utils.AssertEqual(t, 2, len(generic.Attributes))
+	utils.AssertEqual(t, 2, len(generic.Parameters))
+	utils.AssertStringEqual(t, "req", generic.Parameters[0].Name)
+	utils.AssertStringEqual(t, "HttpRequestData", generic.Parameters[0].Type)
+	utils.AssertStringEqual(t, "HttpTrigger", generic.Parameters[0].Attributes[0].Name)
+	utils.AssertEqual(t, 4, len(generic.Parameters[0].Attributes[0].Arguments))
+
+	var multiLine = file.Methods[1]
+	utils.AssertStringEqual(t, "MultiLineAttributes", multiLine.Name)
+	utils.AssertEqual(t, 3, len(multiLine.Attributes))
+	utils.AssertStringEqual(t, "Task<IActionResult<Foo>>", multiLine.ReturnType)
+	utils.AssertMin(t, 1, len(multiLine.Body))
+
+	var tuple = file.Methods[3]
+	utils.AssertStringEqual(t, "Helper", tuple.Name)
+	utils.AssertStringEqual(t, "(int Count, string Name)", tuple.ReturnType)
+
+	utils.AssertEqual(t, 1, len(file.Fields))
+	utils.AssertStringEqual(t, "Separator", file.Fields[0].Name)
+	utils.AssertSliceEqual(t, []string{"private", "const"}, file.Fields[0].Modifiers)
+
+	utils.AssertEqual(t, 1, len(file.Types))
+	utils.AssertSliceEqual(t, []string{"TriggerBase<ComplexTriggers>", "IDisposable"}, file.Types[0].BaseTypes)
+	utils.AssertEqual(t, 1, len(file.Types[0].Parameters))
+}
+
+func Test_ParseAttribute_ReturnsNamedAndPositionalArguments(t *testing.T) {
+	// Arrange
+	var src = `[OpenApiResponseWithBody(statusCode: HttpStatusCode.OK, "application/json", typeof(Foo), Description = "a, b")]`
+
+	// Act
+	attribute, isAttribute := ParseAttribute(src)
+
+	// Assert
+	if !isAttribute {
+		t.Fatal("expected an attribute")
+	}
+	utils.AssertStringEqual(t, "OpenApiResponseWithBody", attribute.ShortName())
+	utils.AssertEqual(t, 4, len(attribute.Arguments))
+
+	statusCode, _ := attribute.Argument(0, "statusCode")
+	utils.AssertStringEqual(t, "HttpStatusCode.OK", statusCode.Text)
+	contentType, _ := attribute.Argument(1, "")
+	utils.AssertStringEqual(t, `"application/json"`, contentType.Text)
+	bodyType, _ := attribute.Argument(2, "bodyType")
+	utils.AssertStringEqual(t, "typeof(Foo)", bodyType.Text)
+	description, _ := attribute.Argument(-1, "Description")
+	utils.AssertStringEqual(t, `"a, b"`, description.Text)
+}
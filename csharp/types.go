@@ -0,0 +1,161 @@
+package csharp
+
+import (
+	"strings"
+)
+
+// Types is an index of the classes, structs, records, interfaces and enums declared across a repo,
+// used to describe the models the endpoints read and return
+type Types struct {
+	types map[string][]*Model // keyed by the type name
+}
+
+// Model is a type declaration together with the members declared in all of its (partial) parts
+type Model struct {
+	Declaration TypeDeclaration
+	Members     []Field // fields and properties
+	Methods     []Method
+}
+
+// TypeName is a c# type reference split into its parts, e.g. List<Item>? or Models.Item[]
+type TypeName struct {
+	Name      string   // without the qualifier or generic arguments, e.g. List
+	Qualifier string   // the namespace or enclosing type, e.g. System.Collections.Generic
+	Arguments []string // the generic arguments, e.g. Item
+	Element   string   // the element type of arrays, the other parts are empty for arrays
+	Nullable  bool
+}
+
+func NewTypes() *Types {
+	return &Types{types: make(map[string][]*Model)}
+}
+
+// Add indexes the types declared in the file, the parts of partial types are merged into one
+func (t *Types) Add(file File) {
+	var models = make(map[string]*Model) // the models of this file keyed by their namespace qualified name
+	for _, declaration := range file.Types {
+		var qualifiedName = declaration.Namespace + "." + declaration.Name
+		var model, exists = t.find(declaration.Name, declaration.Namespace)
+		if !exists {
+			model = &Model{Declaration: declaration}
+			t.types[declaration.Name] = append(t.types[declaration.Name], model)
+		} else if declaration.HasModifier("partial") {
+			model.Declaration.BaseTypes = append(model.Declaration.BaseTypes, declaration.BaseTypes...)
+			model.Declaration.Attributes = append(model.Declaration.Attributes, declaration.Attributes...)
+		}
+		models[qualifiedName] = model
+	}
+
+	for _, field := range file.Fields {
+		if model, exists := models[field.Namespace+"."+field.TypeName]; exists {
+			model.Members = append(model.Members, field)
+		}
+	}
+	for _, method := range file.Methods {
+		if model, exists := models[method.Namespace+"."+method.TypeName]; exists {
+			model.Methods = append(model.Methods, method)
+		}
+	}
+}
+
+// find returns the model declared with the name in exactly that namespace
+func (t *Types) find(name string, namespace string) (*Model, bool) {
+	for _, model := range t.types[name] {
+		if model.Declaration.Namespace == namespace {
+			return model, true
+		}
+	}
+	return nil, false
+}
+
+// Len returns the number of indexed types
+func (t *Types) Len() int {
+	if t == nil {
+		return 0
+	}
+	var count = 0
+	for _, models := range t.types {
+		count += len(models)
+	}
+	return count
+}
+
+// Lookup finds the type referenced by name (optionally qualified, without generic arguments) from code in the namespace.
+// Like the compiler, the namespace and its parents are searched first. Types from other namespaces
+// (imported with a using directive) are only resolved if the name is not ambiguous
+func (t *Types) Lookup(name string, namespace string) (*Model, bool) {
+	if t == nil {
+		return nil, false
+	}
+
+	var qualifier = ""
+	if i := strings.LastIndex(name, "."); i > -1 {
+		qualifier, name = strings.TrimPrefix(name[:i], "global::"), name[i+1:]
+	}
+
+	var candidates = []*Model{}
+	for _, model := range t.types[name] {
+		if len(qualifier) < 1 || model.Declaration.Namespace == qualifier || strings.HasSuffix(model.Declaration.Namespace, "."+qualifier) || model.Declaration.Parent == qualifier {
+			candidates = append(candidates, model)
+		}
+	}
+
+	for scope := namespace; len(scope) > 0; {
+		for _, candidate := range candidates {
+			if candidate.Declaration.Namespace == scope {
+				return candidate, true
+			}
+		}
+		var i = strings.LastIndex(scope, ".")
+		if i < 0 {
+			break
+		}
+		scope = scope[:i]
+	}
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+	return nil, false
+}
+
+// ParseTypeName splits a type reference into its parts, e.g. Dictionary<string, List<int>>? or int[]
+func ParseTypeName(src string) TypeName {
+	var p = parser{src: src, tokens: codeTokens(src), file: &File{}}
+	var tokens = p.tokens
+	var typeName = TypeName{}
+
+	if len(tokens) > 0 && tokens[len(tokens)-1].Is("?") {
+		typeName.Nullable = true
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) < 1 {
+		return typeName
+	}
+
+	// arrays, including the jagged and multi dimensional ones, e.g. int[][] or int[,]
+	if tokens[len(tokens)-1].Is("]") {
+		for open := len(tokens) - 2; open >= 0; open-- {
+			if tokens[open].Is("[") && matching(tokens, open) == len(tokens)-1 {
+				typeName.Element = p.text(tokens[:open])
+				return typeName
+			}
+		}
+	}
+
+	var name = tokens
+	if open := indexOf(tokens, "<"); open > -1 {
+		var close = matchingAngle(tokens, open)
+		for _, argument := range split(tokens[open+1:close], ",", true) {
+			typeName.Arguments = append(typeName.Arguments, p.text(argument))
+		}
+		name = tokens[:open]
+	}
+
+	var qualified = strings.ReplaceAll(p.text(name), " ", "")
+	qualified = strings.TrimPrefix(qualified, "global::")
+	if i := strings.LastIndex(qualified, "."); i > -1 {
+		typeName.Qualifier, qualified = qualified[:i], qualified[i+1:]
+	}
+	typeName.Name = qualified
+	return typeName
+}
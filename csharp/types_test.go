@@ -0,0 +1,99 @@
+package csharp
+
+import (
+	"documentApi/utils"
+	"os"
+	"testing"
+)
+
+func Test_Types_LookupResolvesDeclaredTypes(t *testing.T) {
+	// Arrange
+	fileData, _ := os.ReadFile("../test_assets/models_app/Models.cs")
+	var types = NewTypes()
+	types.Add(Parse(StripComments(string(fileData))))
+	types.Add(Parse(`namespace Repo.Billing { public class Address { public string Iban { get; set; } } }`))
+
+	tests := []struct {
+		name      string
+		typeName  string
+		namespace string
+		expected  string // the namespace of the type found, empty if it isn't found
+	}{
+		{"Same namespace", "Address", "Repo.Billing", "Repo.Billing"},
+		{"Parent namespace", "Address", "Repo.Orders.Models.Requests", "Repo.Orders.Models"},
+		{"Qualified", "Models.Address", "Repo.Billing", "Repo.Orders.Models"},
+		{"Ambiguous", "Address", "Repo.Orders", ""},
+		{"Imported", "CreateOrderRequest", "Repo.Orders", "Repo.Orders.Models"},
+		{"Unknown", "TagRequest", "Repo.Orders", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			model, exists := types.Lookup(test.typeName, test.namespace)
+
+			// Assert
+			if exists != (len(test.expected) > 0) {
+				t.Fatalf("expected found to be %v, got %v", len(test.expected) > 0, exists)
+			}
+			if exists {
+				utils.AssertStringEqual(t, test.expected, model.Declaration.Namespace)
+			}
+		})
+	}
+}
+
+func Test_Types_AddMergesPartialTypes(t *testing.T) {
+	// Arrange
+	fileData, _ := os.ReadFile("../test_assets/models_app/Models.cs")
+	var types = NewTypes()
+
+	// Act
+	types.Add(Parse(StripComments(string(fileData))))
+
+	// Assert
+	utils.AssertEqual(t, 9, types.Len())
+	order, _ := types.Lookup("Order", "Repo.Orders.Models")
+	utils.AssertSliceEqual(t, []string{"Entity", "IValidatableObject"}, order.Declaration.BaseTypes)
+	var members = []string{}
+	for _, member := range order.Members {
+		members = append(members, member.Name)
+	}
+	utils.AssertSliceEqual(t, []string{"OrderNumber", "CustomerId", "Lines", "Status", "ShippingAddress", "Discount", "Metadata", "InternalNotes", "Empty", "version", "Tags"}, members)
+
+	pagedResult, _ := types.Lookup("PagedResult", "Repo.Orders.Models")
+	utils.AssertSliceEqual(t, []string{"T"}, pagedResult.Declaration.TypeParameters)
+	status, _ := types.Lookup("OrderStatus", "Repo.Orders.Models")
+	utils.AssertSliceEqual(t, []string{"Pending", "Shipped", "Cancelled"}, status.Declaration.EnumMembers)
+}
+
+func Test_ParseTypeName_SplitsTypeReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected TypeName
+	}{
+		{"Simple", "Item", TypeName{Name: "Item"}},
+		{"Nullable", "int?", TypeName{Name: "int", Nullable: true}},
+		{"Qualified", "global::System.Collections.Generic.List<Models.Item>", TypeName{Name: "List", Qualifier: "System.Collections.Generic", Arguments: []string{"Models.Item"}}},
+		{"Nested generics", "Dictionary<string, List<int?>>?", TypeName{Name: "Dictionary", Arguments: []string{"string", "List<int?>"}, Nullable: true}},
+		{"Array", "Item?[]", TypeName{Element: "Item?"}},
+		{"Jagged array", "int[][]", TypeName{Element: "int[]"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			var typeName = ParseTypeName(test.src)
+
+			// Assert
+			utils.AssertStringEqual(t, test.expected.Name, typeName.Name)
+			utils.AssertStringEqual(t, test.expected.Qualifier, typeName.Qualifier)
+			utils.AssertStringEqual(t, test.expected.Element, typeName.Element)
+			utils.AssertSliceEqual(t, test.expected.Arguments, typeName.Arguments)
+			if test.expected.Nullable != typeName.Nullable {
+				t.Errorf("expected nullable to be %v, got %v", test.expected.Nullable, typeName.Nullable)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package data
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type FileMetaData struct {
 	Name string
@@ -8,21 +11,110 @@ type FileMetaData struct {
 }
 
 type EndpointMetaData struct {
-	Name           string            `json:"name"`
-	Authentication []string          `json:"authentication,omitempty"`
-	Route          string            `json:"route,omitempty"`
-	Methods        []string          `json:"methods,omitempty"`
-	PathParameters map[string]string `json:"pathParameters,omitempty"`
-	Description    string            `json:"description,omitempty"` // TODO: use ai to generate this?
-	Body           string            `json:"body,omitempty"`        // potentially a json string...parse the cs classes to get the body?
-	ResponseCodes  []int             `json:"responseCodes,omitempty"`
-	Interval       string            `json:"interval,omitempty"` // for time triggers, the cron expression
-	TriggerType    string            `json:"triggerType,omitempty"`
-	FilePath       string            `json:"filePath,omitempty"` // the file where this endpoint is located
+	Id             string               `json:"id"` // stable identifier, see GenerateId
+	Name           string               `json:"name"`
+	Project        string               `json:"project,omitempty"`   // the function app (folder containing the host.json) the endpoint belongs to
+	ClassName      string               `json:"className,omitempty"` // the namespace qualified class the function is declared in
+	Authentication []string             `json:"authentication,omitempty"`
+	Route          string               `json:"route,omitempty"`
+	Methods        []string             `json:"methods,omitempty"`
+	PathParameters map[string]string    `json:"pathParameters,omitempty"`
+	Parameters     []ParameterMetaData  `json:"parameters,omitempty"` // query and header parameters
+	Summary        string               `json:"summary,omitempty"`
+	Tags           []string             `json:"tags,omitempty"`
+	Description    string               `json:"description,omitempty"` // from [OpenApiOperation] or the comments above the handler
+	Body           string               `json:"body,omitempty"`        // an example json request body, from the OpenApiExample class or the schema
+	RequestBody    *RequestBodyMetaData `json:"requestBody,omitempty"`
+	ResponseCodes  []ResponseCode       `json:"responseCodes,omitempty"`
+	ErrorCodes     []ErrorCode          `json:"errorCodes,omitempty"` // the named errors the handler (or the helpers it calls) responds with
+	Interval       string               `json:"interval,omitempty"`   // for time triggers, the cron expression
+	Schedule       *ScheduleMetaData    `json:"schedule,omitempty"`   // for time triggers, when the interval fires
+	Binding        *TriggerBinding      `json:"binding,omitempty"`    // for the non http triggers, what the function is triggered by
+	Settings       []SettingReference   `json:"settings,omitempty"`   // the %AppSetting% placeholders that were resolved in the values above
+	TriggerType    string               `json:"triggerType,omitempty"`
+	FilePath       string               `json:"filePath,omitempty"` // the file where this endpoint is located
+}
+
+type ParameterMetaData struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`             // query, header, cookie
+	Type        string `json:"type,omitempty"` // the c# type name
+	Required    bool   `json:"required"`
+	Description string `json:"description,omitempty"`
+	Source      string `json:"source,omitempty"` // where it was found: attribute ([OpenApiParameter]), binding (a handler parameter) or code (read in the handler)
+}
+
+type RequestBodyMetaData struct {
+	ContentType string      `json:"contentType,omitempty"`
+	TypeName    string      `json:"typeName,omitempty"` // the c# type name
+	Required    bool        `json:"required,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Schema      *JsonSchema `json:"schema,omitempty"`  // resolved from the c# type, nil if the type isn't declared in the repo
+	Example     string      `json:"example,omitempty"` // the c# OpenApiExample class of the body
+}
+
+// TriggerBinding is the source a (non http) trigger listens to. Connections are the name of the app setting holding
+// the connection string (or the prefix of the identity based connection settings), not the connection itself
+type TriggerBinding struct {
+	QueueName          string `json:"queueName,omitempty"`
+	TopicName          string `json:"topicName,omitempty"`
+	SubscriptionName   string `json:"subscriptionName,omitempty"`
+	EventHubName       string `json:"eventHubName,omitempty"`
+	ConsumerGroup      string `json:"consumerGroup,omitempty"`
+	Path               string `json:"path,omitempty"` // the blob path pattern, e.g. samples/{name}
+	DatabaseName       string `json:"databaseName,omitempty"`
+	ContainerName      string `json:"containerName,omitempty"`
+	LeaseContainerName string `json:"leaseContainerName,omitempty"`
+	Connection         string `json:"connection,omitempty"`
+	Name               string `json:"name,omitempty"` // the orchestration, activity or entity name of durable triggers, when it differs from the function name
+}
+
+// ScheduleMetaData is when a timer trigger runs, in a readable form
+type ScheduleMetaData struct {
+	Description string   `json:"description"`        // e.g. every 5 minutes between 09:00 and 17:55, Mon–Fri
+	TimeZone    string   `json:"timeZone"`           // the time zone of the next runs
+	NextRuns    []string `json:"nextRuns,omitempty"` // RFC 3339 times
+}
+
+// SettingReference is an app setting placeholder (%Name%) that was replaced by the setting's value
+type SettingReference struct {
+	Field   string `json:"field"`   // the value the placeholder was in, e.g. route, interval or binding.queueName
+	Setting string `json:"setting"` // the name of the app setting
+	Source  string `json:"source"`  // the settings file the value was read from
+}
+
+type ResponseCode struct {
+	StatusCode  int         `json:"statusCode"`
+	Description string      `json:"description,omitempty"`
+	ContentType string      `json:"contentType,omitempty"`
+	TypeName    string      `json:"typeName,omitempty"` // the c# type name
+	Schema      *JsonSchema `json:"schema,omitempty"`   // resolved from the c# type, nil if the type isn't declared in the repo
+	Source      string      `json:"source,omitempty"`   // code when it is inferred from the handler, empty when it is declared
+}
+
+// ErrorCode is a named error an endpoint responds with, e.g. req.BadRequest("InvalidModule", "invalid module")
+// or throw new DocsApiException(HttpStatusCode.BadRequest, "MissingOid", "Oid is required for the user")
+type ErrorCode struct {
+	Code       string `json:"code"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Method     string `json:"method,omitempty"` // the helper method it is returned or thrown in, empty for the handler itself
 }
 
 func (e EndpointMetaData) String() string {
-	return fmt.Sprintf("Name: %s, Authentication: %v, Route: %s, Methods: %v", e.Name, e.Authentication, e.Route, e.Methods)
+	return fmt.Sprintf("Id: %s, Name: %s, Authentication: %v, Route: %s, Methods: %v", e.Id, e.Name, e.Authentication, e.Route, e.Methods)
+}
+
+// GenerateId builds the identifier of the endpoint from its project, class, function name and methods,
+// e.g. Catalog/Repo.Functions.Items/GetItem/GET,POST. It is the same between runs as long as these don't change
+func (e EndpointMetaData) GenerateId() string {
+	var parts = []string{}
+	for _, part := range []string{e.Project, e.ClassName, e.Name, strings.ToUpper(strings.Join(e.Methods, ","))} {
+		if len(part) > 0 {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
 }
 
 type ApiMetaData struct {
@@ -40,5 +132,9 @@ type RunParams struct {
 	DocType           *string
 	OutputDir         *string           `json:"outputDir,omitempty"`
 	EndpointSortKey   *string           `json:"sort,omitempty"`
+	Parsers           *string           `json:"parsers,omitempty"`        // comma separated list of the parsers to run, or all
+	SettingsEnv       *string           `json:"settingsEnv,omitempty"`    // the environment of the appsettings.{env}.json overlay used to resolve %AppSetting% placeholders
+	TimeZone          *string           `json:"timeZone,omitempty"`       // the IANA time zone the next runs of the timer triggers are computed in
+	MaxRunsPerHour    *int              `json:"maxRunsPerHour,omitempty"` // timer triggers that run more often are flagged by the schedule documenter
 	CollectionEnvVars map[string]string `json:"collectionEnvVars,omitempty"`
 }
@@ -0,0 +1,32 @@
+package data
+
+import "encoding/json"
+
+// FunctionJson is the function.json of a function in a script based function app (node, python, powershell etc.),
+// containing only the parts we document
+type FunctionJson struct {
+	ScriptFile string            `json:"scriptFile,omitempty"`
+	EntryPoint string            `json:"entryPoint,omitempty"`
+	Disabled   bool              `json:"disabled,omitempty"`
+	Bindings   []FunctionBinding `json:"bindings"`
+}
+
+type FunctionBinding struct {
+	Type      string   `json:"type"`
+	Direction string   `json:"direction,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Route     *string  `json:"route,omitempty"` // nil when missing, which means the function name is used as the route
+	Methods   []string `json:"methods,omitempty"`
+	AuthLevel string   `json:"authLevel,omitempty"`
+	Schedule  string   `json:"schedule,omitempty"`
+
+	Properties map[string]any `json:"-"` // all the properties of the binding, including the ones specific to the binding type
+}
+
+func (b *FunctionBinding) UnmarshalJSON(raw []byte) error {
+	type functionBinding FunctionBinding // without the UnmarshalJSON method
+	if err := json.Unmarshal(raw, (*functionBinding)(b)); err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, &b.Properties)
+}
@@ -14,27 +14,43 @@ type InsomniaCollection struct {
 }
 
 type InsomniaCollectionItem struct {
-	Url     string                     `yaml:"url"`
-	Name    string                     `yaml:"name"`
-	Meta    InsomniaCollectionItemMeta `yaml:"meta"`
-	Method  string                     `yaml:"method"`
-	Headers map[string]string          `yaml:"headers,omitempty"`
+	Url         string                     `yaml:"url"`
+	Name        string                     `yaml:"name"`
+	Meta        InsomniaCollectionItemMeta `yaml:"meta"`
+	Method      string                     `yaml:"method"`
+	Body        *InsomniaBody              `yaml:"body,omitempty"`
+	Parameters  []InsomniaParameter        `yaml:"parameters,omitempty"`
+	Headers     []InsomniaParameter        `yaml:"headers,omitempty"`
+	Description string                     `yaml:"description,omitempty"`
 	// Settings       struct{}
 	PathParameters []map[string]string `yaml:"pathParameters,omitempty"`
 }
 
+type InsomniaBody struct {
+	MimeType string `yaml:"mimeType"`
+	Text     string `yaml:"text,omitempty"`
+}
+
+type InsomniaParameter struct {
+	Name        string `yaml:"name"`
+	Value       string `yaml:"value"`
+	Disabled    bool   `yaml:"disabled,omitempty"`
+	Description string `yaml:"description,omitempty"`
+}
+
 type InsomniaCollectionItemMeta struct {
-	Id        string `yaml:"id"`
-	Created   int64  `yaml:"created"`
-	Modified  int64  `yaml:"modified"`
-	IsPrivate bool   `yaml:"isPrivate"`
-	SortKey   int    `yaml:"sortKey"`
+	Id        string  `yaml:"id"`
+	Created   int64   `yaml:"created"`
+	Modified  int64   `yaml:"modified"`
+	IsPrivate bool    `yaml:"isPrivate"`
+	SortKey   float64 `yaml:"sortKey"` // insomnia uses (negative) timestamps and fractions when reordering
 }
 
 type InsomniaEnvironment struct {
-	Name string                  `yaml:"name"`
-	Meta InsomniaEnvironmentMeta `yaml:"meta"`
-	Data map[string]string       `yaml:"data"`
+	Name            string                  `yaml:"name"`
+	Meta            InsomniaEnvironmentMeta `yaml:"meta"`
+	Data            map[string]any          `yaml:"data"`                      // values can be nested objects when edited in insomnia
+	SubEnvironments []map[string]any        `yaml:"subEnvironments,omitempty"` // only created by users, kept as they are
 }
 
 type InsomniaEnvironmentMeta struct {
@@ -0,0 +1,74 @@
+package data
+
+// OpenApiDocument is a minimal representation of an OpenAPI 3.1 document
+// containing only the parts we are able to fill in from the parsed endpoints
+type OpenApiDocument struct {
+	OpenApi    string                     `json:"openapi" yaml:"openapi"`
+	Info       OpenApiInfo                `json:"info" yaml:"info"`
+	Servers    []OpenApiServer            `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths      map[string]OpenApiPathItem `json:"paths" yaml:"paths"`
+	Components *OpenApiComponents         `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+type OpenApiInfo struct {
+	Title   string `json:"title" yaml:"title"`
+	Version string `json:"version" yaml:"version"`
+}
+
+type OpenApiServer struct {
+	Url string `json:"url" yaml:"url"`
+}
+
+// OpenApiPathItem maps the (lowercase) http method to the operation
+type OpenApiPathItem map[string]OpenApiOperation
+
+type OpenApiOperation struct {
+	OperationId string                     `json:"operationId" yaml:"operationId"`
+	Summary     string                     `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Parameters  []OpenApiParameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *OpenApiRequestBody        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Security    []map[string][]string      `json:"security,omitempty" yaml:"security,omitempty"`
+	Responses   map[string]OpenApiResponse `json:"responses" yaml:"responses"`
+}
+
+type OpenApiParameter struct {
+	Name        string        `json:"name" yaml:"name"`
+	In          string        `json:"in" yaml:"in"`
+	Description string        `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool          `json:"required" yaml:"required"`
+	Schema      OpenApiSchema `json:"schema" yaml:"schema"`
+}
+
+type OpenApiRequestBody struct {
+	Description string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool                        `json:"required,omitempty" yaml:"required,omitempty"`
+	Content     map[string]OpenApiMediaType `json:"content" yaml:"content"`
+}
+
+type OpenApiMediaType struct {
+	Schema *JsonSchema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+type OpenApiSchema struct {
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+}
+
+type OpenApiResponse struct {
+	Description string                      `json:"description" yaml:"description"`
+	Content     map[string]OpenApiMediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+type OpenApiComponents struct {
+	SecuritySchemes map[string]OpenApiSecurityScheme `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
+}
+
+type OpenApiSecurityScheme struct {
+	Type         string `json:"type" yaml:"type"`
+	Scheme       string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+	Name         string `json:"name,omitempty" yaml:"name,omitempty"` // the header, query or cookie holding the api key
+	In           string `json:"in,omitempty" yaml:"in,omitempty"`
+	Description  string `json:"description,omitempty" yaml:"description,omitempty"`
+}
@@ -0,0 +1,78 @@
+package data
+
+const PostmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// PostmanCollection is a Postman v2.1 collection, containing only the parts we are able to fill in from the parsed endpoints
+type PostmanCollection struct {
+	Info     PostmanInfo       `json:"info"`
+	Item     []PostmanItem     `json:"item"`
+	Variable []PostmanVariable `json:"variable,omitempty"`
+}
+
+type PostmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// PostmanItem is either a folder (with items) or a request
+type PostmanItem struct {
+	Id          string          `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Item        []PostmanItem   `json:"item,omitempty"`
+	Request     *PostmanRequest `json:"request,omitempty"`
+	Response    []any           `json:"response,omitempty"`
+}
+
+type PostmanRequest struct {
+	Method      string       `json:"method"`
+	Header      []PostmanKey `json:"header"`
+	Body        *PostmanBody `json:"body,omitempty"`
+	Url         PostmanUrl   `json:"url"`
+	Auth        *PostmanAuth `json:"auth,omitempty"`
+	Description string       `json:"description,omitempty"`
+}
+
+type PostmanUrl struct {
+	Raw      string       `json:"raw"`
+	Host     []string     `json:"host"`
+	Path     []string     `json:"path,omitempty"`
+	Query    []PostmanKey `json:"query,omitempty"`
+	Variable []PostmanKey `json:"variable,omitempty"`
+}
+
+// PostmanKey is used for headers, query params, path variables and form fields
+type PostmanKey struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type PostmanBody struct {
+	Mode       string              `json:"mode"`
+	Raw        string              `json:"raw,omitempty"`
+	UrlEncoded []PostmanKey        `json:"urlencoded,omitempty"`
+	FormData   []PostmanKey        `json:"formdata,omitempty"`
+	Options    *PostmanBodyOptions `json:"options,omitempty"`
+}
+
+type PostmanBodyOptions struct {
+	Raw struct {
+		Language string `json:"language"`
+	} `json:"raw"`
+}
+
+type PostmanAuth struct {
+	Type   string       `json:"type"`
+	Bearer []PostmanKey `json:"bearer,omitempty"`
+	Apikey []PostmanKey `json:"apikey,omitempty"`
+}
+
+type PostmanVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
@@ -0,0 +1,56 @@
+package data
+
+import (
+	"encoding/json"
+)
+
+// JsonSchema is the json schema of a request or response body, built from the c# model it is (de)serialized from
+type JsonSchema struct {
+	Title                string                 `json:"title,omitempty" yaml:"title,omitempty"` // the c# type name
+	Type                 SchemaType             `json:"type,omitempty" yaml:"type,omitempty"`
+	Format               string                 `json:"format,omitempty" yaml:"format,omitempty"`
+	Description          string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Enum                 []string               `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Properties           map[string]*JsonSchema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty" yaml:"required,omitempty"`
+	Items                *JsonSchema            `json:"items,omitempty" yaml:"items,omitempty"`
+	AdditionalProperties *JsonSchema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+}
+
+// SchemaType is the type of a json schema. Nullable types have more than one, e.g. ["string", "null"],
+// otherwise it is written as a single string
+type SchemaType []string
+
+func (t SchemaType) value() any {
+	if len(t) == 1 {
+		return t[0]
+	}
+	return []string(t)
+}
+
+func (t SchemaType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value())
+}
+
+func (t SchemaType) MarshalYAML() (any, error) {
+	return t.value(), nil
+}
+
+func (t *SchemaType) UnmarshalJSON(bytes []byte) error {
+	var single string
+	if err := json.Unmarshal(bytes, &single); err == nil {
+		*t = SchemaType{single}
+		return nil
+	}
+	return json.Unmarshal(bytes, (*[]string)(t))
+}
+
+// Is reports whether the schema allows the type (e.g. "object" for ["object", "null"])
+func (t SchemaType) Is(schemaType string) bool {
+	for _, value := range t {
+		if value == schemaType {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package documenters
 
 import (
+	"bytes"
 	"documentApi/data"
 	"documentApi/utils"
 	"encoding/json"
@@ -18,6 +19,73 @@ type BrunoDocumenter struct{}
 
 var sequence = 1 // "static" var to keep track of the sequence number
 
+// brunoJson serializes v without escaping html characters (e.g. & in query strings)
+func brunoJson(v any) (string, error) {
+	var buffer bytes.Buffer
+	var encoder = json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buffer.String()), nil
+}
+
+// brunoBodyMode returns the bruno body mode for the content type of the request body
+func brunoBodyMode(requestBody *data.RequestBodyMetaData) string {
+	if requestBody == nil {
+		return "none"
+	}
+
+	var contentType = strings.ToLower(requestBody.ContentType)
+	switch {
+	case strings.Contains(contentType, "x-www-form-urlencoded"):
+		return "formUrlEncoded"
+	case strings.Contains(contentType, "multipart"):
+		return "multipartForm"
+	case strings.Contains(contentType, "xml"):
+		return "xml"
+	case strings.Contains(contentType, "text"):
+		return "text"
+	}
+	return "json"
+}
+
+// brunoQueryString builds the query string for the required query parameters,
+// bruno expects the enabled query params to also be part of the url
+func brunoQueryString(parameters []data.ParameterMetaData) string {
+	var query = []string{}
+	for _, parameter := range parameters {
+		if parameter.In == "query" && parameter.Required {
+			query = append(query, parameter.Name+"=")
+		}
+	}
+	if len(query) < 1 {
+		return ""
+	}
+	return "?" + strings.Join(query, "&")
+}
+
+// brunoParametersBlock creates a block for all the parameters in the given location,
+// optional parameters are added disabled (prefixed with ~)
+func brunoParametersBlock(blockName string, parameters []data.ParameterMetaData, in string) string {
+	var block = ""
+	for _, parameter := range parameters {
+		if parameter.In != in {
+			continue
+		}
+		var disabled = ""
+		if !parameter.Required {
+			disabled = "~"
+		}
+		block += fmt.Sprintf("  %s%s: \n", disabled, parameter.Name)
+	}
+	if len(block) < 1 {
+		return ""
+	}
+	return blockName + " {\n" + block + "}"
+}
+
 func (b BrunoDocumenter) SerializeRequest(endpoint data.EndpointMetaData) (string, error) {
 	if endpoint.TriggerType != data.TriggerType["Http"] {
 		return "", fmt.Errorf("endpoint %s is not an HTTP trigger", endpoint.Name)
@@ -30,26 +98,28 @@ func (b BrunoDocumenter) SerializeRequest(endpoint data.EndpointMetaData) (strin
 	}
 
 	var request = data.BrunoRequest{
-		URL:  endpoint.Route,
-		Body: "json",    // assuming json for now...should probably check if body is empty before adding this field
+		URL:  endpoint.Route + brunoQueryString(endpoint.Parameters),
+		Body: brunoBodyMode(endpoint.RequestBody),
 		Auth: "inherit", // TODO: you probably have to do this yourself
 	}
 
-	metaJson, err := json.MarshalIndent(meta, "", "  ")
+	metaJson, err := brunoJson(meta)
 	if err != nil {
 		return "", fmt.Errorf("error serializing meta: %s", err.Error())
 	}
-	var metaString = strings.ReplaceAll(strings.ReplaceAll(string(metaJson), "\"", ""), ",", "")
+	var metaString = strings.ReplaceAll(strings.ReplaceAll(metaJson, "\"", ""), ",", "")
 
-	requestJson, err := json.MarshalIndent(request, "", "  ")
+	requestJson, err := brunoJson(request)
 	if err != nil {
 		return "", fmt.Errorf("error serializing request: %s", err.Error())
 	}
-	var requestString = strings.ReplaceAll(strings.ReplaceAll(string(requestJson), "\"", ""), ",", "")
+	var requestString = strings.ReplaceAll(strings.ReplaceAll(requestJson, "\"", ""), ",", "")
 
-	// ignore body for now
-	var bodyString = ""
-	// var bodyString = "body:" + request.Body + "{}"
+	var blocks = []string{
+		"meta " + metaString,
+		httpMethods(endpoint)[0] + " " + requestString,
+		brunoParametersBlock("params:query", endpoint.Parameters, "query"),
+	}
 
 	var pathParamsString = ""
 	if len(endpoint.PathParameters) > 0 {
@@ -59,12 +129,29 @@ func (b BrunoDocumenter) SerializeRequest(endpoint data.EndpointMetaData) (strin
 		}
 		pathParamsString += "}"
 	}
+	blocks = append(blocks, pathParamsString, brunoParametersBlock("headers", endpoint.Parameters, "header"))
+
+	if len(endpoint.Body) > 0 && request.Body != "none" {
+		blocks = append(blocks, "body:"+request.Body+" {\n  "+strings.ReplaceAll(endpoint.Body, "\n", "\n  ")+"\n}")
+	}
+
+	if len(endpoint.Description) > 0 {
+		// blank lines separate the paragraphs of the description, they are not indented
+		var docs = strings.ReplaceAll(strings.ReplaceAll(endpoint.Description, "\n", "\n  "), "\n  \n", "\n\n")
+		blocks = append(blocks, "docs {\n  "+docs+"\n}")
+	}
+
+	// skip the portions that don't exist
+	var serialized = []string{}
+	for _, block := range blocks {
+		if len(block) > 0 {
+			serialized = append(serialized, block)
+		}
+	}
 
 	sequence++
-	// TODO: avoid adding new lines if portions don't exist
-	// Only using the first request method, this would probably need to be serialized n times to handle all methods
-	// return fmt.Sprintf("meta %s\n\n%s %s\n\nbody:%s {}", metaString, endpoint.Methods[0], requestString, request.Body)
-	return fmt.Sprintf("meta %s\n\n%s %s\n\n%s\n\n%s", metaString, endpoint.Methods[0], requestString, pathParamsString, bodyString), nil
+	// only the first method is serialized, SerializeRequests splits endpoints with multiple methods into separate requests
+	return strings.Join(serialized, "\n\n") + "\n", nil
 }
 
 func (b BrunoDocumenter) Name() string {
@@ -82,31 +169,67 @@ func (b BrunoDocumenter) Supports(triggerType string) bool {
 func (b BrunoDocumenter) SerializeRequests(endpoints []data.EndpointMetaData, collectionName string, outputDir string, separateFiles bool, variables map[string]string, logger *logrus.Logger) bool {
 	// separateFiles is a no-op for bruno, it expects each endpoint to be in a separate file
 
-	// write out the endpoints to individual files
+	// write out the endpoints to individual files, one request per method
+	var requests, named, legacyNames = []data.EndpointMetaData{}, []data.EndpointMetaData{}, []string{}
+	var nameCounts = make(map[string]int)
 	for _, endpoint := range endpoints {
-		if b.Supports(endpoint.TriggerType) {
-			// TODO: Function name is a not a primary key (can have duplicates), live with this overwriting duplicates endpoints for now
-			// consider adding an id to file name to avoid overwriting?
-			var filePath = path.Join(outputDir, endpoint.Name+b.Extension())
-			file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-			if err != nil {
-				logger.Error("BrunoDocumenter SerializeRequests - Error opening endpoint file: " + err.Error())
-				return false
-			}
-			defer file.Close()
-
-			// since this documenter only supports http triggers we can assume this is a http endpoint and should prepend the host
-			endpoint.Route = path.Join("{{host}}", utils.ReplacePathVars(endpoint.Route))
-			var serializedRequest, serializationErr = b.SerializeRequest(endpoint)
-			if serializationErr != nil {
-				logger.Warn(serializationErr.Error())
-				continue
-			}
-			var _, writeErr = file.WriteString(serializedRequest)
-			if writeErr != nil {
-				logger.Error("BrunoDocumenter SerializeRequests - Error writing endpoint file: " + writeErr.Error())
-				return false
+		nameCounts[endpoint.Name]++
+	}
+	for _, endpoint := range endpoints {
+		if !b.Supports(endpoint.TriggerType) {
+			continue
+		}
+		for i, request := range requestsPerMethod(endpoint) {
+			requests = append(requests, request)
+			// the file is named after the endpoint and method of the request, not the name with the method suffix
+			var namedRequest = endpoint
+			namedRequest.Methods, namedRequest.Id = request.Methods, request.Id
+			named = append(named, namedRequest)
+			// collections written before the requests were split per method have a file named after the endpoint,
+			// holding its first method. Endpoints sharing a name overwrote each other's file, so it can't be told whose it is
+			var legacyName = ""
+			if i == 0 && nameCounts[endpoint.Name] == 1 {
+				legacyName = endpoint.Name
 			}
+			legacyNames = append(legacyNames, legacyName)
+		}
+	}
+
+	// each request is named after its own id, so the file stays the same when other endpoints are added
+	var fileNames = FileNames(named)
+	for i, request := range requests {
+		var filePath = path.Join(outputDir, fileNames[i]+b.Extension())
+
+		// since this documenter only supports http triggers we can assume this is a http endpoint and should prepend the host
+		request.Route = path.Join("{{host}}", utils.ReplacePathVars(request.Route))
+		var serializedRequest, serializationErr = b.SerializeRequest(request)
+		if serializationErr != nil {
+			logger.Warn(serializationErr.Error())
+			continue
+		}
+
+		// keep the changes made to the request since it was last generated, the first time the file named after
+		// the id is written the changes are taken from the file the endpoint was written to before (if any)
+		var legacyPath = path.Join(outputDir, legacyNames[i]+b.Extension())
+		if existing, err := os.ReadFile(filePath); err == nil {
+			logger.Debug("BrunoDocumenter SerializeRequests - Merging into existing endpoint file: " + filePath)
+			serializedRequest = mergeBrunoRequest(serializedRequest, string(existing))
+		} else if existing, err := os.ReadFile(legacyPath); err == nil && len(legacyNames[i]) > 0 && legacyPath != filePath && brunoRequestName(string(existing)) == legacyNames[i] {
+			logger.Warn("BrunoDocumenter SerializeRequests - Merged endpoint file: " + legacyPath + " into: " + filePath + ", it is no longer updated and can be removed")
+			serializedRequest = mergeBrunoRequest(serializedRequest, string(existing))
+		}
+
+		file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			logger.Error("BrunoDocumenter SerializeRequests - Error opening endpoint file: " + err.Error())
+			return false
+		}
+		defer file.Close()
+
+		var _, writeErr = file.WriteString(serializedRequest)
+		if writeErr != nil {
+			logger.Error("BrunoDocumenter SerializeRequests - Error writing endpoint file: " + writeErr.Error())
+			return false
 		}
 	}
 
@@ -142,6 +265,17 @@ func (b BrunoDocumenter) SerializeRequests(endpoints []data.EndpointMetaData, co
 	// create environment file
 	var brunoEnvFile = path.Join(outputDir, "environments", "local.bru")
 	if utils.InitDir(path.Join(outputDir, "environments"), logger) {
+		var envVarString = "vars {\n"
+		for key, value := range variables {
+			envVarString += fmt.Sprintf("\t %s: %s\n", key, value)
+		}
+		envVarString += "}"
+
+		// keep the values (and any extra vars) the user has set, other environment files are never touched
+		if existing, err := os.ReadFile(brunoEnvFile); err == nil {
+			envVarString = mergeBrunoEnvironment(envVarString, string(existing))
+		}
+
 		file, err := os.OpenFile(brunoEnvFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 		if err != nil {
 			logger.Warn("BrunoDocumenter SerializeRequests - Error creating bruno environment file: " + err.Error())
@@ -149,11 +283,6 @@ func (b BrunoDocumenter) SerializeRequests(endpoints []data.EndpointMetaData, co
 		}
 		defer file.Close()
 
-		var envVarString = "vars {\n"
-		for key, value := range variables {
-			envVarString += fmt.Sprintf("\t %s: %s\n", key, value)
-		}
-		envVarString += "}"
 		var _, writeErr = file.WriteString(envVarString)
 
 		if writeErr != nil {
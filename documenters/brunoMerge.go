@@ -0,0 +1,266 @@
+package documenters
+
+import (
+	"strings"
+)
+
+// brunoBlock is a top level block of a .bru file, e.g. "meta { ... }", "body:json { ... }" or "vars:secret [ ... ]"
+type brunoBlock struct {
+	Name  string
+	List  bool     // list blocks are wrapped in [] instead of {}
+	Lines []string // the content of the block as written (including the indentation)
+}
+
+type brunoEntry struct {
+	Key   string // includes the ~ prefix for disabled entries
+	Value string
+}
+
+var brunoHttpMethods = map[string]bool{
+	"get": true, "post": true, "put": true, "delete": true, "patch": true, "options": true, "head": true, "connect": true, "trace": true,
+}
+
+// parseBruFile splits the contents of a .bru file into its blocks, in the order they are written.
+// Blocks end with a closing bracket at the start of a line, which also holds for text blocks (docs, body, scripts)
+func parseBruFile(content string) []brunoBlock {
+	var blocks = []brunoBlock{}
+	var current *brunoBlock
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if current == nil {
+			var trimmed = strings.TrimSpace(line)
+			if strings.HasSuffix(trimmed, "{") {
+				current = &brunoBlock{Name: strings.TrimSpace(strings.TrimSuffix(trimmed, "{"))}
+			} else if strings.HasSuffix(trimmed, "[") {
+				current = &brunoBlock{Name: strings.TrimSpace(strings.TrimSuffix(trimmed, "[")), List: true}
+			}
+			continue
+		}
+		if (!current.List && strings.HasPrefix(line, "}")) || (current.List && strings.HasPrefix(line, "]")) {
+			blocks = append(blocks, *current)
+			current = nil
+			continue
+		}
+		current.Lines = append(current.Lines, line)
+	}
+	return blocks
+}
+
+// entries returns the key/value pairs of a dictionary block (meta, params, headers, vars etc.)
+func (b brunoBlock) entries() []brunoEntry {
+	var entries = []brunoEntry{}
+	for _, line := range b.Lines {
+		var trimmed = strings.TrimSpace(line)
+		if len(trimmed) < 1 {
+			continue
+		}
+		var key, value, _ = strings.Cut(trimmed, ":")
+		entries = append(entries, brunoEntry{Key: strings.TrimSpace(key), Value: strings.TrimSpace(value)})
+	}
+	return entries
+}
+
+func (b brunoBlock) value(key string) (string, bool) {
+	for _, entry := range b.entries() {
+		if entry.Key == key {
+			return entry.Value, true
+		}
+	}
+	return "", false
+}
+
+func (b brunoBlock) String() string {
+	var open, close = "{", "}"
+	if b.List {
+		open, close = "[", "]"
+	}
+	if len(b.Lines) < 1 {
+		return b.Name + " " + open + "\n" + close
+	}
+	return b.Name + " " + open + "\n" + strings.Join(b.Lines, "\n") + "\n" + close
+}
+
+func newBrunoBlock(name string, entries []brunoEntry) brunoBlock {
+	var block = brunoBlock{Name: name}
+	for _, entry := range entries {
+		block.Lines = append(block.Lines, "  "+entry.Key+": "+entry.Value)
+	}
+	return block
+}
+
+func findBrunoBlock(blocks []brunoBlock, match func(name string) bool) (brunoBlock, bool) {
+	for _, block := range blocks {
+		if match(block.Name) {
+			return block, true
+		}
+	}
+	return brunoBlock{}, false
+}
+
+func isBrunoBlock(name string) func(string) bool {
+	return func(blockName string) bool {
+		return blockName == name
+	}
+}
+
+// mergeBrunoEntries keeps the generated keys (in order) with the existing values, when there is one.
+// If keepExtra is set, the entries that only exist in the existing block are kept at the end (e.g. user added headers)
+func mergeBrunoEntries(generated []brunoEntry, existing []brunoEntry, keepExtra bool) []brunoEntry {
+	var existingValues = make(map[string]brunoEntry, len(existing))
+	for _, entry := range existing {
+		existingValues[strings.TrimPrefix(entry.Key, "~")] = entry
+	}
+
+	var merged = []brunoEntry{}
+	var seen = make(map[string]bool, len(generated))
+	for _, entry := range generated {
+		var name = strings.TrimPrefix(entry.Key, "~")
+		seen[name] = true
+		if old, exists := existingValues[name]; exists {
+			// the user may have enabled/disabled it
+			entry = old
+		}
+		merged = append(merged, entry)
+	}
+
+	if keepExtra {
+		for _, entry := range existing {
+			if !seen[strings.TrimPrefix(entry.Key, "~")] {
+				merged = append(merged, entry)
+			}
+		}
+	}
+	return merged
+}
+
+// brunoRequestName returns the name in the meta block of the .bru file contents
+func brunoRequestName(content string) string {
+	var meta, _ = findBrunoBlock(parseBruFile(content), isBrunoBlock("meta"))
+	var name, _ = meta.value("name")
+	return name
+}
+
+// mergeBrunoRequest merges a freshly generated request into the existing .bru file contents.
+// The name, url (route) and method are refreshed from source, everything the user may have filled in
+// (seq, path param values, query params, headers, body, auth, docs, scripts etc.) is kept
+func mergeBrunoRequest(generated string, existing string) string {
+	var generatedBlocks = parseBruFile(generated)
+	var existingBlocks = parseBruFile(existing)
+	if len(existingBlocks) < 1 {
+		return generated
+	}
+
+	var merged = []brunoBlock{}
+	var handled = make(map[string]bool)
+	for _, block := range generatedBlocks {
+		switch {
+		case block.Name == "meta":
+			if old, exists := findBrunoBlock(existingBlocks, isBrunoBlock("meta")); exists {
+				// only the name comes from source, keep the sequence and anything else the user changed
+				var entries = mergeBrunoEntries(block.entries(), old.entries(), true)
+				for i := range entries {
+					if entries[i].Key == "name" {
+						entries[i].Value, _ = block.value("name")
+					}
+				}
+				block = newBrunoBlock("meta", entries)
+			}
+			handled["meta"] = true
+		case brunoHttpMethods[block.Name]:
+			if old, exists := findBrunoBlock(existingBlocks, func(name string) bool { return brunoHttpMethods[name] }); exists {
+				var entries = mergeBrunoEntries(block.entries(), old.entries(), true)
+				var query, _ = findBrunoBlock(existingBlocks, isBrunoBlock("params:query"))
+				for i := range entries {
+					if entries[i].Key == "url" {
+						entries[i].Value = mergeBrunoUrl(block, query)
+					}
+				}
+				block = newBrunoBlock(block.Name, entries)
+			}
+		case block.Name == "params:path":
+			if old, exists := findBrunoBlock(existingBlocks, isBrunoBlock(block.Name)); exists {
+				// the path params are determined by the route, drop the ones that are no longer in it
+				block = newBrunoBlock(block.Name, mergeBrunoEntries(block.entries(), old.entries(), false))
+			}
+			handled[block.Name] = true
+		case block.Name == "params:query" || block.Name == "headers":
+			if old, exists := findBrunoBlock(existingBlocks, isBrunoBlock(block.Name)); exists {
+				block = newBrunoBlock(block.Name, mergeBrunoEntries(block.entries(), old.entries(), true))
+			}
+			handled[block.Name] = true
+		default:
+			// text blocks (e.g. docs) are kept as the user left them
+			if old, exists := findBrunoBlock(existingBlocks, isBrunoBlock(block.Name)); exists {
+				block = old
+			}
+			handled[block.Name] = true
+		}
+		merged = append(merged, block)
+	}
+
+	// keep the blocks that aren't generated (body, auth, vars, scripts, tests etc.) and the ones that were
+	// generated previously but no longer are (e.g. headers the user filled in after the attribute was removed)
+	for _, block := range existingBlocks {
+		if !handled[block.Name] && !brunoHttpMethods[block.Name] {
+			merged = append(merged, block)
+		}
+	}
+
+	var serialized = make([]string, 0, len(merged))
+	for _, block := range merged {
+		serialized = append(serialized, block.String())
+	}
+	return strings.Join(serialized, "\n\n") + "\n"
+}
+
+// mergeBrunoUrl returns the generated url with the query string built from the enabled query params
+// of the existing request, since bruno expects the url and the query params to match
+func mergeBrunoUrl(generated brunoBlock, existingQuery brunoBlock) string {
+	var url, _ = generated.value("url")
+	var base, query, _ = strings.Cut(url, "?")
+	if len(existingQuery.Name) < 1 {
+		return url
+	}
+
+	var generatedQuery = []brunoEntry{}
+	for _, parameter := range strings.Split(query, "&") {
+		if len(parameter) > 0 {
+			var key, value, _ = strings.Cut(parameter, "=")
+			generatedQuery = append(generatedQuery, brunoEntry{Key: key, Value: value})
+		}
+	}
+
+	var parameters = []string{}
+	for _, entry := range mergeBrunoEntries(generatedQuery, existingQuery.entries(), true) {
+		if !strings.HasPrefix(entry.Key, "~") {
+			parameters = append(parameters, entry.Key+"="+entry.Value)
+		}
+	}
+	if len(parameters) < 1 {
+		return base
+	}
+	return base + "?" + strings.Join(parameters, "&")
+}
+
+// mergeBrunoEnvironment adds the generated variables to the existing environment file,
+// the values the user has set are kept as well as any other blocks (e.g. vars:secret)
+func mergeBrunoEnvironment(generated string, existing string) string {
+	var existingBlocks = parseBruFile(existing)
+	if len(existingBlocks) < 1 {
+		return generated
+	}
+
+	var generatedVars, _ = findBrunoBlock(parseBruFile(generated), isBrunoBlock("vars"))
+	var merged = []string{}
+	var hasVars = false
+	for _, block := range existingBlocks {
+		if block.Name == "vars" {
+			block = newBrunoBlock("vars", mergeBrunoEntries(generatedVars.entries(), block.entries(), true))
+			hasVars = true
+		}
+		merged = append(merged, block.String())
+	}
+	if !hasVars {
+		merged = append([]string{generatedVars.String()}, merged...)
+	}
+	return strings.Join(merged, "\n\n") + "\n"
+}
@@ -0,0 +1,266 @@
+package documenters
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func Test_mergeBrunoRequest_KeepsUserEditsAndRefreshesRoute(t *testing.T) {
+	// Arrange
+	var generated = `meta {
+  name: GetItem
+  type: http
+  seq: 1
+}
+
+post {
+  url: {{host}}/api/v2/items/{{itemId}}?filter=
+  body: json
+  auth: inherit
+}
+
+params:query {
+  filter: 
+  ~page: 
+}
+
+params:path {
+  itemId: 
+}
+
+docs {
+  Gets an item
+}
+`
+	var existing = `meta {
+  name: GetItemOld
+  type: http
+  seq: 7
+}
+
+get {
+  url: {{host}}/api/items/{{itemId}}?filter=active
+  body: json
+  auth: bearer
+}
+
+params:query {
+  filter: active
+  ~page: 
+  custom: 1
+}
+
+params:path {
+  itemId: 42
+  oldParam: 1
+}
+
+headers {
+  x-trace: abc
+}
+
+auth:bearer {
+  token: {{token}}
+}
+
+body:json {
+  {
+    "name": "example"
+  }
+}
+
+docs {
+  Hand written docs
+}
+`
+
+	// Act
+	var merged = mergeBrunoRequest(generated, existing)
+	var blocks = parseBruFile(merged)
+
+	// Assert
+	var names = []string{}
+	for _, block := range blocks {
+		names = append(names, block.Name)
+	}
+	utils.AssertSliceEqual(t, []string{"meta", "post", "params:query", "params:path", "docs", "headers", "auth:bearer", "body:json"}, names)
+
+	var meta, _ = findBrunoBlock(blocks, isBrunoBlock("meta"))
+	var name, _ = meta.value("name")
+	var seq, _ = meta.value("seq")
+	utils.AssertStringEqual(t, "GetItem", name)
+	utils.AssertStringEqual(t, "7", seq)
+
+	var request, _ = findBrunoBlock(blocks, isBrunoBlock("post"))
+	var url, _ = request.value("url")
+	var auth, _ = request.value("auth")
+	utils.AssertStringEqual(t, "{{host}}/api/v2/items/{{itemId}}?filter=active&custom=1", url)
+	utils.AssertStringEqual(t, "bearer", auth)
+
+	var pathParams, _ = findBrunoBlock(blocks, isBrunoBlock("params:path"))
+	var itemId, _ = pathParams.value("itemId")
+	var _, hasOldParam = pathParams.value("oldParam")
+	utils.AssertStringEqual(t, "42", itemId)
+	if hasOldParam {
+		t.Errorf("expected path params that are no longer in the route to be removed")
+	}
+
+	var body, _ = findBrunoBlock(blocks, isBrunoBlock("body:json"))
+	utils.AssertStringEqual(t, "  {\n    \"name\": \"example\"\n  }", strings.Join(body.Lines, "\n"))
+	var docs, _ = findBrunoBlock(blocks, isBrunoBlock("docs"))
+	utils.AssertStringEqual(t, "  Hand written docs", strings.Join(docs.Lines, "\n"))
+}
+
+func Test_mergeBrunoRequest_ReturnsGeneratedWithoutExisting(t *testing.T) {
+	// Arrange
+	var generated = "meta {\n  name: GetItem\n}\n"
+
+	// Act
+	var merged = mergeBrunoRequest(generated, "")
+
+	// Assert
+	utils.AssertStringEqual(t, generated, merged)
+}
+
+func Test_mergeBrunoEnvironment_KeepsExistingValues(t *testing.T) {
+	// Arrange
+	var generated = "vars {\n\t host: http://localhost:7071\n\t tenant: default\n}"
+	var existing = "vars {\n  host: https://dev.example.com\n  apiKey: abc\n}\n\nvars:secret [\n  token\n]\n"
+
+	// Act
+	var merged = mergeBrunoEnvironment(generated, existing)
+
+	// Assert
+	var vars, _ = findBrunoBlock(parseBruFile(merged), isBrunoBlock("vars"))
+	utils.AssertEqual(t, 3, len(vars.entries()))
+	var host, _ = vars.value("host")
+	var tenant, _ = vars.value("tenant")
+	var apiKey, _ = vars.value("apiKey")
+	utils.AssertStringEqual(t, "https://dev.example.com", host)
+	utils.AssertStringEqual(t, "default", tenant)
+	utils.AssertStringEqual(t, "abc", apiKey)
+	utils.AssertContains(t, strings.Split(merged, "\n"), "vars:secret [")
+}
+
+func Test_BrunoDocumenter_SerializeRequest_HandlesNoMethods(t *testing.T) {
+	// Arrange
+	var endpoint = data.EndpointMetaData{Name: "AnyMethod", Route: "{{host}}/any", TriggerType: data.TriggerType["Http"]}
+
+	// Act
+	var serialized, err = BrunoDocumenter{}.SerializeRequest(endpoint)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	utils.AssertContains(t, strings.Split(serialized, "\n"), "get {")
+}
+
+func Test_BrunoDocumenter_SerializeRequests_NamesFilesAfterTheId(t *testing.T) {
+	// Arrange
+	var outputDir = t.TempDir()
+	var endpoint = data.EndpointMetaData{Name: "GetItem", Project: "Catalog", ClassName: "Repo.Items", Route: "items/{id}", Methods: []string{"get", "post"}, TriggerType: data.TriggerType["Http"]}
+	endpoint.Id = endpoint.GenerateId()
+
+	// Act
+	var success = BrunoDocumenter{}.SerializeRequests([]data.EndpointMetaData{endpoint}, "test", outputDir, false, nil, testLogger)
+
+	// Assert
+	if !success {
+		t.Fatalf("expected the collection to be written")
+	}
+	// one file per method, named after the id of the request rather than its name (GetItem (GET))
+	for _, fileName := range []string{"Catalog.Repo.Items.GetItem.GET.bru", "Catalog.Repo.Items.GetItem.POST.bru"} {
+		if _, err := os.Stat(path.Join(outputDir, fileName)); err != nil {
+			t.Errorf("expected %s to be written: %s", fileName, err.Error())
+		}
+	}
+}
+
+func Test_BrunoDocumenter_SerializeRequests_MigratesFilesNamedAfterTheEndpoint(t *testing.T) {
+	// Arrange
+	var outputDir = t.TempDir()
+	var endpoint = data.EndpointMetaData{Name: "GetItem", Project: "Catalog", ClassName: "Repo.Items", Route: "items/{id}", Methods: []string{"get", "post"}, PathParameters: map[string]string{"id": ""}, TriggerType: data.TriggerType["Http"]}
+	endpoint.Id = endpoint.GenerateId()
+	// the file written for the endpoint before the requests were split per method, with the path param filled in
+	var legacy = "meta {\n  name: GetItem\n  type: http\n  seq: 7\n}\n\nget {\n  url: {{host}}/items/:id\n  body: json\n  auth: inherit\n}\n\nparams:path {\n  id: 42\n}\n\n"
+	if err := os.WriteFile(path.Join(outputDir, "GetItem.bru"), []byte(legacy), 0644); err != nil {
+		t.Fatalf("error writing the legacy file: %s", err.Error())
+	}
+	// a file of another collection that happens to share the name of an endpoint
+	var unrelated = "meta {\n  name: Ping\n  type: http\n  seq: 1\n}\n"
+	if err := os.WriteFile(path.Join(outputDir, "HealthCheck.bru"), []byte(unrelated), 0644); err != nil {
+		t.Fatalf("error writing the unrelated file: %s", err.Error())
+	}
+	var health = data.EndpointMetaData{Name: "HealthCheck", Project: "Catalog", ClassName: "Repo.Health", Route: "health", Methods: []string{"get"}, TriggerType: data.TriggerType["Http"]}
+	health.Id = health.GenerateId()
+
+	// Act
+	var success = BrunoDocumenter{}.SerializeRequests([]data.EndpointMetaData{endpoint, health}, "test", outputDir, false, nil, testLogger)
+
+	// Assert
+	if !success {
+		t.Fatalf("expected the collection to be written")
+	}
+	var values = func(fileName string) (string, string) {
+		var written, err = os.ReadFile(path.Join(outputDir, fileName))
+		if err != nil {
+			t.Fatalf("expected the file named after the id: %s", err.Error())
+		}
+		var blocks = parseBruFile(string(written))
+		var meta, _ = findBrunoBlock(blocks, isBrunoBlock("meta"))
+		var seq, _ = meta.value("seq")
+		var params, _ = findBrunoBlock(blocks, isBrunoBlock("params:path"))
+		var id, _ = params.value("id")
+		return seq, id
+	}
+	// the legacy file held the first method only
+	var seq, id = values("Catalog.Repo.Items.GetItem.GET.bru")
+	utils.AssertStringEqual(t, "7", seq)
+	utils.AssertStringEqual(t, "42", id)
+	_, id = values("Catalog.Repo.Items.GetItem.POST.bru")
+	utils.AssertStringEqual(t, "", id)
+	seq, _ = values("Catalog.Repo.Health.HealthCheck.GET.bru")
+	if seq == "1" {
+		t.Errorf("expected the file of another request not to be merged")
+	}
+
+	// the files of the user are never removed
+	for _, fileName := range []string{"GetItem.bru", "HealthCheck.bru"} {
+		if _, err := os.Stat(path.Join(outputDir, fileName)); err != nil {
+			t.Errorf("expected %s to be kept: %s", fileName, err.Error())
+		}
+	}
+}
+
+func Test_BrunoDocumenter_SerializeRequest_WritesTheExampleBody(t *testing.T) {
+	// Arrange
+	var endpoint = data.EndpointMetaData{
+		Name:        "CreateItem",
+		Route:       "{{host}}/items",
+		Methods:     []string{"post"},
+		TriggerType: data.TriggerType["Http"],
+		RequestBody: &data.RequestBodyMetaData{ContentType: "application/json"},
+		Body:        "{\n  \"name\": \"string\"\n}",
+	}
+
+	// Act
+	var serialized, err = BrunoDocumenter{}.SerializeRequest(endpoint)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !strings.Contains(serialized, "body:json {\n  {\n    \"name\": \"string\"\n  }\n}") {
+		t.Errorf("expected a body:json block, got %s", serialized)
+	}
+	var body, exists = findBrunoBlock(parseBruFile(serialized), isBrunoBlock("body:json"))
+	if !exists {
+		t.Fatal("expected the body block to be parsed back")
+	}
+	utils.AssertStringEqual(t, "body:json", body.Name)
+}
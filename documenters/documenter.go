@@ -2,6 +2,11 @@ package documenters
 
 import (
 	"documentApi/data"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
@@ -13,3 +18,138 @@ type Documenter interface {
 	SerializeRequests(endpoints []data.EndpointMetaData, collectionName string, outdir string, separateFiles bool, vars map[string]string, logger *logrus.Logger) bool // this saves all the endpoints to the file
 	Supports(string) bool
 }
+
+// an http trigger that doesn't declare any methods accepts all of them, document it as the most common one
+const defaultHttpMethod = "get"
+
+// httpMethods returns the http methods of the endpoint, falling back to the default method if none are declared
+func httpMethods(endpoint data.EndpointMetaData) []string {
+	if len(endpoint.Methods) < 1 {
+		return []string{defaultHttpMethod}
+	}
+	return endpoint.Methods
+}
+
+// requestsPerMethod splits the endpoint into a copy per http method, for the collection formats
+// that only support a single method per request. When there are multiple methods the method is
+// added to the name (e.g. GetThing (POST)) so the requests (and their files) don't collide
+func requestsPerMethod(endpoint data.EndpointMetaData) []data.EndpointMetaData {
+	var methods = httpMethods(endpoint)
+	var requests = make([]data.EndpointMetaData, 0, len(methods))
+	for _, method := range methods {
+		var request = endpoint
+		request.Methods = []string{method}
+		request.Id = request.GenerateId()
+		if len(methods) > 1 {
+			request.Name = fmt.Sprintf("%s (%s)", endpoint.Name, strings.ToUpper(method))
+		}
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+// characters that are not allowed in file names on at least one OS
+var invalidFileNameRegex = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]`)
+
+func sanitizeFileName(name string) string {
+	return invalidFileNameRegex.ReplaceAllString(name, "_")
+}
+
+// FileNames returns a file name (without extension) for each endpoint, in the same order. The name is derived from
+// the id (project, class, function and methods), e.g. Catalog.Repo.Items.GetItem.GET, so it doesn't change when
+// endpoints are added elsewhere in the repo. Names are compared case insensitively since not all file systems are case sensitive,
+// ids that only differ in case are qualified with a hash of the id and identical ids are numbered
+func FileNames(endpoints []data.EndpointMetaData) []string {
+	var names = make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		// the parts of the id are sanitized on their own, the slashes that separate them become dots
+		var parts = []string{}
+		for _, part := range []string{endpoint.Project, endpoint.ClassName, endpoint.Name, strings.ToUpper(strings.Join(endpoint.Methods, ","))} {
+			if len(part) > 0 {
+				parts = append(parts, sanitizeFileName(part))
+			}
+		}
+		names[i] = strings.Join(parts, ".")
+	}
+	return qualifyNames(endpoints, names, hashQualifier)
+}
+
+// shortNames returns a short name for each endpoint, in the same order. The function name is used when it is unique,
+// otherwise it is qualified with the project and class, and as a last resort with a hash of the id.
+// These are meant to be read (e.g. labels), they change when an endpoint with the same name is added
+func shortNames(endpoints []data.EndpointMetaData) []string {
+	var names = make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		names[i] = sanitizeFileName(endpoint.Name)
+	}
+	return qualifyNames(endpoints, names, projectQualifier, hashQualifier)
+}
+
+func projectQualifier(endpoint data.EndpointMetaData, name string) string {
+	var parts = []string{}
+	for _, part := range []string{endpoint.Project, endpoint.ClassName, endpoint.Name} {
+		if len(part) > 0 {
+			parts = append(parts, part)
+		}
+	}
+	return sanitizeFileName(strings.Join(parts, "."))
+}
+
+func hashQualifier(endpoint data.EndpointMetaData, name string) string {
+	var hash = fnv.New32a()
+	hash.Write([]byte(endpoint.Id))
+	return fmt.Sprintf("%s_%08x", name, hash.Sum32())
+}
+
+// qualifyNames qualifies the names that collide with each qualifier in turn, until they are unique
+func qualifyNames(endpoints []data.EndpointMetaData, names []string, qualify ...func(endpoint data.EndpointMetaData, name string) string) []string {
+	for _, qualifier := range qualify {
+		for _, indexes := range collisions(names) {
+			if sameId(endpoints, indexes) {
+				continue // qualifying won't tell these apart
+			}
+			for _, i := range indexes {
+				names[i] = qualifier(endpoints[i], names[i])
+			}
+		}
+	}
+
+	// only identical ids are left, number them in order
+	for _, indexes := range collisions(names) {
+		for n, i := range indexes[1:] {
+			names[i] += "_" + strconv.Itoa(n+2)
+		}
+	}
+
+	return names
+}
+
+func sameId(endpoints []data.EndpointMetaData, indexes []int) bool {
+	for _, i := range indexes[1:] {
+		if endpoints[i].Id != endpoints[indexes[0]].Id {
+			return false
+		}
+	}
+	return true
+}
+
+// collisions returns the indexes of the names that are used more than once, grouped by name
+func collisions(names []string) [][]int {
+	var indexes = make(map[string][]int)
+	var order = []string{}
+	for i, name := range names {
+		var key = strings.ToLower(name)
+		if _, exists := indexes[key]; !exists {
+			order = append(order, key)
+		}
+		indexes[key] = append(indexes[key], i)
+	}
+
+	var groups = [][]int{}
+	for _, key := range order {
+		if len(indexes[key]) > 1 {
+			groups = append(groups, indexes[key])
+		}
+	}
+	return groups
+}
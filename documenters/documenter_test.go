@@ -0,0 +1,125 @@
+package documenters
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"strings"
+	"testing"
+)
+
+func Test_requestsPerMethod_ReturnsRequestPerMethod(t *testing.T) {
+	tests := []struct {
+		name          string
+		methods       []string
+		expectedNames []string
+		expected      []string
+	}{
+		{
+			name:          "Single method keeps the name",
+			methods:       []string{"get"},
+			expectedNames: []string{"GetThing"},
+			expected:      []string{"get"},
+		},
+		{
+			name:          "Multiple methods are suffixed",
+			methods:       []string{"get", "post"},
+			expectedNames: []string{"GetThing (GET)", "GetThing (POST)"},
+			expected:      []string{"get", "post"},
+		},
+		{
+			name:          "No methods defaults to get",
+			methods:       nil,
+			expectedNames: []string{"GetThing"},
+			expected:      []string{"get"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			var endpoint = data.EndpointMetaData{Name: "GetThing", Methods: test.methods, TriggerType: data.TriggerType["Http"]}
+
+			// Act
+			var requests = requestsPerMethod(endpoint)
+
+			// Assert
+			utils.AssertEqual(t, len(test.expected), len(requests))
+			for i, request := range requests {
+				utils.AssertStringEqual(t, test.expectedNames[i], request.Name)
+				utils.AssertSliceEqual(t, []string{test.expected[i]}, request.Methods)
+			}
+			utils.AssertEqual(t, len(test.methods), len(endpoint.Methods)) // the original endpoint is untouched
+		})
+	}
+}
+
+func Test_shortNames_ReturnsCollisionFreeNames(t *testing.T) {
+	// Arrange
+	var endpoints = []data.EndpointMetaData{
+		{Name: "GetItems", Project: "Catalog", ClassName: "Repo.Catalog.Items"},
+		{Name: "HealthCheck", Project: "Catalog", ClassName: "Repo.Catalog.Health"},
+		{Name: "HealthCheck", Project: "Orders", ClassName: "Repo.Orders.Health"},
+		{Name: "healthcheck", Project: "Orders", ClassName: "Repo.Orders.Health"},
+		{Name: "Export", Project: "Orders", ClassName: "Repo.Orders.Export", Methods: []string{"get"}},
+		{Name: "Export", Project: "Orders", ClassName: "Repo.Orders.Export", Methods: []string{"post"}},
+		{Name: "Duplicate", ClassName: "Repo.Duplicate"},
+		{Name: "Duplicate", ClassName: "Repo.Duplicate"},
+		{Name: "Invalid/Name:"},
+	}
+	for i := range endpoints {
+		endpoints[i].Id = endpoints[i].GenerateId()
+	}
+
+	// Act
+	var names = shortNames(endpoints)
+	var namesAgain = shortNames(endpoints)
+
+	// Assert
+	utils.AssertSliceEqual(t, names, namesAgain)
+	utils.AssertStringEqual(t, "GetItems", names[0])
+	utils.AssertStringEqual(t, "Catalog.Repo.Catalog.Health.HealthCheck", names[1])
+	utils.AssertStringEqual(t, "Orders.Repo.Orders.Health.HealthCheck", names[2][:len("Orders.Repo.Orders.Health.HealthCheck")])
+	utils.AssertStringEqual(t, "Orders.Repo.Orders.Health.healthcheck", names[3][:len("Orders.Repo.Orders.Health.healthcheck")])
+	utils.AssertMin(t, len("Orders.Repo.Orders.Export.Export_"), len(names[4]))
+	utils.AssertStringEqual(t, "Duplicate", names[6])
+	utils.AssertStringEqual(t, "Duplicate_2", names[7]) // identical ids can only be numbered
+	utils.AssertStringEqual(t, "Invalid_Name_", names[8])
+
+	var seen = make(map[string]bool)
+	for _, name := range names {
+		if seen[strings.ToLower(name)] {
+			t.Errorf("file name %s is used more than once", name)
+		}
+		seen[strings.ToLower(name)] = true
+	}
+}
+
+func Test_FileNames_DerivesNamesFromTheId(t *testing.T) {
+	// Arrange
+	var endpoints = []data.EndpointMetaData{
+		{Name: "HealthCheck", Project: "Catalog", ClassName: "Repo.Catalog.Health", Methods: []string{"get"}},
+		{Name: "Export", Project: "Orders", ClassName: "Repo.Orders.Export", Methods: []string{"get", "post"}},
+		{Name: "export", Project: "Orders", ClassName: "Repo.Orders.Export", Methods: []string{"get", "post"}},
+		{Name: "Duplicate", ClassName: "Repo.Duplicate"},
+		{Name: "Duplicate", ClassName: "Repo.Duplicate"},
+		{Name: "GET /api/items", ClassName: "Program", Methods: []string{"get"}},
+	}
+	for i := range endpoints {
+		endpoints[i].Id = endpoints[i].GenerateId()
+	}
+
+	// Act
+	var names = FileNames(endpoints)
+	// another HealthCheck is added in a different project
+	var added = FileNames(append([]data.EndpointMetaData{{Name: "HealthCheck", Project: "Orders", ClassName: "Repo.Orders.Health", Methods: []string{"get"}}}, endpoints...))
+
+	// Assert
+	utils.AssertStringEqual(t, "Catalog.Repo.Catalog.Health.HealthCheck.GET", names[0])
+	utils.AssertStringEqual(t, "Catalog.Repo.Catalog.Health.HealthCheck.GET", added[1]) // the existing file keeps its name
+	utils.AssertStringEqual(t, "Orders.Repo.Orders.Health.HealthCheck.GET", added[0])
+	utils.AssertMin(t, len("Orders.Repo.Orders.Export.Export.GET,POST_"), len(names[1])) // ids that only differ in case
+	utils.AssertMin(t, len("Orders.Repo.Orders.Export.export.GET,POST_"), len(names[2]))
+	utils.AssertStringEqual(t, "Repo.Duplicate.Duplicate", names[3])
+	utils.AssertStringEqual(t, "Repo.Duplicate.Duplicate_2", names[4]) // identical ids can only be numbered
+	utils.AssertStringEqual(t, "Program.GET _api_items.GET", names[5])
+}
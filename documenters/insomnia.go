@@ -1,11 +1,16 @@
 package documenters
 
 import (
+	"crypto/sha256"
 	"documentApi/data"
 	"documentApi/utils"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -14,6 +19,22 @@ import (
 
 type InsomniaDocumenter struct{}
 
+// the request ids derived from the endpoint id, the ones written before (random ids from utils.GenerateId) contain dashes
+var insomniaRequestIdRegex = regexp.MustCompile(`^req_[0-9a-f]{32}(_\d+)?$`)
+
+// insomniaRequestId derives the id of the request from the endpoint id (including the method), so importing the
+// collection again updates the same request even if its name or route changed. used counts the ids handed out,
+// endpoints with identical ids are numbered in order
+func insomniaRequestId(id string, used map[string]int) string {
+	var hash = sha256.Sum256([]byte(id))
+	var requestId = "req_" + hex.EncodeToString(hash[:16])
+	used[requestId]++
+	if used[requestId] > 1 {
+		requestId += "_" + strconv.Itoa(used[requestId])
+	}
+	return requestId
+}
+
 func (i InsomniaDocumenter) Extension() string {
 	return ".yaml"
 }
@@ -31,12 +52,9 @@ func (i InsomniaDocumenter) SerializeRequests(endpoints []data.EndpointMetaData,
 	// separateFiles is a no-op for insomnia, it outputs a single collection file
 
 	var filePath = path.Join(outputDir, collectionName+i.Extension())
-	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-	if err != nil {
-		logger.Error("InsomniaDocumenter SerializeRequests - Error opening collection file: " + err.Error())
-		return false
-	}
-	defer file.Close()
+	// the collection from a previous run, its ids (and other user data) need to be kept so importing it again
+	// updates the existing requests instead of creating duplicates
+	var existing = readInsomniaCollection(filePath, logger)
 
 	var collection data.InsomniaCollection = data.InsomniaCollection{
 		Type: "collection.insomnia.rest/5.0",
@@ -45,41 +63,62 @@ func (i InsomniaDocumenter) SerializeRequests(endpoints []data.EndpointMetaData,
 	var collectionRequests = make([]data.InsomniaCollectionItem, 0, len(endpoints))
 
 	var timeStamp int64 = time.Now().Unix()
-	var sortKey int = 0
+	var usedIds = make(map[string]int)
 	for _, endpoint := range endpoints {
-		if i.Supports(endpoint.TriggerType) {
-			// since this documenter only supports http triggers we can assume this is a http endpoint and should prepend the host
-			endpoint.Route = path.Join("{{host}}", utils.ReplacePathVars(endpoint.Route)) // TODO: add host to env vars
+		if !i.Supports(endpoint.TriggerType) {
+			continue
+		}
+		// since this documenter only supports http triggers we can assume this is a http endpoint and should prepend the host
+		endpoint.Route = path.Join("{{host}}", utils.ReplacePathVars(endpoint.Route)) // TODO: add host to env vars
+		for _, request := range requestsPerMethod(endpoint) {
 			collectionRequests = append(collectionRequests, data.InsomniaCollectionItem{
-				Url:            endpoint.Route,
-				Name:           endpoint.Name,
-				Method:         endpoint.Methods[0], // using only the first method for now
-				PathParameters: mapToMapArray(endpoint.PathParameters),
+				Url:            request.Route,
+				Name:           request.Name,
+				Method:         request.Methods[0],
+				Body:           insomniaBody(request.RequestBody, request.Body),
+				Parameters:     insomniaParameters(request.Parameters, "query"),
+				Headers:        insomniaParameters(request.Parameters, "header"),
+				Description:    request.Description,
+				PathParameters: mapToMapArray(request.PathParameters),
 				Meta: data.InsomniaCollectionItemMeta{
-					Id:        "req_" + utils.GenerateId(),
-					Created:   timeStamp, // TODO: preserve timestamp if updating
+					Id:        insomniaRequestId(request.Id, usedIds),
+					Created:   timeStamp,
 					Modified:  timeStamp,
 					IsPrivate: false,
-					SortKey:   sortKey,
+					SortKey:   float64(len(collectionRequests)),
 				},
 			})
-			sortKey++
 		}
 	}
 
 	collection.Collection = collectionRequests
 
+	var environmentData = make(map[string]any, len(envVars))
+	for key, value := range envVars {
+		environmentData[key] = value
+	}
 	collection.Environment = data.InsomniaEnvironment{
 		Name: "Base Environment",
 		Meta: data.InsomniaEnvironmentMeta{
 			Id:        "env_" + utils.GenerateId(),
-			Created:   timeStamp, // TODO: preserve timestamp if updating
+			Created:   timeStamp,
 			Modified:  timeStamp,
 			IsPrivate: false,
 		},
-		Data: envVars,
+		Data: environmentData,
 	}
 
+	if existing != nil {
+		mergeInsomniaCollection(&collection, *existing)
+	}
+
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		logger.Error("InsomniaDocumenter SerializeRequests - Error opening collection file: " + err.Error())
+		return false
+	}
+	defer file.Close()
+
 	err = yaml.NewEncoder(file).Encode(collection)
 	if err != nil {
 		logger.Error("InsomniaDocumenter SerializeRequests - Error saving insomnia collection: " + err.Error())
@@ -89,10 +128,119 @@ func (i InsomniaDocumenter) SerializeRequests(endpoints []data.EndpointMetaData,
 	return true
 }
 
+// readInsomniaCollection reads the collection written by a previous run, returns nil if there is none (or it can't be read)
+func readInsomniaCollection(filePath string, logger *logrus.Logger) *data.InsomniaCollection {
+	fileData, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+
+	var collection data.InsomniaCollection
+	if err := yaml.Unmarshal(fileData, &collection); err != nil {
+		logger.Warn("InsomniaDocumenter SerializeRequests - Error reading existing collection, it will be overwritten: " + err.Error())
+		return nil
+	}
+	return &collection
+}
+
+// mergeInsomniaCollection keeps the ids, created timestamps and sort keys of the requests that already exist in the
+// previous collection, as well as the environment ids and any environment data added by the user.
+// Requests are matched by their id (derived from the endpoint id), the requests of collections written before the ids
+// were derived are matched by name and method, using the url to tell apart endpoints with the same name
+func mergeInsomniaCollection(collection *data.InsomniaCollection, existing data.InsomniaCollection) {
+	collection.Meta = existing.Meta
+
+	var matched = make([]bool, len(existing.Collection))
+	var lastSortKey float64 = 0
+	for j, old := range existing.Collection {
+		lastSortKey = max(lastSortKey, old.Meta.SortKey)
+		// requests with a derived id that no longer exists are removed endpoints, they are only matched by id
+		matched[j] = insomniaRequestIdRegex.MatchString(old.Meta.Id)
+	}
+
+	var isMatched = make([]bool, len(collection.Collection))
+	for j := range collection.Collection {
+		for k, old := range existing.Collection {
+			if old.Meta.Id == collection.Collection[j].Meta.Id {
+				collection.Collection[j].Meta.Created = old.Meta.Created
+				collection.Collection[j].Meta.SortKey = old.Meta.SortKey
+				isMatched[j], matched[k] = true, true
+				break
+			}
+		}
+	}
+	// most specific match first, so a renamed endpoint or a changed route doesn't steal another endpoints request
+	for _, criteria := range [][2]bool{{true, true}, {true, false}, {false, true}} {
+		for j := range collection.Collection {
+			if !isMatched[j] {
+				isMatched[j] = matchInsomniaItem(&collection.Collection[j], existing.Collection, matched, criteria[0], criteria[1])
+			}
+		}
+	}
+
+	// new requests go after the existing ones
+	for j := range collection.Collection {
+		if !isMatched[j] {
+			lastSortKey++
+			collection.Collection[j].Meta.SortKey = lastSortKey
+		}
+	}
+
+	if len(existing.Environment.Meta.Id) > 0 {
+		collection.Environment.Meta.Id = existing.Environment.Meta.Id
+		collection.Environment.Meta.Created = existing.Environment.Meta.Created
+	}
+	for key, value := range existing.Environment.Data {
+		collection.Environment.Data[key] = value // the user may have changed the generated values too
+	}
+	collection.Environment.SubEnvironments = existing.Environment.SubEnvironments
+}
+
+// matchInsomniaItem looks for an unmatched request in the existing collection with the same method (and name and/or url),
+// and copies over its ids, created timestamp and sort key
+func matchInsomniaItem(item *data.InsomniaCollectionItem, existing []data.InsomniaCollectionItem, matched []bool, sameName bool, sameUrl bool) bool {
+	for j, old := range existing {
+		if matched[j] || !strings.EqualFold(old.Method, item.Method) {
+			continue
+		}
+		if (sameName && old.Name != item.Name) || (sameUrl && old.Url != item.Url) {
+			continue
+		}
+		matched[j] = true
+		item.Meta.Id = old.Meta.Id
+		item.Meta.Created = old.Meta.Created
+		item.Meta.SortKey = old.Meta.SortKey
+		return true
+	}
+	return false
+}
+
 func (i InsomniaDocumenter) Supports(triggerType string) bool {
 	return triggerType == data.TriggerType["Http"]
 }
 
+// insomniaParameters returns the parameters in the given location, optional parameters are disabled
+func insomniaParameters(parameters []data.ParameterMetaData, in string) []data.InsomniaParameter {
+	var insomniaParameters = []data.InsomniaParameter{}
+	for _, parameter := range parameters {
+		if parameter.In == in {
+			insomniaParameters = append(insomniaParameters, data.InsomniaParameter{
+				Name:        parameter.Name,
+				Disabled:    !parameter.Required,
+				Description: parameter.Description,
+			})
+		}
+	}
+	return insomniaParameters
+}
+
+func insomniaBody(requestBody *data.RequestBodyMetaData, body string) *data.InsomniaBody {
+	if requestBody == nil || len(requestBody.ContentType) < 1 {
+		return nil
+	}
+	return &data.InsomniaBody{MimeType: requestBody.ContentType, Text: body}
+}
+
 func mapToMapArray(flatMap map[string]string) []map[string]string {
 	m := make([]map[string]string, 0, len(flatMap))
 
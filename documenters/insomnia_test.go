@@ -0,0 +1,130 @@
+package documenters
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"os"
+	"path"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func Test_InsomniaDocumenter_SerializeRequests_PreservesExistingIds(t *testing.T) {
+	// Arrange
+	var outputDir = t.TempDir()
+	var endpoints = []data.EndpointMetaData{
+		{Name: "HealthCheck", Route: "catalog/health", Methods: []string{"get"}, TriggerType: data.TriggerType["Http"]},
+		{Name: "HealthCheck", Route: "orders/health", Methods: []string{"get"}, TriggerType: data.TriggerType["Http"]},
+		{Name: "GetItems", Route: "catalog/items", Methods: []string{"get"}, TriggerType: data.TriggerType["Http"]},
+	}
+	var documenter = InsomniaDocumenter{}
+	documenter.SerializeRequests(endpoints, "test", outputDir, false, map[string]string{"host": "http://localhost:7071"}, testLogger)
+	var first = readTestInsomniaCollection(t, outputDir)
+
+	// the user edits the environment and the collection gets a new endpoint
+	first.Environment.Data["host"] = "https://dev.example.com"
+	first.Environment.Data["token"] = map[string]any{"value": "abc"}
+	var fileData, _ = yaml.Marshal(first)
+	os.WriteFile(path.Join(outputDir, "test.yaml"), fileData, 0644)
+	endpoints = append(endpoints, data.EndpointMetaData{Name: "AddItem", Route: "catalog/items", Methods: []string{"post"}, TriggerType: data.TriggerType["Http"]})
+
+	// Act
+	documenter.SerializeRequests(endpoints, "test", outputDir, false, map[string]string{"host": "http://localhost:7071"}, testLogger)
+	var second = readTestInsomniaCollection(t, outputDir)
+
+	// Assert
+	utils.AssertEqual(t, 4, len(second.Collection))
+	var firstIds = make(map[string]string)
+	for _, item := range first.Collection {
+		firstIds[item.Url] = item.Meta.Id
+	}
+	for _, item := range second.Collection {
+		if item.Name == "AddItem" {
+			if _, exists := firstIds[item.Url]; exists && item.Meta.Id == firstIds[item.Url] {
+				t.Errorf("expected a new id for the new endpoint")
+			}
+			if item.Meta.SortKey <= 2 {
+				t.Errorf("expected the new endpoint to be sorted after the existing ones, got %v", item.Meta.SortKey)
+			}
+			continue
+		}
+		utils.AssertStringEqual(t, firstIds[item.Url], item.Meta.Id)
+	}
+	utils.AssertStringEqual(t, first.Environment.Meta.Id, second.Environment.Meta.Id)
+	utils.AssertStringEqual(t, "https://dev.example.com", second.Environment.Data["host"].(string))
+	if _, exists := second.Environment.Data["token"]; !exists {
+		t.Errorf("expected user added environment data to be kept")
+	}
+}
+
+func Test_InsomniaDocumenter_SerializeRequests_KeepsIdsWhenTheRouteChanges(t *testing.T) {
+	// Arrange
+	var outputDir = t.TempDir()
+	var endpoints = []data.EndpointMetaData{
+		{Name: "HealthCheck", Project: "Catalog", ClassName: "Repo.Health", Route: "catalog/health", Methods: []string{"get"}, TriggerType: data.TriggerType["Http"]},
+		{Name: "HealthCheck", Project: "Orders", ClassName: "Repo.Health", Route: "catalog/health", Methods: []string{"get"}, TriggerType: data.TriggerType["Http"]},
+	}
+	for i := range endpoints {
+		endpoints[i].Id = endpoints[i].GenerateId()
+	}
+	var documenter = InsomniaDocumenter{}
+	documenter.SerializeRequests(endpoints, "test", outputDir, false, nil, testLogger)
+	var first = readTestInsomniaCollection(t, outputDir)
+
+	// the route prefix of the orders app changes, both endpoints have the same name (and had the same url)
+	endpoints[1].Route = "api/orders/health"
+	endpoints = []data.EndpointMetaData{endpoints[1], endpoints[0]}
+
+	// Act
+	documenter.SerializeRequests(endpoints, "test", outputDir, false, nil, testLogger)
+	var second = readTestInsomniaCollection(t, outputDir)
+
+	// Assert
+	utils.AssertEqual(t, 2, len(second.Collection))
+	utils.AssertStringEqual(t, "{{host}}/api/orders/health", second.Collection[0].Url)
+	utils.AssertStringEqual(t, first.Collection[1].Meta.Id, second.Collection[0].Meta.Id)
+	utils.AssertStringEqual(t, first.Collection[0].Meta.Id, second.Collection[1].Meta.Id)
+	utils.AssertEqual(t, int(first.Collection[1].Meta.SortKey), int(second.Collection[0].Meta.SortKey))
+	if first.Collection[0].Meta.Id == first.Collection[1].Meta.Id {
+		t.Errorf("expected different ids for the endpoints of different projects")
+	}
+}
+
+func Test_mergeInsomniaCollection_MatchesLegacyRequestsByNameAndUrl(t *testing.T) {
+	// Arrange
+	var used = make(map[string]int)
+	var collection = data.InsomniaCollection{Collection: []data.InsomniaCollectionItem{
+		{Name: "GetItems", Method: "get", Url: "{{host}}/api/items", Meta: data.InsomniaCollectionItemMeta{Id: insomniaRequestId("Catalog/Repo.Items/GetItems/GET", used)}},
+		{Name: "AddItem", Method: "post", Url: "{{host}}/api/items", Meta: data.InsomniaCollectionItemMeta{Id: insomniaRequestId("Catalog/Repo.Items/AddItem/POST", used)}},
+	}, Environment: data.InsomniaEnvironment{Data: map[string]any{}}}
+	var existing = data.InsomniaCollection{Collection: []data.InsomniaCollectionItem{
+		// written before the ids were derived from the endpoint id
+		{Name: "GetItems", Method: "get", Url: "{{host}}/api/items", Meta: data.InsomniaCollectionItemMeta{Id: "req_1f2e3d4c-5b6a-7988-a1b2-c3d4e5f6a7b8-1760000000000", Created: 1, SortKey: 3}},
+		// a removed endpoint, its derived id is not reused
+		{Name: "AddItem", Method: "post", Url: "{{host}}/api/items", Meta: data.InsomniaCollectionItemMeta{Id: insomniaRequestId("Catalog/Repo.Items/CreateItem/POST", used), Created: 2, SortKey: 4}},
+	}}
+
+	// Act
+	mergeInsomniaCollection(&collection, existing)
+
+	// Assert
+	utils.AssertStringEqual(t, existing.Collection[0].Meta.Id, collection.Collection[0].Meta.Id)
+	utils.AssertEqual(t, 1, int(collection.Collection[0].Meta.Created))
+	if collection.Collection[1].Meta.Id == existing.Collection[1].Meta.Id {
+		t.Errorf("expected the new endpoint to keep its own id")
+	}
+	utils.AssertEqual(t, 5, int(collection.Collection[1].Meta.SortKey))
+}
+
+func readTestInsomniaCollection(t *testing.T, outputDir string) data.InsomniaCollection {
+	var collection data.InsomniaCollection
+	var fileData, err = os.ReadFile(path.Join(outputDir, "test.yaml"))
+	if err != nil {
+		t.Fatalf("error reading collection: %s", err.Error())
+	}
+	if err := yaml.Unmarshal(fileData, &collection); err != nil {
+		t.Fatalf("error parsing collection: %s", err.Error())
+	}
+	return collection
+}
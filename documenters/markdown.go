@@ -8,6 +8,8 @@ import (
 	"os"
 	"path"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"slices"
@@ -28,6 +30,21 @@ func isSeparatorRow(fields []string) bool {
 	return true
 }
 
+// splitCells splits a table row on the pipes that aren't escaped with a backslash
+func splitCells(row string) []string {
+	var cells = []string{}
+	var start = 0
+	for i := 0; i < len(row); i++ {
+		if row[i] == '\\' {
+			i++ // skip the escaped char
+		} else if row[i] == '|' {
+			cells = append(cells, row[start:i])
+			start = i + 1
+		}
+	}
+	return append(cells, row[start:])
+}
+
 // adapted from https://github.com/christking246/utils/blob/main/services/Formatter.js#L15
 // formatMarkdownTable formats a markdown table with proper alignment
 func formatMarkdownTable(str string) string {
@@ -36,9 +53,9 @@ func formatMarkdownTable(str string) string {
 	// Parse each row into cells
 	var parsedRows [][]string
 	for _, row := range rows {
-		// Remove leading and trailing pipes, then split by pipe
+		// Remove leading and trailing pipes, then split by pipe (escaped pipes are part of the cell)
 		trimmed := strings.Trim(row, "|")
-		cells := strings.Split(trimmed, "|")
+		cells := splitCells(trimmed)
 		for i := range cells {
 			cells[i] = strings.TrimSpace(cells[i])
 		}
@@ -100,16 +117,166 @@ func (m MarkdownDocumenter) Name() string {
 	return "markdown"
 }
 
+// formatParameters formats the query and header parameters as "name (in, type, required) - description"
+func formatParameters(parameters []data.ParameterMetaData) string {
+	var formatted = make([]string, 0, len(parameters))
+	for _, parameter := range parameters {
+		var details = []string{parameter.In}
+		if len(parameter.Type) > 0 {
+			details = append(details, parameter.Type)
+		}
+		if parameter.Required {
+			details = append(details, "required")
+		}
+		var formattedParameter = fmt.Sprintf("%s (%s)", parameter.Name, strings.Join(details, ", "))
+		if len(parameter.Description) > 0 {
+			formattedParameter += " - " + parameter.Description
+		}
+		formatted = append(formatted, formattedParameter)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// schemaTypeName is the short name of the type a schema describes, e.g. "OrderLine[]" or "string?" for a nullable string
+func schemaTypeName(schema *data.JsonSchema) string {
+	if schema == nil {
+		return "any"
+	}
+	var name = schema.Title
+	switch {
+	case schema.Type.Is("array") && schema.Items != nil:
+		name = schemaTypeName(schema.Items) + "[]"
+	case len(name) > 0:
+	case len(schema.Type) > 0:
+		name = schema.Type[0]
+	default:
+		name = "any"
+	}
+	if schema.Type.Is("null") {
+		name += "?"
+	}
+	return name
+}
+
+// formatSchema summarizes the top level properties of an object schema, required properties are marked with a *,
+// e.g. "{ id: string, lines*: OrderLine[] }"
+func formatSchema(schema *data.JsonSchema) string {
+	if schema == nil || len(schema.Properties) < 1 {
+		return ""
+	}
+	var names = make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var properties = make([]string, 0, len(names))
+	for _, name := range names {
+		var property = name
+		if slices.Contains(schema.Required, name) {
+			property += "*"
+		}
+		properties = append(properties, property+": "+schemaTypeName(schema.Properties[name]))
+	}
+	return "{ " + strings.Join(properties, ", ") + " }"
+}
+
+// formatRequestBody formats the request body as "contentType typeName { properties }"
+func formatRequestBody(requestBody *data.RequestBodyMetaData) string {
+	if requestBody == nil {
+		return ""
+	}
+	var parts = []string{}
+	for _, part := range []string{requestBody.ContentType, requestBody.TypeName, formatSchema(requestBody.Schema)} {
+		if len(part) > 0 {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatResponseCodes formats the response codes as "code typeName { properties } - description"
+func formatResponseCodes(responseCodes []data.ResponseCode) string {
+	var formatted = make([]string, 0, len(responseCodes))
+	for _, responseCode := range responseCodes {
+		var response = strconv.Itoa(responseCode.StatusCode)
+		if len(responseCode.TypeName) > 0 {
+			response += " " + responseCode.TypeName
+		}
+		if properties := formatSchema(responseCode.Schema); len(properties) > 0 {
+			response += " " + properties
+		}
+		if len(responseCode.Description) > 0 {
+			response += " - " + responseCode.Description
+		}
+		formatted = append(formatted, response)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// formatErrorCodes formats the named errors as "statusCode code - message", e.g. "400 InvalidModule - invalid module"
+func formatErrorCodes(errorCodes []data.ErrorCode) string {
+	var formatted = make([]string, 0, len(errorCodes))
+	for _, errorCode := range errorCodes {
+		var named = strconv.Itoa(errorCode.StatusCode) + " " + errorCode.Code
+		if len(errorCode.Message) > 0 {
+			named += " - " + errorCode.Message
+		}
+		formatted = append(formatted, named)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// formatInterval formats the interval of a timer trigger with when it runs, e.g. "0 0 \* \* \* \* (every hour)"
+func formatInterval(endpoint data.EndpointMetaData) string {
+	var interval = strings.ReplaceAll(endpoint.Interval, "*", "\\*")
+	if endpoint.Schedule != nil && len(endpoint.Schedule.Description) > 0 {
+		interval += " (" + endpoint.Schedule.Description + ")"
+	}
+	return interval
+}
+
+// formatBinding formats what the trigger listens to as "label: value" pairs, e.g. "queue: orders, connection: Storage"
+func formatBinding(binding *data.TriggerBinding) string {
+	if binding == nil {
+		return ""
+	}
+	var formatted = []string{}
+	for _, property := range [][2]string{
+		{"name", binding.Name},
+		{"queue", binding.QueueName},
+		{"topic", binding.TopicName},
+		{"subscription", binding.SubscriptionName},
+		{"event hub", binding.EventHubName},
+		{"consumer group", binding.ConsumerGroup},
+		{"path", binding.Path},
+		{"database", binding.DatabaseName},
+		{"container", binding.ContainerName},
+		{"lease container", binding.LeaseContainerName},
+		{"connection", binding.Connection},
+	} {
+		if len(property[1]) > 0 {
+			formatted = append(formatted, property[0]+": "+property[1])
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// tableCell escapes the text of a table cell, the pipes would start a new cell and the line breaks a new row
+func tableCell(text string) string {
+	return strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>").Replace(text)
+}
+
 func (m MarkdownDocumenter) SerializeRequest(endpoint data.EndpointMetaData) (string, error) {
-	return fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s | %s |", endpoint.Name, strings.ToUpper(strings.Join(endpoint.Methods, ", ")), endpoint.Route, strings.Join(endpoint.Authentication, ", "), endpoint.TriggerType, strings.ReplaceAll(endpoint.Interval, "*", "\\*"), endpoint.Description, endpoint.FilePath), nil
+	return fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s | %s | %s | %s | %s | %s | %s | %s |", endpoint.Name, strings.ToUpper(strings.Join(endpoint.Methods, ", ")), endpoint.Route, strings.Join(endpoint.Authentication, ", "), endpoint.TriggerType, formatInterval(endpoint), formatBinding(endpoint.Binding), tableCell(endpoint.Description), strings.Join(endpoint.Tags, ", "), tableCell(formatParameters(endpoint.Parameters)), tableCell(formatRequestBody(endpoint.RequestBody)), tableCell(formatResponseCodes(endpoint.ResponseCodes)), formatErrorCodes(endpoint.ErrorCodes), endpoint.FilePath), nil
 }
 
 func (m MarkdownDocumenter) SerializeRequests(endpoints []data.EndpointMetaData, collectionName string, outputDir string, separateFiles bool, vars map[string]string, logger *logrus.Logger) bool {
 	// separateFiles is a no-op for markdown, it does not make sense to write a table column per file
 	// vars is not used in this documenter
 
-	var markDownString string = "| Function Name | Methods | Route | Authentication | TriggerType | Interval | Description | File Path |\n"
-	markDownString += "|--------|--------|--------|--------|--------|--------|--------|--------|\n"
+	var markDownString string = "| Function Name | Methods | Route | Authentication | TriggerType | Interval | Binding | Description | Tags | Parameters | Request Body | Responses | Error Codes | File Path |\n"
+	markDownString += "|--------|--------|--------|--------|--------|--------|--------|--------|--------|--------|--------|--------|--------|--------|\n"
 	for _, endpoint := range endpoints {
 		var serializedRequest, serializationErr = m.SerializeRequest(endpoint)
 		if serializationErr != nil {
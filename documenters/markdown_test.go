@@ -0,0 +1,131 @@
+package documenters
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"strings"
+	"testing"
+)
+
+func Test_formatBinding_ReturnsTriggerDetails(t *testing.T) {
+	var tests = []struct {
+		name     string
+		binding  *data.TriggerBinding
+		expected string
+	}{
+		{"no binding", nil, ""},
+		{"queue", &data.TriggerBinding{QueueName: "orders", Connection: "Storage"}, "queue: orders, connection: Storage"},
+		{"cosmos", &data.TriggerBinding{DatabaseName: "catalog", ContainerName: "items", LeaseContainerName: "leases"}, "database: catalog, container: items, lease container: leases"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			var formatted = formatBinding(test.binding)
+
+			// Assert
+			utils.AssertStringEqual(t, test.expected, formatted)
+		})
+	}
+}
+
+func Test_formatInterval_ReturnsScheduleDescription(t *testing.T) {
+	var tests = []struct {
+		name     string
+		endpoint data.EndpointMetaData
+		expected string
+	}{
+		{"not a timer", data.EndpointMetaData{}, ""},
+		{"no schedule", data.EndpointMetaData{Interval: "%CleanupSchedule%"}, "%CleanupSchedule%"},
+		{"schedule", data.EndpointMetaData{Interval: "0 0 * * * *", Schedule: &data.ScheduleMetaData{Description: "every hour"}}, "0 0 \\* \\* \\* \\* (every hour)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			var formatted = formatInterval(test.endpoint)
+
+			// Assert
+			utils.AssertStringEqual(t, test.expected, formatted)
+		})
+	}
+}
+
+func Test_formatRequestBody_SummarizesTheSchema(t *testing.T) {
+	var address = &data.JsonSchema{Title: "Address", Type: data.SchemaType{"object", "null"}}
+	var tests = []struct {
+		name        string
+		requestBody *data.RequestBodyMetaData
+		expected    string
+	}{
+		{"no body", nil, ""},
+		{"unresolved type", &data.RequestBodyMetaData{ContentType: "application/json", TypeName: "TagRequest"}, "application/json TagRequest"},
+		{"schema", &data.RequestBodyMetaData{ContentType: "application/json", TypeName: "CreateOrderRequest", Schema: &data.JsonSchema{
+			Type: data.SchemaType{"object"},
+			Properties: map[string]*data.JsonSchema{
+				"lines":           {Type: data.SchemaType{"array"}, Items: &data.JsonSchema{Title: "OrderLine", Type: data.SchemaType{"object"}}},
+				"customerId":      {Type: data.SchemaType{"string"}},
+				"shippingAddress": address,
+				"metadata":        {},
+			},
+			Required: []string{"customerId"},
+		}}, "application/json CreateOrderRequest { customerId*: string, lines: OrderLine[], metadata: any, shippingAddress: Address? }"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			var formatted = formatRequestBody(test.requestBody)
+
+			// Assert
+			utils.AssertStringEqual(t, test.expected, formatted)
+		})
+	}
+}
+
+func Test_formatErrorCodes_ReturnsStatusCodeAndMessage(t *testing.T) {
+	// Arrange
+	var errorCodes = []data.ErrorCode{
+		{Code: "InvalidModule", StatusCode: 400, Message: "invalid module"},
+		{Code: "ModuleNotFound", StatusCode: 404, Method: "FindModule"},
+	}
+
+	// Act
+	var formatted = formatErrorCodes(errorCodes)
+
+	// Assert
+	utils.AssertStringEqual(t, "400 InvalidModule - invalid module, 404 ModuleNotFound", formatted)
+}
+
+func Test_SerializeRequest_KeepsMultilineDescriptionsInTheRow(t *testing.T) {
+	// Arrange
+	var endpoint = data.EndpointMetaData{
+		Name:        "GetReport",
+		Description: "GET /api/v2/reports/{reportId}\n\nGets the report | cached",
+		Parameters:  []data.ParameterMetaData{{Name: "refresh", In: "query", Description: "Rebuilds the report"}},
+	}
+
+	// Act
+	var row, err = MarkdownDocumenter{}.SerializeRequest(endpoint)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	for _, expected := range []string{"| GET /api/v2/reports/{reportId}<br><br>Gets the report \\| cached |", "| refresh (query) - Rebuilds the report |"} {
+		if !strings.Contains(row, expected) {
+			t.Errorf("expected %q in the row, got %s", expected, row)
+		}
+	}
+}
+
+func Test_formatMarkdownTable_KeepsEscapedPipesInTheCell(t *testing.T) {
+	// Arrange
+	var table = "| Name | Description |\n|--|--|\n| ListReports | Lists the reports \\| all of them |\n"
+
+	// Act
+	var formatted = formatMarkdownTable(table)
+
+	// Assert
+	utils.AssertStringEqual(t, "| Name        | Description                      |\n|-------------|----------------------------------|\n| ListReports | Lists the reports \\| all of them |", formatted)
+}
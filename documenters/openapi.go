@@ -0,0 +1,271 @@
+package documenters
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+type OpenApiDocumenter struct{}
+
+const openApiVersion = "3.1.0"
+const openApiFileName = "openapi"
+
+// component keys are restricted to ^[a-zA-Z0-9\.\-_]+$
+var securitySchemeKeyRegex = regexp.MustCompile(`[^a-zA-Z0-9.\-_]+`)
+
+func (o OpenApiDocumenter) Extension() string {
+	return ".yaml"
+}
+
+func (o OpenApiDocumenter) Name() string {
+	return "openapi"
+}
+
+func (o OpenApiDocumenter) Supports(triggerType string) bool {
+	return triggerType == data.TriggerType["Http"]
+}
+
+// the auth modes of the function keys required by the auth level of an http trigger, these are sent in a header
+// rather than as a bearer token
+var functionKeySchemes = map[string]bool{"FunctionKey": true, "AdminKey": true}
+
+// openApiPath converts the route into an OpenAPI path, these always start with a slash and their parameters
+// are plain names, e.g. "api/items/{id:int}" -> "/api/items/{id}"
+func openApiPath(route string) string {
+	return path.Join("/", utils.NormalizeRouteTemplate(route))
+}
+
+func securitySchemeKey(auth string) string {
+	return securitySchemeKeyRegex.ReplaceAllString(auth, "_")
+}
+
+// openApiSecurityScheme describes how the auth mode is sent, function keys are api keys and
+// every other mode (tokens, policies, roles) is a bearer token
+func openApiSecurityScheme(auth string) data.OpenApiSecurityScheme {
+	if functionKeySchemes[auth] {
+		return data.OpenApiSecurityScheme{Type: "apiKey", Name: "x-functions-key", In: "header", Description: "Requires " + auth}
+	}
+	return data.OpenApiSecurityScheme{Type: "http", Scheme: "bearer", Description: "Requires " + auth}
+}
+
+// openApiSchemaType maps a c# type name to the json schema type
+func openApiSchemaType(csharpType string) string {
+	switch strings.TrimSuffix(strings.TrimPrefix(csharpType, "System."), "?") {
+	case "", "string", "String", "char", "Char", "Guid", "DateTime", "DateTimeOffset", "TimeSpan":
+		return "string"
+	case "int", "Int32", "long", "Int64", "short", "Int16", "uint", "UInt32", "ulong", "UInt64", "byte", "Byte":
+		return "integer"
+	case "float", "Single", "double", "Double", "decimal", "Decimal":
+		return "number"
+	case "bool", "Boolean":
+		return "boolean"
+	}
+	if strings.HasSuffix(csharpType, "[]") {
+		return "array"
+	}
+	return "object"
+}
+
+// openApiContent returns the content map for the content type, if any.
+// Types that couldn't be resolved to a schema are described by their name
+func openApiContent(contentType string, typeName string, schema *data.JsonSchema) map[string]data.OpenApiMediaType {
+	if len(contentType) < 1 {
+		return nil
+	}
+
+	var mediaType = data.OpenApiMediaType{Schema: schema}
+	if schema == nil && len(typeName) > 0 {
+		mediaType.Schema = &data.JsonSchema{Type: data.SchemaType{openApiSchemaType(typeName)}, Title: typeName}
+	}
+	return map[string]data.OpenApiMediaType{contentType: mediaType}
+}
+
+// openApiOperations returns the operations for every method of the endpoint keyed by the lowercase method
+func openApiOperations(endpoint data.EndpointMetaData) map[string]data.OpenApiOperation {
+	var operations = make(map[string]data.OpenApiOperation, len(httpMethods(endpoint)))
+
+	// every parameter of the path template must be declared, including the ones the parser didn't extract
+	var pathParameters = utils.ExtractPathVars(openApiPath(endpoint.Route))
+	var parameters = make([]data.OpenApiParameter, 0, len(pathParameters))
+	for name := range pathParameters {
+		parameters = append(parameters, data.OpenApiParameter{
+			Name:     name,
+			In:       "path",
+			Required: true, // path parameters are always required in OpenAPI
+			Schema:   data.OpenApiSchema{Type: "string"},
+		})
+	}
+	// map iteration is random, keep the output stable between runs
+	sort.Slice(parameters, func(i, j int) bool {
+		return parameters[i].Name < parameters[j].Name
+	})
+	for _, parameter := range endpoint.Parameters {
+		parameters = append(parameters, data.OpenApiParameter{
+			Name:        parameter.Name,
+			In:          parameter.In,
+			Description: parameter.Description,
+			Required:    parameter.Required,
+			Schema:      data.OpenApiSchema{Type: openApiSchemaType(parameter.Type)},
+		})
+	}
+
+	var requestBody *data.OpenApiRequestBody
+	if endpoint.RequestBody != nil && len(endpoint.RequestBody.ContentType) > 0 {
+		requestBody = &data.OpenApiRequestBody{
+			Description: endpoint.RequestBody.Description,
+			Required:    endpoint.RequestBody.Required,
+			Content:     openApiContent(endpoint.RequestBody.ContentType, endpoint.RequestBody.TypeName, endpoint.RequestBody.Schema),
+		}
+	}
+
+	var responses = make(map[string]data.OpenApiResponse, len(endpoint.ResponseCodes))
+	for _, responseCode := range endpoint.ResponseCodes {
+		var description = responseCode.Description
+		if len(description) < 1 {
+			description = http.StatusText(responseCode.StatusCode)
+		}
+		responses[strconv.Itoa(responseCode.StatusCode)] = data.OpenApiResponse{
+			Description: description,
+			Content:     openApiContent(responseCode.ContentType, responseCode.TypeName, responseCode.Schema),
+		}
+	}
+	if len(responses) < 1 {
+		responses["default"] = data.OpenApiResponse{Description: "Default response"} // at least one response is required
+	}
+
+	// each auth mode is treated as an alternative way to access the endpoint
+	var security = make([]map[string][]string, 0, len(endpoint.Authentication))
+	for _, auth := range endpoint.Authentication {
+		security = append(security, map[string][]string{securitySchemeKey(auth): {}})
+	}
+
+	var methods = httpMethods(endpoint)
+	for _, method := range methods {
+		method = strings.ToLower(method)
+		var operationId = endpoint.Name
+		if len(methods) > 1 {
+			// operation ids must be unique across the document
+			operationId += "_" + method
+		}
+
+		operations[method] = data.OpenApiOperation{
+			OperationId: operationId,
+			Summary:     endpoint.Summary,
+			Description: endpoint.Description,
+			Tags:        endpoint.Tags,
+			Parameters:  parameters,
+			RequestBody: requestBody,
+			Security:    security,
+			Responses:   responses,
+		}
+	}
+
+	return operations
+}
+
+// this returns the path item (as yaml) for a single endpoint
+func (o OpenApiDocumenter) SerializeRequest(endpoint data.EndpointMetaData) (string, error) {
+	if !o.Supports(endpoint.TriggerType) {
+		return "", fmt.Errorf("endpoint %s is not an HTTP trigger", endpoint.Name)
+	}
+
+	var pathItem = map[string]data.OpenApiPathItem{
+		openApiPath(endpoint.Route): openApiOperations(endpoint),
+	}
+	pathYaml, err := yaml.Marshal(pathItem)
+	if err != nil {
+		return "", fmt.Errorf("error serializing path item: %s", err.Error())
+	}
+
+	return string(pathYaml), nil
+}
+
+func (o OpenApiDocumenter) SerializeRequests(endpoints []data.EndpointMetaData, collectionName string, outputDir string, separateFiles bool, vars map[string]string, logger *logrus.Logger) bool {
+	// separateFiles is a no-op for openapi, the spec is a single document
+
+	var document = data.OpenApiDocument{
+		OpenApi: openApiVersion,
+		Info: data.OpenApiInfo{
+			Title:   collectionName,
+			Version: "1.0.0",
+		},
+		Paths: make(map[string]data.OpenApiPathItem),
+	}
+
+	if host, exists := vars["host"]; exists && len(host) > 0 {
+		document.Servers = []data.OpenApiServer{{Url: host}}
+	}
+
+	var securitySchemes = make(map[string]data.OpenApiSecurityScheme)
+	for _, endpoint := range endpoints {
+		if !o.Supports(endpoint.TriggerType) {
+			continue
+		}
+
+		var openApiRoute = openApiPath(endpoint.Route)
+		if _, exists := document.Paths[openApiRoute]; !exists {
+			document.Paths[openApiRoute] = make(data.OpenApiPathItem)
+		}
+		for method, operation := range openApiOperations(endpoint) {
+			if _, exists := document.Paths[openApiRoute][method]; exists {
+				logger.Warn("OpenApiDocumenter SerializeRequests - Duplicate operation '" + strings.ToUpper(method) + " " + openApiRoute + "' found for endpoint: " + endpoint.Name + ", skipping")
+				continue
+			}
+			document.Paths[openApiRoute][method] = operation
+		}
+
+		for _, auth := range endpoint.Authentication {
+			securitySchemes[securitySchemeKey(auth)] = openApiSecurityScheme(auth)
+		}
+	}
+
+	if len(securitySchemes) > 0 {
+		document.Components = &data.OpenApiComponents{SecuritySchemes: securitySchemes}
+	}
+
+	// write the yaml spec
+	var yamlFilePath = path.Join(outputDir, openApiFileName+o.Extension())
+	yamlFile, err := os.OpenFile(yamlFilePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		logger.Error("OpenApiDocumenter SerializeRequests - Error opening yaml spec file: " + err.Error())
+		return false
+	}
+	defer yamlFile.Close()
+
+	var encoder = yaml.NewEncoder(yamlFile)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		logger.Error("OpenApiDocumenter SerializeRequests - Error writing yaml spec file: " + err.Error())
+		return false
+	}
+
+	// write the same spec as json, some tools only accept one or the other
+	var jsonFilePath = path.Join(outputDir, openApiFileName+".json")
+	jsonFile, err := os.OpenFile(jsonFilePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		logger.Error("OpenApiDocumenter SerializeRequests - Error opening json spec file: " + err.Error())
+		return false
+	}
+	defer jsonFile.Close()
+
+	var jsonEncoder = json.NewEncoder(jsonFile)
+	jsonEncoder.SetIndent("", "  ")
+	if err := jsonEncoder.Encode(document); err != nil {
+		logger.Error("OpenApiDocumenter SerializeRequests - Error writing json spec file: " + err.Error())
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,76 @@
+package documenters
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+var testLogger = logrus.New()
+
+func Test_OpenApiDocumenter_SerializeRequests_WritesSpec(t *testing.T) {
+	// Arrange
+	var outputDir = t.TempDir()
+	var endpoints = []data.EndpointMetaData{
+		{
+			Name:           "GetItem",
+			Route:          "/api/items/{id:int}/{*path}", // the route prefix of the host.json is already joined
+			Methods:        []string{"get", "post"},
+			Parameters:     []data.ParameterMetaData{{Name: "filter", In: "query", Required: true}},
+			Authentication: []string{"FunctionKey", "DocsToken"},
+			TriggerType:    data.TriggerType["Http"],
+		},
+		{Name: "HealthCheck", Route: "health/{check?}", Methods: []string{"get"}, TriggerType: data.TriggerType["Http"]},
+		{Name: "Cleanup", TriggerType: data.TriggerType["Timer"]},
+	}
+
+	// Act
+	var success = OpenApiDocumenter{}.SerializeRequests(endpoints, "test", outputDir, false, map[string]string{"host": "http://localhost:7071"}, testLogger)
+
+	// Assert
+	if !success {
+		t.Fatalf("expected the spec to be written")
+	}
+	var document data.OpenApiDocument
+	var fileData, _ = os.ReadFile(path.Join(outputDir, "openapi.json"))
+	if err := json.Unmarshal(fileData, &document); err != nil {
+		t.Fatalf("error parsing spec: %s", err.Error())
+	}
+
+	utils.AssertStringEqual(t, "http://localhost:7071", document.Servers[0].Url)
+	utils.AssertEqual(t, 2, len(document.Paths))
+
+	// the constraints and catch all are stripped from the path template
+	var item, exists = document.Paths["/api/items/{id}/{path}"]
+	if !exists {
+		t.Fatalf("expected the normalized item path, got %v", document.Paths)
+	}
+	utils.AssertEqual(t, 2, len(item))
+	utils.AssertStringEqual(t, "GetItem_get", item["get"].OperationId)
+	utils.AssertStringEqual(t, "GetItem_post", item["post"].OperationId)
+	var parameters = []string{}
+	for _, parameter := range item["get"].Parameters {
+		parameters = append(parameters, parameter.Name+" ("+parameter.In+")")
+	}
+	utils.AssertSliceEqual(t, []string{"id (path)", "path (path)", "filter (query)"}, parameters)
+	utils.AssertEqual(t, 2, len(item["get"].Security))
+
+	// the optional marker is stripped, path parameters are always required
+	var health = document.Paths["/health/{check}"]
+	utils.AssertStringEqual(t, "HealthCheck", health["get"].OperationId)
+	utils.AssertStringEqual(t, "check", health["get"].Parameters[0].Name)
+	utils.AssertEqual(t, 1, len(health["get"].Parameters))
+
+	// function keys are sent in a header, the other auth modes are bearer tokens
+	var schemes = document.Components.SecuritySchemes
+	utils.AssertStringEqual(t, "apiKey", schemes["FunctionKey"].Type)
+	utils.AssertStringEqual(t, "x-functions-key", schemes["FunctionKey"].Name)
+	utils.AssertStringEqual(t, "header", schemes["FunctionKey"].In)
+	utils.AssertStringEqual(t, "http", schemes["DocsToken"].Type)
+	utils.AssertStringEqual(t, "bearer", schemes["DocsToken"].Scheme)
+}
@@ -0,0 +1,262 @@
+package documenters
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+type PostmanDocumenter struct{}
+
+func (p PostmanDocumenter) Extension() string {
+	return ".postman_collection.json"
+}
+
+func (p PostmanDocumenter) Name() string {
+	return "postman"
+}
+
+func (p PostmanDocumenter) Supports(triggerType string) bool {
+	return triggerType == data.TriggerType["Http"]
+}
+
+// postmanVariable is the collection variable holding the token for the auth mode
+func postmanVariable(auth string) string {
+	return securitySchemeKey(auth)
+}
+
+// postmanUrl builds the url for the route, path variables use the :name format and
+// only the required query parameters are enabled
+func postmanUrl(endpoint data.EndpointMetaData) data.PostmanUrl {
+	var route = strings.Trim(utils.ReplacePathVars(endpoint.Route), "/")
+	var url = data.PostmanUrl{
+		Host: []string{"{{host}}"},
+	}
+	if len(route) > 0 {
+		url.Path = strings.Split(route, "/")
+	}
+
+	var names = make([]string, 0, len(endpoint.PathParameters))
+	for name := range endpoint.PathParameters {
+		names = append(names, name)
+	}
+	sort.Strings(names) // map iteration is random, keep the output stable between runs
+	for _, name := range names {
+		url.Variable = append(url.Variable, data.PostmanKey{Key: name, Value: ""})
+	}
+
+	var query = []string{}
+	for _, parameter := range endpoint.Parameters {
+		if parameter.In != "query" {
+			continue
+		}
+		url.Query = append(url.Query, data.PostmanKey{
+			Key:         parameter.Name,
+			Value:       "",
+			Disabled:    !parameter.Required,
+			Description: parameter.Description,
+		})
+		if parameter.Required {
+			query = append(query, parameter.Name+"=")
+		}
+	}
+
+	url.Raw = strings.Join(append(url.Host, url.Path...), "/")
+	if len(query) > 0 {
+		url.Raw += "?" + strings.Join(query, "&")
+	}
+	return url
+}
+
+// postmanBody returns the body for the content type of the request body, with the example body (if any) as the raw body
+func postmanBody(requestBody *data.RequestBodyMetaData, example string) *data.PostmanBody {
+	switch brunoBodyMode(requestBody) {
+	case "none":
+		return nil
+	case "formUrlEncoded":
+		return &data.PostmanBody{Mode: "urlencoded", UrlEncoded: []data.PostmanKey{}}
+	case "multipartForm":
+		return &data.PostmanBody{Mode: "formdata", FormData: []data.PostmanKey{}}
+	}
+
+	var body = &data.PostmanBody{Mode: "raw", Raw: example, Options: &data.PostmanBodyOptions{}}
+	body.Options.Raw.Language = brunoBodyMode(requestBody)
+	return body
+}
+
+// postmanAuth uses the first auth mode of the endpoint, postman only supports one per request.
+// Function keys are sent in the x-functions-key header, every other mode is a bearer token
+func postmanAuth(authentication []string) *data.PostmanAuth {
+	if len(authentication) < 1 {
+		return nil // inherit from the collection
+	}
+	var variable = "{{" + postmanVariable(authentication[0]) + "}}"
+	if functionKeySchemes[authentication[0]] {
+		return &data.PostmanAuth{
+			Type: "apikey",
+			Apikey: []data.PostmanKey{
+				{Key: "key", Value: "x-functions-key", Type: "string"},
+				{Key: "value", Value: variable, Type: "string"},
+				{Key: "in", Value: "header", Type: "string"},
+			},
+		}
+	}
+	return &data.PostmanAuth{
+		Type: "bearer",
+		Bearer: []data.PostmanKey{
+			{Key: "token", Value: variable, Type: "string"},
+		},
+	}
+}
+
+func postmanRequest(endpoint data.EndpointMetaData) data.PostmanItem {
+	var headers = []data.PostmanKey{}
+	for _, parameter := range endpoint.Parameters {
+		if parameter.In == "header" {
+			headers = append(headers, data.PostmanKey{
+				Key:         parameter.Name,
+				Value:       "",
+				Disabled:    !parameter.Required,
+				Description: parameter.Description,
+			})
+		}
+	}
+	if endpoint.RequestBody != nil && len(endpoint.RequestBody.ContentType) > 0 {
+		headers = append(headers, data.PostmanKey{Key: "Content-Type", Value: endpoint.RequestBody.ContentType})
+	}
+
+	var description = endpoint.Description
+	if len(endpoint.Authentication) > 1 {
+		description = strings.TrimSpace(description + "\n\nAlso accepts: " + strings.Join(endpoint.Authentication[1:], ", "))
+	}
+
+	return data.PostmanItem{
+		Id:   endpoint.Id,
+		Name: endpoint.Name,
+		Request: &data.PostmanRequest{
+			Method:      strings.ToUpper(endpoint.Methods[0]),
+			Header:      headers,
+			Body:        postmanBody(endpoint.RequestBody, endpoint.Body),
+			Url:         postmanUrl(endpoint),
+			Auth:        postmanAuth(endpoint.Authentication),
+			Description: description,
+		},
+		Response: []any{},
+	}
+}
+
+// postmanFolder returns the key and name of the folder the endpoint goes in, the class it is declared in
+// (or the file if the class is unknown). The key is qualified with the project since the same class can be in several function apps
+func postmanFolder(endpoint data.EndpointMetaData) (string, string) {
+	if len(endpoint.ClassName) > 0 {
+		return path.Join(endpoint.Project, endpoint.ClassName), endpoint.ClassName[strings.LastIndex(endpoint.ClassName, ".")+1:]
+	}
+	return endpoint.FilePath, strings.TrimSuffix(utils.Base(endpoint.FilePath), ".cs")
+}
+
+// this returns the serialized request item(s) for a single endpoint, one per method
+func (p PostmanDocumenter) SerializeRequest(endpoint data.EndpointMetaData) (string, error) {
+	if !p.Supports(endpoint.TriggerType) {
+		return "", fmt.Errorf("endpoint %s is not an HTTP trigger", endpoint.Name)
+	}
+
+	var items = []data.PostmanItem{}
+	for _, request := range requestsPerMethod(endpoint) {
+		items = append(items, postmanRequest(request))
+	}
+
+	itemJson, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error serializing request: %s", err.Error())
+	}
+	return string(itemJson), nil
+}
+
+func (p PostmanDocumenter) SerializeRequests(endpoints []data.EndpointMetaData, collectionName string, outputDir string, separateFiles bool, vars map[string]string, logger *logrus.Logger) bool {
+	// separateFiles is a no-op for postman, it outputs a single collection file
+
+	var collection = data.PostmanCollection{
+		Info: data.PostmanInfo{
+			Name:   collectionName,
+			Schema: data.PostmanSchema,
+		},
+		Item: []data.PostmanItem{},
+	}
+
+	// group the requests into folders, in the order the endpoints are given
+	var folders = []data.PostmanItem{}
+	var folderIndexes = make(map[string]int)
+	var folderNames = make(map[string][]string)
+	var authModes = []string{}
+	for _, endpoint := range endpoints {
+		if !p.Supports(endpoint.TriggerType) {
+			continue
+		}
+
+		var key, name = postmanFolder(endpoint)
+		if _, exists := folderIndexes[key]; !exists {
+			folderIndexes[key] = len(folders)
+			folders = append(folders, data.PostmanItem{Name: name, Item: []data.PostmanItem{}})
+			folderNames[name] = append(folderNames[name], key)
+		}
+		for _, request := range requestsPerMethod(endpoint) {
+			folders[folderIndexes[key]].Item = append(folders[folderIndexes[key]].Item, postmanRequest(request))
+		}
+
+		for _, auth := range endpoint.Authentication {
+			if !slices.Contains(authModes, auth) {
+				authModes = append(authModes, auth)
+			}
+		}
+	}
+
+	// classes with the same name in different namespaces (or projects) get the qualified name
+	for _, keys := range folderNames {
+		if len(keys) > 1 {
+			for _, key := range keys {
+				folders[folderIndexes[key]].Name = key
+			}
+		}
+	}
+	collection.Item = folders
+
+	var keys = make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		collection.Variable = append(collection.Variable, data.PostmanVariable{Key: key, Value: vars[key], Type: "string"})
+	}
+	for _, auth := range authModes {
+		if _, exists := vars[postmanVariable(auth)]; !exists {
+			collection.Variable = append(collection.Variable, data.PostmanVariable{Key: postmanVariable(auth), Value: "", Type: "string"})
+		}
+	}
+
+	var filePath = path.Join(outputDir, collectionName+p.Extension())
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		logger.Error("PostmanDocumenter SerializeRequests - Error opening collection file: " + err.Error())
+		return false
+	}
+	defer file.Close()
+
+	var encoder = json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(collection); err != nil {
+		logger.Error("PostmanDocumenter SerializeRequests - Error writing collection file: " + err.Error())
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,91 @@
+package documenters
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+)
+
+func Test_PostmanDocumenter_SerializeRequests_WritesCollection(t *testing.T) {
+	// Arrange
+	var outputDir = t.TempDir()
+	var endpoints = []data.EndpointMetaData{
+		{
+			Id:             "Catalog/Repo.Items/GetItem/GET,POST",
+			Name:           "GetItem",
+			Project:        "Catalog",
+			ClassName:      "Repo.Items",
+			Route:          "api/items/{itemId}",
+			Methods:        []string{"get", "post"},
+			PathParameters: map[string]string{"itemId": ""},
+			Parameters:     []data.ParameterMetaData{{Name: "filter", In: "query", Required: true}, {Name: "page", In: "query"}},
+			RequestBody:    &data.RequestBodyMetaData{ContentType: "application/json"},
+			Authentication: []string{"DocsToken", "S2SToken"},
+			TriggerType:    data.TriggerType["Http"],
+		},
+		{Name: "HealthCheck", Project: "Orders", ClassName: "Repo.Items", Route: "health", Methods: []string{"get"}, TriggerType: data.TriggerType["Http"]},
+		{Name: "Cleanup", ClassName: "Repo.Jobs", TriggerType: data.TriggerType["Timer"]},
+	}
+
+	// Act
+	var success = PostmanDocumenter{}.SerializeRequests(endpoints, "test", outputDir, false, map[string]string{"host": "http://localhost:7071"}, testLogger)
+
+	// Assert
+	if !success {
+		t.Fatalf("expected the collection to be written")
+	}
+	var collection data.PostmanCollection
+	var fileData, _ = os.ReadFile(path.Join(outputDir, "test.postman_collection.json"))
+	if err := json.Unmarshal(fileData, &collection); err != nil {
+		t.Fatalf("error parsing collection: %s", err.Error())
+	}
+
+	utils.AssertStringEqual(t, data.PostmanSchema, collection.Info.Schema)
+	utils.AssertEqual(t, 2, len(collection.Item))
+	utils.AssertStringEqual(t, "Catalog/Repo.Items", collection.Item[0].Name)
+	utils.AssertStringEqual(t, "Orders/Repo.Items", collection.Item[1].Name)
+
+	var requests = collection.Item[0].Item
+	utils.AssertEqual(t, 2, len(requests))
+	utils.AssertStringEqual(t, "GetItem (POST)", requests[1].Name)
+	utils.AssertStringEqual(t, "Catalog/Repo.Items/GetItem/POST", requests[1].Id)
+	utils.AssertStringEqual(t, "POST", requests[1].Request.Method)
+	utils.AssertStringEqual(t, "{{host}}/api/items/:itemId?filter=", requests[1].Request.Url.Raw)
+	utils.AssertSliceEqual(t, []string{"api", "items", ":itemId"}, requests[1].Request.Url.Path)
+	utils.AssertStringEqual(t, "itemId", requests[1].Request.Url.Variable[0].Key)
+	utils.AssertEqual(t, 2, len(requests[1].Request.Url.Query))
+	utils.AssertStringEqual(t, "json", requests[1].Request.Body.Options.Raw.Language)
+	utils.AssertStringEqual(t, "{{DocsToken}}", requests[1].Request.Auth.Bearer[0].Value)
+
+	var variables = []string{}
+	for _, variable := range collection.Variable {
+		variables = append(variables, variable.Key)
+	}
+	utils.AssertSliceEqual(t, []string{"host", "DocsToken", "S2SToken"}, variables)
+}
+
+func Test_postmanAuth_SendsFunctionKeysInAHeader(t *testing.T) {
+	// Act
+	var functionKey = postmanAuth([]string{"FunctionKey", "DocsToken"})
+	var adminKey = postmanAuth([]string{"AdminKey"})
+	var token = postmanAuth([]string{"DocsToken", "FunctionKey"})
+
+	// Assert
+	utils.AssertStringEqual(t, "apikey", functionKey.Type)
+	utils.AssertEqual(t, 0, len(functionKey.Bearer))
+	var values = []string{}
+	for _, key := range functionKey.Apikey {
+		values = append(values, key.Key+"="+key.Value)
+	}
+	utils.AssertSliceEqual(t, []string{"key=x-functions-key", "value={{FunctionKey}}", "in=header"}, values)
+	utils.AssertStringEqual(t, "apikey", adminKey.Type)
+	utils.AssertStringEqual(t, "{{AdminKey}}", adminKey.Apikey[1].Value)
+	utils.AssertStringEqual(t, "bearer", token.Type)
+	utils.AssertStringEqual(t, "{{DocsToken}}", token.Bearer[0].Value)
+	if postmanAuth([]string{}) != nil {
+		t.Fatalf("expected requests without auth to inherit the auth of the collection")
+	}
+}
@@ -38,9 +38,9 @@ func (rawDocumenter RawDocumenter) SerializeRequests(endpoints []data.EndpointMe
 
 	if separateFiles {
 		// write out the endpoints to individual files
-		for _, endpoint := range endpoints {
-			// TODO: Function name is a not a primary key (can have duplicates), live with this overwriting duplicates endpoints for now
-			var filePath = path.Join(outputDir, endpoint.Name+rawDocumenter.Extension())
+		var fileNames = FileNames(endpoints)
+		for i, endpoint := range endpoints {
+			var filePath = path.Join(outputDir, fileNames[i]+rawDocumenter.Extension())
 			file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 			if err != nil {
 				logger.Error("RawDocumenter SerializeRequests - Error opening endpoint file: " + err.Error())
@@ -0,0 +1,431 @@
+package documenters
+
+import (
+	"documentApi/data"
+	"documentApi/ncrontab"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ScheduleDocumenter writes a timeline of when the timer triggers run (a markdown report, an ics calendar
+// and a mermaid gantt chart) and flags the ones that overlap or run too often
+type ScheduleDocumenter struct {
+	MaxRunsPerHour int       // timer triggers that run more often are flagged, DefaultMaxRunsPerHour when 0
+	From           time.Time // the timeline starts at the beginning of this day, today when zero
+}
+
+const DefaultMaxRunsPerHour = 12
+
+// how long a run is assumed to take, runs of different timer triggers closer together than this overlap
+const scheduleRunWindow = 5 * time.Minute
+
+// timer triggers that run more often than this in a day are drawn as a single block for the day in the chart and calendar
+const scheduleDailyRunLimit = 24
+
+const scheduleDayFormat = "Mon 02 Jan"
+
+// scheduledJob is a timer trigger and its runs during the week of the timeline, runs is nil when the schedule isn't valid
+type scheduledJob struct {
+	endpoint data.EndpointMetaData
+	label    string // the function name, qualified when it isn't unique
+	runs     []time.Time
+}
+
+// scheduleOverlap is a pair of timer triggers with runs that are closer together than the run window
+type scheduleOverlap struct {
+	first  int // the indexes of the jobs
+	second int
+	count  int // the number of runs of the first job that overlap a run of the second
+	at     time.Time
+}
+
+// scheduleBlock is a run, or all the runs of a day for the jobs that run more than scheduleDailyRunLimit times a day
+type scheduleBlock struct {
+	start time.Time
+	end   time.Time
+	runs  int
+}
+
+func (s ScheduleDocumenter) Extension() string {
+	return ".md"
+}
+
+func (s ScheduleDocumenter) Name() string {
+	return "schedule"
+}
+
+func (s ScheduleDocumenter) Supports(triggerType string) bool {
+	return triggerType == data.TriggerType["Timer"]
+}
+
+func (s ScheduleDocumenter) SerializeRequest(endpoint data.EndpointMetaData) (string, error) {
+	if !s.Supports(endpoint.TriggerType) {
+		return "", fmt.Errorf("ScheduleDocumenter SerializeRequest - '%s' is not a timer trigger", endpoint.Name)
+	}
+	return fmt.Sprintf("| %s | %s | %s |", endpoint.Name, strings.ReplaceAll(endpoint.Interval, "*", "\\*"), scheduleDescription(endpoint)), nil
+}
+
+func (s ScheduleDocumenter) maxRunsPerHour() int {
+	if s.MaxRunsPerHour > 0 {
+		return s.MaxRunsPerHour
+	}
+	return DefaultMaxRunsPerHour
+}
+
+func scheduleDescription(endpoint data.EndpointMetaData) string {
+	if endpoint.Schedule == nil {
+		return "invalid or unresolved schedule"
+	}
+	return endpoint.Schedule.Description
+}
+
+// timeline computes the runs of the timer triggers for the week starting at the beginning of the From day,
+// in the time zone the schedules were described in
+func (s ScheduleDocumenter) timeline(endpoints []data.EndpointMetaData) ([]scheduledJob, time.Time) {
+	var location = time.UTC
+	for _, endpoint := range endpoints {
+		if endpoint.Schedule != nil {
+			if zone, err := time.LoadLocation(endpoint.Schedule.TimeZone); err == nil {
+				location = zone
+			}
+			break
+		}
+	}
+
+	var from = s.From
+	if from.IsZero() {
+		from = time.Now()
+	}
+	from = from.In(location)
+	var start = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, location)
+	var end = start.AddDate(0, 0, 7)
+
+	var timers = []data.EndpointMetaData{}
+	for _, endpoint := range endpoints {
+		if s.Supports(endpoint.TriggerType) {
+			timers = append(timers, endpoint)
+		}
+	}
+
+	var jobs = []scheduledJob{}
+	var labels = shortNames(timers)
+	for i, endpoint := range timers {
+		var job = scheduledJob{endpoint: endpoint, label: labels[i]}
+		if schedule, err := ncrontab.Parse(endpoint.Interval); err == nil {
+			job.runs = []time.Time{}
+			// start just before the day, so a run at midnight is included
+			for after := start.Add(-time.Nanosecond); ; {
+				var next = schedule.Next(after, 1)
+				if len(next) < 1 || !next[0].Before(end) {
+					break
+				}
+				after = next[0]
+				job.runs = append(job.runs, next[0].Round(time.Second))
+			}
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, start
+}
+
+// mostRunsInAnHour returns the most times the job runs within one (clock) hour
+func mostRunsInAnHour(runs []time.Time) int {
+	var perHour = make(map[string]int)
+	var most = 0
+	for _, run := range runs {
+		var hour = run.Format("2006-01-02T15")
+		perHour[hour]++
+		most = max(most, perHour[hour])
+	}
+	return most
+}
+
+// findOverlaps returns the pairs of jobs that have runs closer together than the run window
+func findOverlaps(jobs []scheduledJob) []scheduleOverlap {
+	var overlaps = []scheduleOverlap{}
+	for first := range jobs {
+		for second := first + 1; second < len(jobs); second++ {
+			var overlap = scheduleOverlap{first: first, second: second}
+			var other = 0
+			for _, run := range jobs[first].runs {
+				// skip the runs of the other job that end before this one starts
+				for other < len(jobs[second].runs) && !jobs[second].runs[other].After(run.Add(-scheduleRunWindow)) {
+					other++
+				}
+				if other < len(jobs[second].runs) && jobs[second].runs[other].Before(run.Add(scheduleRunWindow)) {
+					if overlap.count == 0 {
+						overlap.at = run
+						if jobs[second].runs[other].Before(run) {
+							overlap.at = jobs[second].runs[other]
+						}
+					}
+					overlap.count++
+				}
+			}
+			if overlap.count > 0 {
+				overlaps = append(overlaps, overlap)
+			}
+		}
+	}
+	return overlaps
+}
+
+// scheduleBlocks groups the runs into the blocks drawn in the chart and calendar, a block per run or a block
+// for the whole day when the job runs more than scheduleDailyRunLimit times that day
+func scheduleBlocks(runs []time.Time) []scheduleBlock {
+	var blocks = []scheduleBlock{}
+	for i := 0; i < len(runs); {
+		var day = i
+		for day < len(runs) && runs[day].YearDay() == runs[i].YearDay() {
+			day++
+		}
+		if day-i > scheduleDailyRunLimit {
+			blocks = append(blocks, scheduleBlock{start: runs[i], end: runs[day-1].Add(scheduleRunWindow), runs: day - i})
+		} else {
+			for _, run := range runs[i:day] {
+				blocks = append(blocks, scheduleBlock{start: run, end: run.Add(scheduleRunWindow), runs: 1})
+			}
+		}
+		i = day
+	}
+	return blocks
+}
+
+// overlapsRun reports whether the run of the job overlaps a run of another job
+func overlapsRun(jobs []scheduledJob, job int, run time.Time) bool {
+	for other := range jobs {
+		if other == job {
+			continue
+		}
+		for _, otherRun := range jobs[other].runs {
+			if otherRun.After(run.Add(-scheduleRunWindow)) && otherRun.Before(run.Add(scheduleRunWindow)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// scheduleWarnings returns the overlapping and too frequent timer triggers
+func (s ScheduleDocumenter) scheduleWarnings(jobs []scheduledJob) []string {
+	var warnings = []string{}
+	for _, overlap := range findOverlaps(jobs) {
+		warnings = append(warnings, fmt.Sprintf("`%s` and `%s` overlap %d times this week, first at %s", jobs[overlap.first].label, jobs[overlap.second].label, overlap.count, overlap.at.Format(scheduleDayFormat+" 15:04")))
+	}
+	for _, job := range jobs {
+		if most := mostRunsInAnHour(job.runs); most > s.maxRunsPerHour() {
+			warnings = append(warnings, fmt.Sprintf("`%s` runs up to %d times an hour, more than %d", job.label, most, s.maxRunsPerHour()))
+		}
+	}
+	return warnings
+}
+
+func runCount(count int) string {
+	if count == 0 {
+		return ""
+	}
+	return strconv.Itoa(count)
+}
+
+// scheduleMarkdown is the report with the timer triggers, their runs per hour of the first day and per day of the week, and the warnings
+func (s ScheduleDocumenter) scheduleMarkdown(jobs []scheduledJob, start time.Time, warnings []string) string {
+	var markdown = "# Timer Triggers\n\n"
+	markdown += fmt.Sprintf("Times are in %s, for the week from %s. Runs of different triggers within %s of each other overlap, triggers that run more than %d times an hour run too often.\n\n", start.Location().String(), start.Format(scheduleDayFormat+" 2006"), scheduleRunWindow.String(), s.maxRunsPerHour())
+	if len(jobs) < 1 {
+		return markdown + "No timer triggers found.\n"
+	}
+
+	var table = "| Function Name | Interval | Schedule | Runs This Week | Most Runs In An Hour | File Path |\n"
+	table += "|--------|--------|--------|--------|--------|--------|\n"
+	for _, job := range jobs {
+		table += fmt.Sprintf("| %s | %s | %s | %d | %d | %s |\n", job.label, strings.ReplaceAll(job.endpoint.Interval, "*", "\\*"), scheduleDescription(job.endpoint), len(job.runs), mostRunsInAnHour(job.runs), job.endpoint.FilePath)
+	}
+	markdown += formatMarkdownTable(table) + "\n\n"
+
+	// the runs per hour of the first day
+	var end = start.AddDate(0, 0, 1)
+	var header, separator = "| Function Name |", "|--------|"
+	for hour := 0; hour < 24; hour++ {
+		header += fmt.Sprintf(" %02d |", hour)
+		separator += "----|"
+	}
+	table = header + "\n" + separator + "\n"
+	var totals = make([]int, 24)
+	for _, job := range jobs {
+		var perHour = make([]int, 24)
+		for _, run := range job.runs {
+			if run.Before(end) {
+				perHour[run.Hour()]++
+				totals[run.Hour()]++
+			}
+		}
+		table += "| " + job.label + " |"
+		for _, count := range perHour {
+			table += " " + runCount(count) + " |"
+		}
+		table += "\n"
+	}
+	table += "| **Total** |"
+	for _, count := range totals {
+		table += " " + runCount(count) + " |"
+	}
+	markdown += "## " + start.Format(scheduleDayFormat) + "\n\n" + formatMarkdownTable(table) + "\n\n"
+
+	// the runs per day of the week
+	header, separator = "| Function Name |", "|--------|"
+	for day := 0; day < 7; day++ {
+		header += " " + start.AddDate(0, 0, day).Format(scheduleDayFormat) + " |"
+		separator += "--------|"
+	}
+	table = header + "\n" + separator + "\n"
+	totals = make([]int, 7)
+	for _, job := range jobs {
+		var perDay = make([]int, 7)
+		for _, run := range job.runs {
+			for day := 6; day >= 0; day-- {
+				if !run.Before(start.AddDate(0, 0, day)) {
+					perDay[day]++
+					totals[day]++
+					break
+				}
+			}
+		}
+		table += "| " + job.label + " |"
+		for _, count := range perDay {
+			table += " " + runCount(count) + " |"
+		}
+		table += "\n"
+	}
+	table += "| **Total** |"
+	for _, count := range totals {
+		table += " " + runCount(count) + " |"
+	}
+	markdown += "## Week\n\n" + formatMarkdownTable(table) + "\n\n"
+
+	markdown += "## Warnings\n\n"
+	if len(warnings) < 1 {
+		return markdown + "No overlapping or too frequent timer triggers.\n"
+	}
+	for _, warning := range warnings {
+		markdown += "- ⚠️ " + warning + "\n"
+	}
+	return markdown
+}
+
+// mermaidText removes the characters that end a task or section name in a mermaid gantt chart
+func mermaidText(text string) string {
+	return strings.NewReplacer(":", " ", ";", " ", "#", " ", "\n", " ").Replace(text)
+}
+
+// scheduleGantt is a mermaid gantt chart of the runs on the first day, the runs that overlap another trigger are marked critical
+func scheduleGantt(jobs []scheduledJob, start time.Time) string {
+	var end = start.AddDate(0, 0, 1)
+	var chart = "---\ndisplayMode: compact\n---\ngantt\n"
+	chart += "    title Timer triggers on " + start.Format(scheduleDayFormat+" 2006") + " (" + start.Location().String() + ")\n"
+	chart += "    dateFormat YYYY-MM-DDTHH:mm\n"
+	chart += "    axisFormat %H:%M\n"
+	for i, job := range jobs {
+		var runs = []time.Time{}
+		for _, run := range job.runs {
+			if run.Before(end) {
+				runs = append(runs, run)
+			}
+		}
+		if len(runs) < 1 {
+			continue
+		}
+
+		chart += "    section " + mermaidText(job.label) + "\n"
+		for _, block := range scheduleBlocks(runs) {
+			var name = mermaidText(job.label)
+			var tag = ""
+			if block.runs > 1 {
+				name += " (" + strconv.Itoa(block.runs) + " runs)"
+			} else if overlapsRun(jobs, i, block.start) {
+				tag = "crit, "
+			}
+			chart += fmt.Sprintf("    %s :%s%s, %dm\n", name, tag, block.start.Format("2006-01-02T15:04"), int(block.end.Sub(block.start).Minutes()))
+		}
+	}
+	return chart
+}
+
+// icsText escapes the characters with a meaning in ics text values
+func icsText(text string) string {
+	return strings.NewReplacer("\\", "\\\\", ";", "\\;", ",", "\\,", "\n", "\\n").Replace(text)
+}
+
+// icsLine folds the content line into lines of at most 75 octets, without splitting characters
+func icsLine(line string) string {
+	var folded strings.Builder
+	var length = 0
+	for _, character := range line {
+		var size = len(string(character))
+		if length+size > 75 {
+			folded.WriteString("\r\n ")
+			length = 1
+		}
+		folded.WriteRune(character)
+		length += size
+	}
+	return folded.String() + "\r\n"
+}
+
+// scheduleCalendar is an ics calendar with an event for each run (or day of runs) of the timer triggers during the week
+func scheduleCalendar(jobs []scheduledJob, collectionName string, stamp time.Time) string {
+	const icsTime = "20060102T150405Z"
+	var calendar = icsLine("BEGIN:VCALENDAR") + icsLine("VERSION:2.0") + icsLine("PRODID:-//documentApi//Timer Triggers//EN") + icsLine("CALSCALE:GREGORIAN")
+	calendar += icsLine("X-WR-CALNAME:" + icsText(collectionName+" timer triggers"))
+	for _, job := range jobs {
+		for _, block := range scheduleBlocks(job.runs) {
+			var summary = job.label
+			if block.runs > 1 {
+				summary += " (" + strconv.Itoa(block.runs) + " runs)"
+			}
+			calendar += icsLine("BEGIN:VEVENT")
+			calendar += icsLine("UID:" + icsText(job.endpoint.Id+"-"+block.start.UTC().Format(icsTime)) + "@documentApi")
+			calendar += icsLine("DTSTAMP:" + stamp.UTC().Format(icsTime))
+			calendar += icsLine("DTSTART:" + block.start.UTC().Format(icsTime))
+			calendar += icsLine("DTEND:" + block.end.UTC().Format(icsTime))
+			calendar += icsLine("SUMMARY:" + icsText(summary))
+			calendar += icsLine("DESCRIPTION:" + icsText(scheduleDescription(job.endpoint)+" ("+job.endpoint.Interval+")\n"+job.endpoint.FilePath))
+			calendar += icsLine("END:VEVENT")
+		}
+	}
+	return calendar + icsLine("END:VCALENDAR")
+}
+
+func (s ScheduleDocumenter) SerializeRequests(endpoints []data.EndpointMetaData, collectionName string, outputDir string, separateFiles bool, vars map[string]string, logger *logrus.Logger) bool {
+	// separateFiles is a no-op for the schedule, the timeline is about all the timer triggers together
+	// vars is not used in this documenter
+
+	var jobs, start = s.timeline(endpoints)
+	var warnings = s.scheduleWarnings(jobs)
+	for _, warning := range warnings {
+		logger.Warn("ScheduleDocumenter SerializeRequests - " + strings.ReplaceAll(warning, "`", "'"))
+	}
+
+	var files = []struct {
+		extension string
+		content   string
+	}{
+		{s.Extension(), s.scheduleMarkdown(jobs, start, warnings)},
+		{".ics", scheduleCalendar(jobs, collectionName, start)},
+		{".mmd", scheduleGantt(jobs, start)},
+	}
+	for _, file := range files {
+		var filePath = path.Join(outputDir, collectionName+file.extension)
+		if err := os.WriteFile(filePath, []byte(file.content), 0644); err != nil {
+			logger.Error("ScheduleDocumenter SerializeRequests - Error writing schedule file '" + filePath + "': " + err.Error())
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,102 @@
+package documenters
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+// three heavy jobs all firing at midnight, next to a job that runs every minute
+var testScheduleEndpoints = []data.EndpointMetaData{
+	{Id: "Reindex", Name: "Reindex", TriggerType: data.TriggerType["Timer"], Interval: "0 0 0 * * *", Schedule: &data.ScheduleMetaData{Description: "at 00:00", TimeZone: "UTC"}},
+	{Id: "Backup", Name: "Backup", TriggerType: data.TriggerType["Timer"], Interval: "0 0 0 * * *", Schedule: &data.ScheduleMetaData{Description: "at 00:00", TimeZone: "UTC"}},
+	{Id: "Report", Name: "Report", TriggerType: data.TriggerType["Timer"], Interval: "0 2 0 * * 1-5", Schedule: &data.ScheduleMetaData{Description: "at 00:02, Mon–Fri", TimeZone: "UTC"}},
+	{Id: "Heartbeat", Name: "Heartbeat", TriggerType: data.TriggerType["Timer"], Interval: "0 * 12-13 * * *", Schedule: &data.ScheduleMetaData{Description: "every minute between 12:00 and 13:59", TimeZone: "UTC"}},
+	{Id: "Archive", Name: "Archive", TriggerType: data.TriggerType["Timer"], Interval: "%ArchiveSchedule%"},
+	{Id: "GetItems", Name: "GetItems", TriggerType: data.TriggerType["Http"], Route: "items"},
+}
+
+// a monday
+var testScheduleFrom = time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+
+func Test_ScheduleDocumenter_scheduleWarnings_FlagsOverlapsAndFrequentTriggers(t *testing.T) {
+	// Arrange
+	var documenter = ScheduleDocumenter{MaxRunsPerHour: 30, From: testScheduleFrom}
+	var jobs, start = documenter.timeline(testScheduleEndpoints)
+
+	// Act
+	var warnings = documenter.scheduleWarnings(jobs)
+
+	// Assert
+	utils.AssertStringEqual(t, "2026-10-19T00:00:00Z", start.Format(time.RFC3339))
+	utils.AssertEqual(t, 5, len(jobs))
+	utils.AssertEqual(t, 7, len(jobs[0].runs))
+	utils.AssertEqual(t, 5, len(jobs[2].runs))
+	utils.AssertEqual(t, 0, len(jobs[4].runs))
+	utils.AssertEqual(t, 4, len(warnings))
+	utils.AssertStringEqual(t, "`Reindex` and `Backup` overlap 7 times this week, first at Mon 19 Oct 00:00", warnings[0])
+	utils.AssertStringEqual(t, "`Reindex` and `Report` overlap 5 times this week, first at Mon 19 Oct 00:00", warnings[1])
+	utils.AssertStringEqual(t, "`Backup` and `Report` overlap 5 times this week, first at Mon 19 Oct 00:00", warnings[2])
+	utils.AssertStringEqual(t, "`Heartbeat` runs up to 60 times an hour, more than 30", warnings[3])
+}
+
+func Test_ScheduleDocumenter_SerializeRequests_WritesTimeline(t *testing.T) {
+	// Arrange
+	var outputDir = t.TempDir()
+	var documenter = ScheduleDocumenter{From: testScheduleFrom}
+
+	// Act
+	var written = documenter.SerializeRequests(testScheduleEndpoints, "test", outputDir, false, nil, testLogger)
+
+	// Assert
+	if !written {
+		t.Fatalf("Expected the schedule to be written")
+	}
+	var markdown, _ = os.ReadFile(path.Join(outputDir, "test.md"))
+	for _, expected := range []string{
+		"| Reindex       | 0 0 0 \\* \\* \\*      | at 00:00 ",
+		"| Archive       | %ArchiveSchedule%   | invalid or unresolved schedule ",
+		"| **Total**     | 3  |    |",
+		"- ⚠️ `Heartbeat` runs up to 60 times an hour, more than 12",
+	} {
+		if !strings.Contains(string(markdown), expected) {
+			t.Errorf("Expected the markdown to contain '%s', got:\n%s", expected, markdown)
+		}
+	}
+
+	var chart, _ = os.ReadFile(path.Join(outputDir, "test.mmd"))
+	for _, expected := range []string{
+		"    section Reindex\n    Reindex :crit, 2026-10-19T00:00, 5m\n",
+		"    section Heartbeat\n    Heartbeat (120 runs) :2026-10-19T12:00, 124m\n",
+	} {
+		if !strings.Contains(string(chart), expected) {
+			t.Errorf("Expected the chart to contain '%s', got:\n%s", expected, chart)
+		}
+	}
+
+	var calendar, _ = os.ReadFile(path.Join(outputDir, "test.ics"))
+	utils.AssertEqual(t, 7+7+5+7, strings.Count(string(calendar), "BEGIN:VEVENT"))
+	if !strings.Contains(string(calendar), "UID:Report-20261023T000200Z@documentApi\r\n") || !strings.Contains(string(calendar), "DESCRIPTION:at 00:02\\, Mon–Fri (0 2 0 * * 1-5)\\n\r\n") {
+		t.Errorf("Expected the calendar to contain the events of the report, got:\n%s", calendar)
+	}
+}
+
+func Test_icsLine_FoldsLongLines(t *testing.T) {
+	// Arrange
+	var line = "DESCRIPTION:" + strings.Repeat("–", 30)
+
+	// Act
+	var folded = icsLine(line)
+
+	// Assert
+	for _, part := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("Expected lines of at most 75 octets, got %d: %s", len(part), part)
+		}
+	}
+	utils.AssertStringEqual(t, line, strings.ReplaceAll(strings.TrimSuffix(folded, "\r\n"), "\r\n ", ""))
+}
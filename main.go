@@ -4,22 +4,25 @@ import (
 	"context"
 	"documentApi/data"
 	"documentApi/documenters"
+	"documentApi/parsers"
 	"documentApi/utils"
 	"flag"
 	"fmt"
 	"net/http"
 	"os"
 	"path"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+	_ "time/tzdata" // the time zone database isn't always installed (e.g. on windows)
 
 	"github.com/joho/godotenv"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sirupsen/logrus"
 )
 
-// TODO: add option to keep old vars (env, path params, etc) from existing collections upon updating
 // TODO: add option to create documentation for a specific list of trigger types
 // TODO: should allow more then just host as env var to be passed
 
@@ -27,26 +30,40 @@ const Version string = "v1.0.7-beta"
 const DefaultRepoPath string = "."
 const DefaultHost string = "http://localhost:7071"
 const DefaultSortKey string = "name"
+const DefaultParsers string = "all"
+const DefaultTimeZone string = "UTC"
+const ScheduleRuns int = 5 // the number of next runs documented for the timer triggers
 
 var DefaultDocumenterType = documenters.RawDocumenter{}.Name()
 var DefaultArgs = map[string]string{}
 
-var Documenters map[string]documenters.Documenter = make(map[string]documenters.Documenter, 4)
+var Documenters map[string]documenters.Documenter = make(map[string]documenters.Documenter, 7)
+var Parsers map[string]parsers.Parser = make(map[string]parsers.Parser, 4)
 
 func initDocumenters() {
 	Documenters[documenters.RawDocumenter{}.Name()] = documenters.RawDocumenter{}
 	Documenters[documenters.BrunoDocumenter{}.Name()] = documenters.BrunoDocumenter{}
 	Documenters[documenters.MarkdownDocumenter{}.Name()] = documenters.MarkdownDocumenter{}
 	Documenters[documenters.InsomniaDocumenter{}.Name()] = documenters.InsomniaDocumenter{}
+	Documenters[documenters.OpenApiDocumenter{}.Name()] = documenters.OpenApiDocumenter{}
+	Documenters[documenters.PostmanDocumenter{}.Name()] = documenters.PostmanDocumenter{}
+	Documenters[documenters.ScheduleDocumenter{}.Name()] = documenters.ScheduleDocumenter{}
+}
+
+func initParsers() {
+	Parsers[parsers.CSharpParser{}.Name()] = parsers.CSharpParser{}
+	Parsers[parsers.FunctionJsonParser{}.Name()] = parsers.FunctionJsonParser{}
+	Parsers[parsers.NodeParser{}.Name()] = parsers.NodeParser{}
+	Parsers[parsers.PythonParser{}.Name()] = parsers.PythonParser{}
 }
 
 // TODO: remove this, why am I still maintaining this
 func writeResults(endpoints []data.EndpointMetaData, docType string, outputDir string, logger *logrus.Logger) {
 	// TODO: some of these documenters don't document a single request per file, e.g. insomnia
-	for _, endpoint := range endpoints {
+	var fileNames = documenters.FileNames(endpoints)
+	for i, endpoint := range endpoints {
 		if Documenters[docType].Supports(endpoint.TriggerType) {
-			// TODO: Function name is a not a primary key (can have duplicates), live with this overwriting duplicates endpoints for now
-			var filePath = path.Join(outputDir, endpoint.Name+Documenters[docType].Extension())
+			var filePath = path.Join(outputDir, fileNames[i]+Documenters[docType].Extension())
 			file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 			if err != nil {
 				logger.Error("Error opening file: " + err.Error())
@@ -73,6 +90,46 @@ func supportedDocumenters() string {
 	return stringList
 }
 
+func supportedParsers() string {
+	var names = []string{}
+	for name := range Parsers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(append(names, "all"), ", ")
+}
+
+// getParsers returns the parsers for a comma separated list of names (or all of them), in a stable order
+func getParsers(names string) ([]parsers.Parser, error) {
+	var selected = []string{}
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "all" {
+			selected = []string{}
+			for name := range Parsers {
+				selected = append(selected, name)
+			}
+			break
+		}
+		if len(name) < 1 {
+			continue
+		}
+		if _, exists := Parsers[name]; !exists {
+			return nil, fmt.Errorf("parser '%s' does not exist, supported parsers are: %s", name, supportedParsers())
+		}
+		if !slices.Contains(selected, name) {
+			selected = append(selected, name)
+		}
+	}
+	sort.Strings(selected)
+
+	var result = []parsers.Parser{}
+	for _, name := range selected {
+		result = append(result, Parsers[name])
+	}
+	return result, nil
+}
+
 // This could have been done better, if I used the same naming
 func getDefaultArg(arg string) string {
 	if _, yes := DefaultArgs["loaded"]; !yes {
@@ -85,6 +142,10 @@ func getDefaultArg(arg string) string {
 		DefaultArgs["docType"] = os.Getenv("DOC_TYPE")
 		DefaultArgs["outputDir"] = os.Getenv("OUTPUT_DIR")
 		DefaultArgs["sortKey"] = os.Getenv("SORT_KEY")
+		DefaultArgs["parsers"] = os.Getenv("PARSERS")
+		DefaultArgs["settingsEnv"] = os.Getenv("SETTINGS_ENV")
+		DefaultArgs["timeZone"] = os.Getenv("TIME_ZONE")
+		DefaultArgs["maxRunsPerHour"] = os.Getenv("MAX_RUNS_PER_HOUR")
 	}
 
 	switch arg {
@@ -113,10 +174,38 @@ func getDefaultArg(arg string) string {
 			return DefaultArgs["sortKey"]
 		}
 		return DefaultSortKey
+	case "parsers":
+		if len(DefaultArgs["parsers"]) > 0 {
+			return DefaultArgs["parsers"]
+		}
+		return DefaultParsers
+	case "settingsEnv":
+		return DefaultArgs["settingsEnv"]
+	case "timeZone":
+		if len(DefaultArgs["timeZone"]) > 0 {
+			return DefaultArgs["timeZone"]
+		}
+		return DefaultTimeZone
+	case "maxRunsPerHour":
+		if len(DefaultArgs["maxRunsPerHour"]) > 0 {
+			return DefaultArgs["maxRunsPerHour"]
+		}
+		return strconv.Itoa(documenters.DefaultMaxRunsPerHour)
 	}
 	return ""
 }
 
+// getDefaultMaxRunsPerHour is the default threshold of the schedule documenter as a number,
+// falling back to documenters.DefaultMaxRunsPerHour when MAX_RUNS_PER_HOUR isn't a number
+func getDefaultMaxRunsPerHour() int {
+	var maxRunsPerHour, err = strconv.Atoi(getDefaultArg("maxRunsPerHour"))
+	if err != nil {
+		fmt.Println("Invalid MAX_RUNS_PER_HOUR, using " + strconv.Itoa(documenters.DefaultMaxRunsPerHour))
+		return documenters.DefaultMaxRunsPerHour
+	}
+	return maxRunsPerHour
+}
+
 func getCollectionEnvVars(cmd *flag.FlagSet) map[string]string {
 	var collectionEnvVars = make(map[string]string)
 	var host = cmd.String("host", getDefaultArg("host"), "host string to prepend the http endpoints with")
@@ -125,7 +214,7 @@ func getCollectionEnvVars(cmd *flag.FlagSet) map[string]string {
 	return collectionEnvVars
 }
 
-func getPrefixKey(p string, prefixes map[string]string) string {
+func getPrefixKey[V any](p string, prefixes map[string]V) string {
 	for k := range prefixes {
 		if utils.HasParent(p, k) {
 			return k
@@ -134,6 +223,17 @@ func getPrefixKey(p string, prefixes map[string]string) string {
 	return ""
 }
 
+// logDiagnostics logs the problems found by a step of the processing, e.g. "Parser 'csharp'"
+func logDiagnostics(source string, diagnostics []parsers.Diagnostic, logger *logrus.Logger) {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == parsers.SeverityError {
+			logger.Error(source + " - " + diagnostic.String())
+		} else {
+			logger.Warn(source + " - " + diagnostic.String())
+		}
+	}
+}
+
 func process(params data.RunParams, logger *logrus.Logger) {
 	logger.Info("Processing repo: '" + *params.Repo + "' with documenter: '" + *params.DocType + "' will output to: '" + *params.OutputDir + "'")
 
@@ -148,8 +248,24 @@ func process(params data.RunParams, logger *logrus.Logger) {
 		return
 	}
 
-	// locate all the cs files in the repo
-	entries, err := utils.GetFiles(*params.Repo, []string{".cs"}, false, true, true)
+	var selectedParsers, err = getParsers(*params.Parsers)
+	if err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	location, err := time.LoadLocation(*params.TimeZone)
+	if err != nil {
+		logger.Error("Time zone '" + *params.TimeZone + "' does not exist: " + err.Error())
+		return
+	}
+
+	// locate all the source files the parsers read
+	var extensions = []string{}
+	for _, parser := range selectedParsers {
+		extensions = append(extensions, parser.Extensions()...)
+	}
+	entries, err := utils.GetFiles(*params.Repo, extensions, false, true, true)
 	if err != nil {
 		logger.Error("Error reading repo '" + *params.Repo + "': " + err.Error())
 		return
@@ -158,38 +274,60 @@ func process(params data.RunParams, logger *logrus.Logger) {
 	var prefixes = getApiPrefixes(*params.Repo, logger)
 	logger.Debug("Found prefixes: " + strconv.Itoa(len(prefixes)) + " in repo: " + *params.Repo)
 
-	// parse the cs files looking for all the endpoints/triggers
-	var endpointCount = 0
-	var endpoints = []data.EndpointMetaData{}
-	// should this be multithreaded?
-	for _, entry := range entries {
-		for _, endpoint := range parse(entry, logger) {
-			var prefixKey = getPrefixKey(entry.Path, prefixes)
-			if prefixes[prefixKey] != "" && len(endpoint.Route) > 0 {
-				endpoint.Route = path.Join("/", prefixes[prefixKey], endpoint.Route)
-			} else if prefixes[prefixKey] == "" && len(endpoint.Route) > 0 {
-				logger.Debug("No prefix found for endpoint: " + endpoint.Name + " in file: " + entry.Path)
+	var settings = getAppSettings(*params.Repo, *params.SettingsEnv, logger)
+	logger.Debug("Found settings for: " + strconv.Itoa(len(settings)) + " projects in repo: " + *params.Repo)
+
+	// parse the source files looking for all the endpoints/triggers
+	var found = []data.EndpointMetaData{}
+	for _, parser := range selectedParsers {
+		var files = []data.FileMetaData{}
+		for _, entry := range entries {
+			if parsers.Handles(parser, entry.Name) {
+				files = append(files, entry)
 			}
+		}
+
+		var parsed, diagnostics = parser.Parse(files, logger)
+		logDiagnostics("Parser '"+parser.Name()+"'", diagnostics, logger)
+		logger.Debug("Parser '" + parser.Name() + "' found " + strconv.Itoa(len(parsed)) + " endpoints in " + strconv.Itoa(len(files)) + " files")
+		found = append(found, parsed...)
+	}
 
-			endpoints = append(endpoints, endpoint)
-			logger.Info("Found endpoint: " + endpoint.String())
-			endpointCount++
+	var endpointCount = 0
+	var endpoints = []data.EndpointMetaData{}
+	for _, endpoint := range found {
+		var prefixKey = getPrefixKey(endpoint.FilePath, prefixes)
+		endpoint.Project = prefixKey
+		endpoint.Id = endpoint.GenerateId()
+		for _, name := range resolveSettings(&endpoint, settings[getPrefixKey(endpoint.FilePath, settings)]) {
+			logger.Warn("No setting '" + name + "' found for endpoint: " + endpoint.Name + " in file: " + endpoint.FilePath)
 		}
+		if prefixes[prefixKey] != "" && len(endpoint.Route) > 0 {
+			endpoint.Route = path.Join("/", prefixes[prefixKey], endpoint.Route)
+		} else if prefixes[prefixKey] == "" && len(endpoint.Route) > 0 {
+			logger.Debug("No prefix found for endpoint: " + endpoint.Name + " in file: " + endpoint.FilePath)
+		}
+
+		endpoints = append(endpoints, endpoint)
+		logger.Info("Found endpoint: " + endpoint.String())
+		endpointCount++
 	}
 	logger.Info("Found " + strconv.Itoa(endpointCount) + " endpoints in repo: " + *params.Repo)
+	logDiagnostics("Schedules", describeSchedules(endpoints, time.Now().In(location), ScheduleRuns), logger)
+	reportDuplicates(endpoints, logger)
 
-	// sort the endpoints
-	sort.Slice(endpoints, func(i, j int) bool {
-		if strings.EqualFold(*params.EndpointSortKey, "name") {
-			return endpoints[i].Name < endpoints[j].Name
-		}
+	// sort the endpoints, ties are broken by id so the output (and the generated file names) are the same between runs
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		var left, right = endpoints[i].Name, endpoints[j].Name
 		if strings.EqualFold(*params.EndpointSortKey, "route") {
-			return endpoints[i].Route < endpoints[j].Route // this may not be correct
+			left, right = endpoints[i].Route, endpoints[j].Route // this may not be correct
+		} else if strings.EqualFold(*params.EndpointSortKey, "triggerType") {
+			left, right = endpoints[i].TriggerType, endpoints[j].TriggerType
 		}
-		if strings.EqualFold(*params.EndpointSortKey, "triggerType") {
-			return endpoints[i].TriggerType < endpoints[j].TriggerType
+		if left != right {
+			return left < right
 		}
-		return endpoints[i].Name < endpoints[j].Name
+		return endpoints[i].Id < endpoints[j].Id
 	})
 
 	// begin writing out documentation
@@ -201,7 +339,7 @@ func process(params data.RunParams, logger *logrus.Logger) {
 			}
 			// writeResults(endpoints, doc.Name(), outDir, logger)
 			// TODO: pass "separateFiles" as param from user?
-			if !Documenters[doc.Name()].SerializeRequests(endpoints, utils.Base(*params.Repo), outDir, false, params.CollectionEnvVars, logger) {
+			if !configureDocumenter(doc, params).SerializeRequests(endpoints, utils.Base(*params.Repo), outDir, false, params.CollectionEnvVars, logger) {
 				logger.Error("Error writing results for documenter: " + doc.Name())
 			} else {
 				logger.Info("Wrote results for documenter '" + doc.Name() + "' to: " + outDir)
@@ -217,7 +355,7 @@ func process(params data.RunParams, logger *logrus.Logger) {
 	}
 
 	// writeResults(endpoints, *docType, *outputDir, logger)
-	if !Documenters[*params.DocType].SerializeRequests(endpoints, utils.Base(*params.Repo), *params.OutputDir, true, params.CollectionEnvVars, logger) {
+	if !configureDocumenter(Documenters[*params.DocType], params).SerializeRequests(endpoints, utils.Base(*params.Repo), *params.OutputDir, true, params.CollectionEnvVars, logger) {
 		logger.Error("Error writing results for documenter: " + Documenters[*params.DocType].Name())
 	} else {
 		logger.Info("Wrote results for documenter: " + Documenters[*params.DocType].Name() + " to: " + *params.OutputDir)
@@ -231,6 +369,10 @@ func run(logger *logrus.Logger) {
 	RunParams.DocType = runCmd.String("docType", getDefaultArg("docType"), "Documenter type to use ("+supportedDocumenters()+")")
 	RunParams.OutputDir = runCmd.String("outputDir", getDefaultArg("outputDir"), "Dir to output documented api files")
 	RunParams.EndpointSortKey = runCmd.String("sort", getDefaultArg("sortKey"), "the field to sort the endpoints by (name, route, triggerType)")
+	RunParams.Parsers = runCmd.String("parsers", getDefaultArg("parsers"), "comma separated list of the parsers to run ("+supportedParsers()+")")
+	RunParams.TimeZone = runCmd.String("timeZone", getDefaultArg("timeZone"), "the IANA time zone (e.g. Europe/London) to compute the next runs of the timer triggers in")
+	RunParams.MaxRunsPerHour = runCmd.Int("maxRunsPerHour", getDefaultMaxRunsPerHour(), "timer triggers that run more often than this are flagged by the schedule documenter")
+	RunParams.SettingsEnv = runCmd.String("settingsEnv", getDefaultArg("settingsEnv"), "the environment of the appsettings.{env}.json overlay used to resolve %AppSetting% placeholders, e.g. Development")
 	runCmd.Parse(os.Args[2:])
 
 	RunParams.CollectionEnvVars = getCollectionEnvVars(runCmd)
@@ -264,6 +406,26 @@ func serve(logger *logrus.Logger) {
 			input.EndpointSortKey = &endpointSortKey
 		}
 
+		if input.Parsers == nil {
+			parserNames := getDefaultArg("parsers")
+			input.Parsers = &parserNames
+		}
+
+		if input.TimeZone == nil {
+			timeZone := getDefaultArg("timeZone")
+			input.TimeZone = &timeZone
+		}
+
+		if input.MaxRunsPerHour == nil {
+			maxRunsPerHour := getDefaultMaxRunsPerHour()
+			input.MaxRunsPerHour = &maxRunsPerHour
+		}
+
+		if input.SettingsEnv == nil {
+			settingsEnv := getDefaultArg("settingsEnv")
+			input.SettingsEnv = &settingsEnv
+		}
+
 		// TODO: implement function for settings collection env vars, in a unified way between cli and server ... for now set host as the default if unset
 		if input.CollectionEnvVars == nil || len(input.CollectionEnvVars) == 0 {
 			input.CollectionEnvVars = make(map[string]string)
@@ -304,6 +466,7 @@ func main() {
 
 	logger.Info("Starting documentApi version: " + Version)
 	initDocumenters()
+	initParsers()
 
 	if len(os.Args) < 2 {
 		logger.Error("Missing subcommand")
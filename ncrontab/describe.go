@@ -0,0 +1,227 @@
+package ncrontab
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Describe returns when the schedule fires in English, e.g. "every 5 minutes between 09:00 and 17:55, Mon–Fri"
+func (s Schedule) Describe() string {
+	if s.interval > 0 {
+		return "every " + describeDuration(s.interval)
+	}
+	if len(s.fields) != len(fieldSpecs) {
+		return ""
+	}
+
+	var second, minute, hour = s.fields[secondField], s.fields[minuteField], s.fields[hourField]
+	var description string
+	if second.isSingle() && minute.isSingle() && hour.isList() {
+		// fixed times of the day, e.g. at 09:30 and 17:30
+		var times = []string{}
+		for _, value := range hour.matching() {
+			times = append(times, clock(value, minute.first(), second.first()))
+		}
+		description = "at " + joinList(times)
+	} else {
+		description = describeTime(second, minute, hour)
+	}
+
+	var day = s.fields[dayField]
+	if step := day.step(); step > 1 {
+		description += ", every " + strconv.Itoa(step) + " days"
+	} else if !day.isStar() {
+		description += ", on " + day.describeValues("day", "days") + " of the month"
+	}
+	if dayOfWeek := s.fields[dayOfWeekField]; !dayOfWeek.isStar() {
+		description += ", " + dayOfWeek.describeValues("", "")
+	}
+	if month := s.fields[monthField]; !month.isStar() {
+		description += ", in " + month.describeValues("", "")
+	}
+	return description
+}
+
+// describeTime describes how often the schedule fires during the day
+func describeTime(second field, minute field, hour field) string {
+	var clauses = []string{}
+	var onTheMinute = second.isSingle() && second.first() == 0
+	switch {
+	case second.isStar():
+		clauses = append(clauses, "every second")
+	case second.step() > 1:
+		clauses = append(clauses, "every "+strconv.Itoa(second.step())+" seconds")
+	case !onTheMinute:
+		clauses = append(clauses, "at "+second.describeValues("second", "seconds")+" past the minute")
+	}
+
+	// fires once an hour, the hours are described together with the minute
+	var hourly = onTheMinute && minute.isSingle()
+	switch {
+	case hourly:
+		if minute.first() == 0 {
+			clauses = append(clauses, "every hour")
+		} else {
+			clauses = append(clauses, "at "+strconv.Itoa(minute.first())+" minutes past the hour")
+		}
+	case minute.isStar():
+		if len(clauses) == 0 {
+			clauses = append(clauses, "every minute")
+		}
+	case minute.step() > 1:
+		clauses = append(clauses, "every "+strconv.Itoa(minute.step())+" minutes")
+	default:
+		clauses = append(clauses, "at "+minute.describeValues("minute", "minutes")+" past the hour")
+	}
+
+	switch {
+	case hour.isStar():
+	case hour.step() > 1:
+		if hourly && minute.first() == 0 {
+			clauses[len(clauses)-1] = "every " + strconv.Itoa(hour.step()) + " hours"
+		} else {
+			clauses = append(clauses, "every "+strconv.Itoa(hour.step())+" hours")
+		}
+	case len(hour.parts) == 1 && hour.parts[0].step == 1:
+		// a range of hours, from the first to the last time it fires
+		clauses[len(clauses)-1] += " between " + clock(hour.parts[0].start, minute.first(), second.first()) + " and " + clock(hour.parts[0].end, minute.last(), second.last())
+	default:
+		clauses = append(clauses, "during "+hour.describeValues("hour", "hours"))
+	}
+	return strings.Join(clauses, ", ")
+}
+
+// describeDuration formats a TimeSpan interval, e.g. "hour" or "1 hour and 30 minutes"
+func describeDuration(interval time.Duration) string {
+	var units = []struct {
+		name   string
+		length time.Duration
+	}{
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+		{"second", time.Second},
+	}
+
+	var amounts = []string{}
+	var single string
+	for _, unit := range units {
+		var amount = int(interval / unit.length)
+		interval -= time.Duration(amount) * unit.length
+		if amount == 1 {
+			amounts = append(amounts, "1 "+unit.name)
+			single = unit.name
+		} else if amount > 1 {
+			amounts = append(amounts, strconv.Itoa(amount)+" "+unit.name+"s")
+		}
+	}
+	if len(amounts) == 1 && len(single) > 0 {
+		return single // every hour rather than every 1 hour
+	}
+	return joinList(amounts)
+}
+
+func clock(hour int, minute int, second int) string {
+	if second != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", hour, minute, second)
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute)
+}
+
+// joinList joins the items as an English list, e.g. "a, b and c"
+func joinList(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+func (f field) isStar() bool {
+	return len(f.parts) == 1 && f.parts[0].star && f.parts[0].step == 1
+}
+
+func (f field) isSingle() bool {
+	return len(f.matching()) == 1
+}
+
+// isList reports whether the field is only made up of single values, e.g. 9,17
+func (f field) isList() bool {
+	for _, p := range f.parts {
+		if p.start != p.end {
+			return false
+		}
+	}
+	return true
+}
+
+// step is the interval of a field that fires every n values of its whole range (*/n or 0/n), 0 otherwise
+func (f field) step() int {
+	if len(f.parts) != 1 || f.parts[0].step < 2 || f.parts[0].start != f.spec.min || f.parts[0].end != f.spec.max {
+		return 0
+	}
+	return f.parts[0].step
+}
+
+// matching returns the values the field matches, in order
+func (f field) matching() []int {
+	var values = []int{}
+	for value, matches := range f.values {
+		if matches {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (f field) first() int {
+	var values = f.matching()
+	if len(values) == 0 {
+		return 0
+	}
+	return values[0]
+}
+
+func (f field) last() int {
+	var values = f.matching()
+	if len(values) == 0 {
+		return 0
+	}
+	return values[len(values)-1]
+}
+
+// describeValues lists the values of the field, ranges are kept (9–17, Mon–Fri) and stepped parts are listed value by value.
+// The unit is put in front of the list, e.g. "minutes 0 and 30", named values (days of the week and months) don't need one
+func (f field) describeValues(singular string, plural string) string {
+	var items = []string{}
+	var count = 0
+	for _, p := range f.parts {
+		if p.step > 1 || p.start == p.end {
+			for value := p.start; value <= p.end; value += p.step {
+				items = append(items, f.spec.format(value))
+				count++
+			}
+			continue
+		}
+		items = append(items, f.spec.format(p.start)+"–"+f.spec.format(p.end))
+		count += 2
+	}
+
+	var unit = plural
+	if count == 1 {
+		unit = singular
+	}
+	if len(unit) == 0 {
+		return joinList(items)
+	}
+	return unit + " " + joinList(items)
+}
+
+// format returns the abbreviated name of the value, or the number when the field doesn't have names
+func (spec fieldSpec) format(value int) string {
+	if len(spec.names) > 0 {
+		return spec.names[value-spec.min][:3]
+	}
+	return strconv.Itoa(value)
+}
@@ -0,0 +1,51 @@
+package ncrontab
+
+import (
+	"documentApi/utils"
+	"testing"
+)
+
+func Test_Describe_ReturnsEnglishDescription(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"* * * * * *", "every second"},
+		{"*/30 * * * * *", "every 30 seconds"},
+		{"0 * * * * *", "every minute"},
+		{"0 */5 * * * *", "every 5 minutes"},
+		{"0 0 * * * *", "every hour"},
+		{"0 15 * * * *", "at 15 minutes past the hour"},
+		{"0 0,30 * * * *", "at minutes 0 and 30 past the hour"},
+		{"0 0 */2 * * *", "every 2 hours"},
+		{"0 30 9 * * *", "at 09:30"},
+		{"30 0 9,17 * * *", "at 09:00:30 and 17:00:30"},
+		{"0 */5 9-17 * * 1-5", "every 5 minutes between 09:00 and 17:55, Mon–Fri"},
+		{"0 0 9-17 * * *", "every hour between 09:00 and 17:00"},
+		{"0 0 1-5,10 * * *", "every hour, during hours 1–5 and 10"},
+		{"0 0 0 * * 0", "at 00:00, Sun"},
+		{"0 0 2 1 * *", "at 02:00, on day 1 of the month"},
+		{"0 0 2 1,15 * *", "at 02:00, on days 1 and 15 of the month"},
+		{"0 0 12 */2 * *", "at 12:00, every 2 days"},
+		{"0 0 9 * JAN,JUL sat,sun", "at 09:00, Sat and Sun, in Jan and Jul"},
+		{"00:05:00", "every 5 minutes"},
+		{"1.00:00:00", "every day"},
+		{"01:30:00", "every 1 hour and 30 minutes"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			// Arrange
+			var schedule, err = Parse(test.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err.Error())
+			}
+
+			// Act
+			var description = schedule.Describe()
+
+			// Assert
+			utils.AssertStringEqual(t, test.expected, description)
+		})
+	}
+}
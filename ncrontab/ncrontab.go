@@ -0,0 +1,245 @@
+package ncrontab
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a timer trigger schedule, either an NCRONTAB expression with six fields
+// ({second} {minute} {hour} {day} {month} {day-of-week}) or a TimeSpan (hh:mm:ss) the function runs every
+type Schedule struct {
+	Expression string
+	fields     []field       // in the order of the expression, empty for TimeSpan schedules
+	interval   time.Duration // for TimeSpan schedules
+}
+
+// fieldSpec is the range of values (and their names, if they have any) a field of the expression accepts
+type fieldSpec struct {
+	name  string
+	min   int
+	max   int
+	names []string // indexed from min
+}
+
+const (
+	secondField = iota
+	minuteField
+	hourField
+	dayField
+	monthField
+	dayOfWeekField
+)
+
+var monthNames = []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+var dayNames = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+// the most days each month has, February has 29 in leap years
+var daysInMonth = []int{31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+var fieldSpecs = []fieldSpec{
+	{"second", 0, 59, nil},
+	{"minute", 0, 59, nil},
+	{"hour", 0, 23, nil},
+	{"day", 1, 31, nil},
+	{"month", 1, 12, monthNames},
+	{"day-of-week", 0, 6, dayNames},
+}
+
+// the .NET TimeSpan format, [d.]hh:mm[:ss]
+var timeSpanRegex = regexp.MustCompile(`^(?:(\d+)\.)?(\d{1,2}):(\d{2})(?::(\d{2}))?$`)
+
+// how far ahead to look for the next time, the rarest schedules (e.g. the 29th of February on a Monday) run every 28 years
+const searchYears = 30
+
+// part is one of the comma separated parts of a field, e.g. 9-17, */5 or 30
+type part struct {
+	start int
+	end   int
+	step  int
+	star  bool
+}
+
+type field struct {
+	spec   fieldSpec
+	parts  []part
+	values []bool // indexed by value, whether the field matches it
+}
+
+// Parse validates the schedule and returns it, the error describes what is wrong with an invalid expression
+func Parse(expression string) (Schedule, error) {
+	var trimmed = strings.TrimSpace(expression)
+	if matches := timeSpanRegex.FindStringSubmatch(trimmed); matches != nil {
+		var interval, err = parseTimeSpan(matches)
+		if err != nil {
+			return Schedule{}, err
+		}
+		return Schedule{Expression: expression, interval: interval}, nil
+	}
+
+	var texts = strings.Fields(trimmed)
+	if len(texts) != len(fieldSpecs) {
+		return Schedule{}, fmt.Errorf("expected %d fields ({second} {minute} {hour} {day} {month} {day-of-week}), found %d", len(fieldSpecs), len(texts))
+	}
+
+	var schedule = Schedule{Expression: expression}
+	for i, text := range texts {
+		var parsed, err = parseField(text, fieldSpecs[i])
+		if err != nil {
+			return Schedule{}, err
+		}
+		schedule.fields = append(schedule.fields, parsed)
+	}
+	if !schedule.hasDate() {
+		return Schedule{}, fmt.Errorf("none of the months in '%s' have the days in '%s'", texts[monthField], texts[dayField])
+	}
+	return schedule, nil
+}
+
+// hasDate checks that at least one of the days of the month exists in one of the months, e.g. 0 0 0 31 2 * never runs
+func (s Schedule) hasDate() bool {
+	for month := 1; month <= 12; month++ {
+		if !s.fields[monthField].has(month) {
+			continue
+		}
+		for day := 1; day <= daysInMonth[month-1]; day++ {
+			if s.fields[dayField].has(day) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseTimeSpan(matches []string) (time.Duration, error) {
+	var days, _ = strconv.Atoi("0" + matches[1])
+	var hours, _ = strconv.Atoi(matches[2])
+	var minutes, _ = strconv.Atoi(matches[3])
+	var seconds, _ = strconv.Atoi("0" + matches[4])
+	if hours > 23 || minutes > 59 || seconds > 59 {
+		return 0, fmt.Errorf("invalid TimeSpan '%s'", matches[0])
+	}
+
+	var interval = time.Duration(days)*24*time.Hour + time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	if interval <= 0 {
+		return 0, fmt.Errorf("the TimeSpan '%s' must be longer than 0", matches[0])
+	}
+	return interval, nil
+}
+
+func parseField(text string, spec fieldSpec) (field, error) {
+	var parsed = field{spec: spec, values: make([]bool, spec.max+1)}
+	for _, item := range strings.Split(text, ",") {
+		var p = part{step: 1}
+		var rangeText, stepText, hasStep = strings.Cut(item, "/")
+		if hasStep {
+			var step, err = strconv.Atoi(stepText)
+			if err != nil || step < 1 {
+				return field{}, fmt.Errorf("invalid step '%s' in the %s field '%s'", stepText, spec.name, text)
+			}
+			p.step = step
+		}
+
+		var err error
+		if rangeText == "*" {
+			p.star = true
+			p.start, p.end = spec.min, spec.max
+		} else if from, to, isRange := strings.Cut(rangeText, "-"); isRange {
+			if p.start, err = spec.value(from); err != nil {
+				return field{}, fmt.Errorf("%s in the %s field '%s'", err.Error(), spec.name, text)
+			}
+			if p.end, err = spec.value(to); err != nil {
+				return field{}, fmt.Errorf("%s in the %s field '%s'", err.Error(), spec.name, text)
+			}
+			if p.start > p.end {
+				return field{}, fmt.Errorf("the range '%s' in the %s field ends before it starts", rangeText, spec.name)
+			}
+		} else {
+			if p.start, err = spec.value(rangeText); err != nil {
+				return field{}, fmt.Errorf("%s in the %s field '%s'", err.Error(), spec.name, text)
+			}
+			p.end = p.start
+			if hasStep { // n/step means every step from n
+				p.end = spec.max
+			}
+		}
+
+		for value := p.start; value <= p.end; value += p.step {
+			parsed.values[value] = true
+		}
+		parsed.parts = append(parsed.parts, p)
+	}
+	return parsed, nil
+}
+
+// value parses a number or a name (full or abbreviated to at least three letters) of the field
+func (spec fieldSpec) value(text string) (int, error) {
+	if number, err := strconv.Atoi(text); err == nil {
+		if number < spec.min || number > spec.max {
+			return 0, fmt.Errorf("the value %d is out of the range %d-%d", number, spec.min, spec.max)
+		}
+		return number, nil
+	}
+	if len(text) >= 3 {
+		for i, name := range spec.names {
+			if strings.HasPrefix(strings.ToLower(name), strings.ToLower(text)) {
+				return spec.min + i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid value '%s'", text)
+}
+
+func (f field) has(value int) bool {
+	return value < len(f.values) && f.values[value]
+}
+
+// Next returns the next count times the schedule fires after the given time, in the location of that time.
+// TimeSpan schedules run at an interval from when the function app starts, their times are counted from after
+func (s Schedule) Next(after time.Time, count int) []time.Time {
+	var times = []time.Time{}
+	for len(times) < count {
+		var next, found = s.next(after)
+		if !found {
+			break
+		}
+		times = append(times, next)
+		after = next
+	}
+	return times
+}
+
+func (s Schedule) next(after time.Time) (time.Time, bool) {
+	if s.interval > 0 {
+		return after.Add(s.interval), true
+	}
+	if len(s.fields) != len(fieldSpecs) {
+		return time.Time{}, false
+	}
+
+	var location = after.Location()
+	var t = after.Truncate(time.Second).Add(time.Second)
+	var limit = t.AddDate(searchYears, 0, 0)
+	for t.Before(limit) {
+		var year, month, day = t.Date()
+		var hour, minute, second = t.Clock()
+		switch {
+		case !s.fields[monthField].has(int(month)):
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, location)
+		// the day of the month and the day of the week both have to match
+		case !s.fields[dayField].has(day) || !s.fields[dayOfWeekField].has(int(t.Weekday())):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, location)
+		case !s.fields[hourField].has(hour):
+			t = time.Date(year, month, day, hour+1, 0, 0, 0, location)
+		case !s.fields[minuteField].has(minute):
+			t = time.Date(year, month, day, hour, minute+1, 0, 0, location)
+		case !s.fields[secondField].has(second):
+			t = time.Date(year, month, day, hour, minute, second+1, 0, location)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
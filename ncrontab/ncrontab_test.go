@@ -0,0 +1,121 @@
+package ncrontab
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Parse_ReportsInvalidExpressions(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   string // part of the error, empty when the expression is valid
+	}{
+		{"Every 5 minutes", "0 */5 * * * *", ""},
+		{"Names", "0 30 9 * jan-mar MON-FRI", ""},
+		{"Lists and steps", "0 0,30 9-17/2 1,15 * *", ""},
+		{"TimeSpan", "00:05:00", ""},
+		{"TimeSpan with days", "1.00:00:00", ""},
+		{"Five fields", "*/5 * * * *", "expected 6 fields"},
+		{"Seven fields", "0 0 0 * * * 2026", "expected 6 fields"},
+		{"Out of range", "0 60 * * * *", "out of the range 0-59 in the minute field"},
+		{"Day zero", "0 0 0 0 * *", "out of the range 1-31 in the day field"},
+		{"Reversed range", "0 0 17-9 * * *", "ends before it starts"},
+		{"Invalid step", "0 */0 * * * *", "invalid step '0'"},
+		{"Invalid name", "0 0 0 * * Funday", "invalid value 'Funday'"},
+		{"Empty list item", "0 0,,30 * * * *", "invalid value ''"},
+		{"Empty TimeSpan", "00:00:00", "must be longer than 0"},
+		{"Invalid TimeSpan", "00:75:00", "invalid TimeSpan"},
+		{"Leap day", "0 0 0 29 Feb *", ""},
+		{"Thirty first of February", "0 0 0 31 2 *", "none of the months in '2' have the days in '31'"},
+		{"Thirtieth of February", "0 0 0 30 2 *", "none of the months in '2' have the days in '30'"},
+		{"Thirty first of short months", "0 0 0 31 4,6,9,11 *", "none of the months"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			var _, err = Parse(test.expression)
+
+			// Assert
+			if len(test.expected) == 0 && err != nil {
+				t.Fatalf("Expected '%s' to be valid, got: %s", test.expression, err.Error())
+			}
+			if len(test.expected) > 0 && (err == nil || !strings.Contains(err.Error(), test.expected)) {
+				t.Fatalf("Expected an error containing '%s' for '%s', got: %v", test.expected, test.expression, err)
+			}
+		})
+	}
+}
+
+func Test_Next_ReturnsNextRuns(t *testing.T) {
+	var london, _ = time.LoadLocation("Europe/London")
+	var from = time.Date(2026, 10, 23, 16, 58, 30, 0, london) // a friday
+	tests := []struct {
+		name       string
+		expression string
+		from       time.Time
+		expected   []string
+	}{
+		{
+			name:       "Every 5 minutes",
+			expression: "0 */5 * * * *",
+			from:       from,
+			expected:   []string{"2026-10-23T17:00:00+01:00", "2026-10-23T17:05:00+01:00", "2026-10-23T17:10:00+01:00"},
+		},
+		{
+			name:       "Working hours skip the weekend",
+			expression: "0 */30 9-17 * * 1-5",
+			from:       from,
+			expected:   []string{"2026-10-23T17:00:00+01:00", "2026-10-23T17:30:00+01:00", "2026-10-26T09:00:00Z"},
+		},
+		{
+			name:       "Daylight saving ends",
+			expression: "0 0 2 * * *",
+			from:       from,
+			expected:   []string{"2026-10-24T02:00:00+01:00", "2026-10-25T02:00:00Z", "2026-10-26T02:00:00Z"},
+		},
+		{
+			name:       "Day of month and day of week both have to match",
+			expression: "0 0 0 13 * FRI",
+			from:       from,
+			expected:   []string{"2026-11-13T00:00:00Z", "2027-08-13T00:00:00+01:00"},
+		},
+		{
+			name:       "Leap day",
+			expression: "0 0 0 29 FEB *",
+			from:       from,
+			expected:   []string{"2028-02-29T00:00:00Z", "2032-02-29T00:00:00Z"},
+		},
+		{
+			name:       "TimeSpan",
+			expression: "01:30:00",
+			from:       from,
+			expected:   []string{"2026-10-23T18:28:30+01:00", "2026-10-23T19:58:30+01:00"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			var schedule, err = Parse(test.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err.Error())
+			}
+
+			// Act
+			var runs = schedule.Next(test.from, len(test.expected))
+
+			// Assert
+			if len(runs) != len(test.expected) {
+				t.Fatalf("Expected %d runs, got %v", len(test.expected), runs)
+			}
+			for i, run := range runs {
+				if run.Format(time.RFC3339) != test.expected[i] {
+					t.Errorf("Expected run %d to be %s, got %s", i, test.expected[i], run.Format(time.RFC3339))
+				}
+			}
+		})
+	}
+}
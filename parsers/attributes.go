@@ -0,0 +1,92 @@
+package parsers
+
+import (
+	"documentApi/csharp"
+	"documentApi/data"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var nameofRegex = regexp.MustCompile(`\{\s*nameof\(\s*(?:[\w.]+\.)?(?<name>\w+)\s*\)\s*\}`)
+
+// stringValue returns the content of a string literal argument, nameof() expressions
+// (including those in interpolated strings) are replaced with the name they reference.
+// Any other expression is returned as it is written
+func stringValue(argument csharp.Argument) string {
+	if len(argument.Tokens) == 1 {
+		if value, isString := csharp.StringValue(argument.Tokens[0]); isString {
+			if strings.HasPrefix(argument.Text, "$") || strings.HasPrefix(argument.Text, "@$") {
+				value = nameofRegex.ReplaceAllString(value, "${name}")
+			}
+			return value
+		}
+	}
+
+	var tokens = argument.Tokens
+	if len(tokens) > 3 && tokens[0].Is("nameof") && tokens[1].Is("(") && tokens[len(tokens)-1].Is(")") {
+		return tokens[len(tokens)-2].Text
+	}
+
+	return argument.Text
+}
+
+// expressionValue evaluates the argument, resolving any constants it references.
+// Falls back to the value as written if the expression can't be evaluated
+func expressionValue(argument csharp.Argument, typeName string, constants *csharp.Constants) string {
+	if value, ok := constants.Evaluate(argument.Tokens, typeName); ok {
+		return value
+	}
+	return stringValue(argument)
+}
+
+// typeofValue returns the type name referenced in a typeof() expression
+func typeofValue(argument csharp.Argument) string {
+	var tokens = argument.Tokens
+	if len(tokens) > 3 && tokens[0].Is("typeof") && tokens[1].Is("(") && tokens[len(tokens)-1].Is(")") {
+		var inner = strings.TrimSpace(argument.Text[len(tokens[0].Text):])
+		return strings.TrimSpace(inner[1 : len(inner)-1])
+	}
+	return ""
+}
+
+// enumValue returns the member name of an enum value, e.g. "ParameterLocation.Query" -> "Query"
+func enumValue(argument csharp.Argument) string {
+	if len(argument.Tokens) > 0 {
+		return argument.Tokens[len(argument.Tokens)-1].Text
+	}
+	return ""
+}
+
+// boolValue reports whether the argument is the literal true
+func boolValue(argument csharp.Argument) bool {
+	return argument.Text == "true"
+}
+
+// statusCodeValue returns the integer http status code for a HttpStatusCode enum value or integer literal,
+// e.g. HttpStatusCode.OK, 200 or (HttpStatusCode)200
+func statusCodeValue(argument csharp.Argument) (int, bool) {
+	if len(argument.Tokens) < 1 {
+		return 0, false
+	}
+
+	var last = argument.Tokens[len(argument.Tokens)-1]
+	if last.Kind == csharp.Number {
+		code, err := strconv.Atoi(last.Text)
+		return code, err == nil
+	}
+
+	code, exists := data.HttpStatusCode[last.Text]
+	return code, exists
+}
+
+// stringListValue returns the strings in a c# collection, e.g. ["a", "b"] or new[] { "a", "b" }
+func stringListValue(argument csharp.Argument) []string {
+	var list = []string{}
+	for _, token := range argument.Tokens {
+		if value, isString := csharp.StringValue(token); isString {
+			list = append(list, value)
+		}
+	}
+	return list
+}
@@ -0,0 +1,63 @@
+package parsers
+
+import (
+	"documentApi/data"
+	"strings"
+)
+
+// the positional arguments of the trigger attributes, the rest are set as named arguments (e.g. Connection = "Storage")
+var triggerAttributeParameters = map[string][]string{
+	"QueueTrigger":      {"queueName"},
+	"ServiceBusTrigger": {"queueName"}, // or topicName and subscriptionName, see triggerParameterNames
+	"BlobTrigger":       {"blobPath"},
+	"EventHubTrigger":   {"eventHubName"},
+	"CosmosDBTrigger":   {"databaseName", "containerName"},
+}
+
+// triggerParameterNames returns the names of the positional arguments of the trigger attribute
+func triggerParameterNames(attributeName string, positionalCount int) []string {
+	if attributeName == "ServiceBusTrigger" && positionalCount > 1 {
+		return []string{"topicName", "subscriptionName"}
+	}
+	return triggerAttributeParameters[attributeName]
+}
+
+// setBindingProperty sets the binding property by its name in the c# attributes, function.json, node options or python
+// decorators (the name is case insensitive and python's underscores are ignored), returns false if it's not a documented property
+func setBindingProperty(binding *data.TriggerBinding, name string, value string) bool {
+	switch strings.ToLower(strings.ReplaceAll(name, "_", "")) {
+	case "queuename":
+		binding.QueueName = value
+	case "topicname":
+		binding.TopicName = value
+	case "subscriptionname":
+		binding.SubscriptionName = value
+	case "eventhubname":
+		binding.EventHubName = value
+	case "consumergroup":
+		binding.ConsumerGroup = value
+	case "path", "blobpath":
+		binding.Path = value
+	case "databasename":
+		binding.DatabaseName = value
+	case "containername", "collectionname":
+		binding.ContainerName = value
+	case "leasecontainername", "leasecollectionname":
+		binding.LeaseContainerName = value
+	case "connection", "connectionstringsetting":
+		binding.Connection = value
+	case "orchestration", "activity", "entityname":
+		binding.Name = value
+	default:
+		return false
+	}
+	return true
+}
+
+// bindingOrNil returns nil for a binding without any properties, so it's left out of the output
+func bindingOrNil(binding *data.TriggerBinding) *data.TriggerBinding {
+	if binding == nil || *binding == (data.TriggerBinding{}) {
+		return nil
+	}
+	return binding
+}
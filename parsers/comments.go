@@ -0,0 +1,101 @@
+package parsers
+
+import (
+	"documentApi/csharp"
+	"documentApi/data"
+	"regexp"
+	"strings"
+)
+
+// plain comments above a handler that are commented out code or notes to the developers rather than documentation
+var codeCommentRegex = regexp.MustCompile(`(?m)[;{]\s*$|^\s*}|^\s*[\[#]`)
+var noteCommentRegex = regexp.MustCompile(`^(TODO|FIXME|HACK|NOTE)\b`)
+
+// searchComments documents the endpoint with the comments above the handler, the xml doc comment
+// (/// <summary>, <remarks>, <param> and <returns>) and plain comments, e.g. // GET /api/v2/modules/{moduleId}.
+// Descriptions that are declared with attributes (e.g. [OpenApiOperation(Description = "...")]) are kept as they are
+func searchComments(method csharp.Method, comments []csharp.CommentBlock, endpoint *data.EndpointMetaData) {
+	if method.Line < 1 {
+		return // lambda handlers of minimal apis aren't declared as methods
+	}
+	var firstLine = method.Line
+	for _, attribute := range method.Attributes {
+		if attribute.Line > 0 {
+			firstLine = min(firstLine, attribute.Line)
+		}
+	}
+
+	var paragraphs, docParagraphs = []string{}, []string{}
+	for _, comment := range csharp.CommentBlocksBefore(comments, firstLine) {
+		if !comment.IsDoc {
+			if !codeCommentRegex.MatchString(comment.Text) && !noteCommentRegex.MatchString(comment.Text) {
+				paragraphs = append(paragraphs, comment.Text)
+			}
+			continue
+		}
+
+		var doc = csharp.ParseDocComment(comment.Text)
+		for _, text := range []string{doc.Summary, doc.Remarks} {
+			if len(text) > 0 {
+				docParagraphs = append(docParagraphs, text)
+			}
+		}
+		for name, description := range doc.Parameters {
+			describeParameter(method, name, description, endpoint)
+		}
+		if len(doc.Returns) > 0 {
+			describeSuccessResponse(doc.Returns, endpoint)
+		}
+	}
+
+	// the summary is the fallback description of [OpenApiOperation], a declared description is kept as it is
+	if len(endpoint.Description) > 0 && endpoint.Description != endpoint.Summary {
+		return
+	}
+	if len(docParagraphs) > 0 {
+		paragraphs = append(paragraphs, docParagraphs...)
+	} else if len(endpoint.Description) > 0 {
+		paragraphs = append(paragraphs, endpoint.Description)
+	}
+	endpoint.Description = strings.Join(paragraphs, "\n\n")
+}
+
+// describeParameter sets the description of the query or header parameter (or the request body) bound to the
+// handler parameter, unless it is already described
+func describeParameter(method csharp.Method, name string, description string, endpoint *data.EndpointMetaData) {
+	var bindingName = name
+	for _, parameter := range method.Parameters {
+		if parameter.Name != name {
+			continue
+		}
+		if _, isBody := parameter.Attribute("FromBody"); isBody || endpoint.RequestBody != nil && strings.TrimSuffix(parameter.Type, "?") == endpoint.RequestBody.TypeName {
+			if endpoint.RequestBody != nil && len(endpoint.RequestBody.Description) < 1 {
+				endpoint.RequestBody.Description = description
+			}
+			return
+		}
+		if binding, exists := parameter.Attribute("FromQuery", "FromHeader"); exists {
+			if argument, named := binding.Argument(-1, "Name"); named {
+				bindingName = stringValue(argument)
+			}
+		}
+	}
+
+	for i := range endpoint.Parameters {
+		if strings.EqualFold(endpoint.Parameters[i].Name, bindingName) && len(endpoint.Parameters[i].Description) < 1 {
+			endpoint.Parameters[i].Description = description
+		}
+	}
+}
+
+// describeSuccessResponse sets the description of the first success response that isn't described yet
+func describeSuccessResponse(description string, endpoint *data.EndpointMetaData) {
+	for i := range endpoint.ResponseCodes {
+		if endpoint.ResponseCodes[i].StatusCode >= 200 && endpoint.ResponseCodes[i].StatusCode < 300 {
+			if len(endpoint.ResponseCodes[i].Description) < 1 {
+				endpoint.ResponseCodes[i].Description = description
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package parsers
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"testing"
+)
+
+func Test_Parse_DescribesEndpointsWithDocComments(t *testing.T) {
+	// Arrange
+	var files = []data.FileMetaData{{Name: "doc_comments.cs", Path: "../test_assets/doc_comments.cs"}}
+
+	// Act
+	var endpoints, _ = CSharpParser{}.Parse(files, testLogger)
+
+	// Assert
+	utils.AssertEqual(t, 3, len(endpoints))
+	t.Run("GetReport", func(t *testing.T) {
+		// the plain comment and the summary and remarks of the doc comment, with the references replaced by their names
+		utils.AssertStringEqual(t, "GET /api/v2/reports/{reportId}\n\nGets the Report of a module.\n\nReports are cached for an hour.\n\nUse rebuild to rebuild it.", endpoints[0].Description)
+		utils.AssertEqual(t, 1, len(endpoints[0].Parameters))
+		utils.AssertStringEqual(t, "Rebuilds the report when true", endpoints[0].Parameters[0].Description) // bound with [FromQuery(Name = "refresh")]
+		utils.AssertStringEqual(t, "The report", endpoints[0].ResponseCodes[0].Description)
+	})
+	t.Run("ListReports", func(t *testing.T) {
+		// the declared description is kept, commented out code and notes aren't descriptions
+		utils.AssertStringEqual(t, "Lists the reports | all of them", endpoints[1].Description)
+	})
+	t.Run("CreateReport", func(t *testing.T) {
+		// the summary replaces the description that defaults to the summary of [OpenApiOperation]
+		utils.AssertStringEqual(t, "Creates a report", endpoints[2].Description)
+		utils.AssertStringEqual(t, "The modules to report on", endpoints[2].RequestBody.Description)
+	})
+}
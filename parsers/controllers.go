@@ -0,0 +1,357 @@
+package parsers
+
+import (
+	"documentApi/csharp"
+	"documentApi/data"
+	"documentApi/utils"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var routeTokenRegex = regexp.MustCompile(`(?i)\[(controller|action|area)\]`)
+
+// the template of the default conventional routes ({controller=Home}/{action=Index}/{id?} and
+// {area:exists}/{controller}/{action}), used for the actions that don't have an attribute route
+const conventionalRouteTemplate = "[area]/[controller]/[action]"
+
+// the attributes that map an action to an http method, e.g. HttpGet -> get
+var httpMethodAttributes = map[string]string{
+	"HttpGet": "get", "HttpPost": "post", "HttpPut": "put", "HttpDelete": "delete", "HttpPatch": "patch", "HttpHead": "head", "HttpOptions": "options",
+}
+
+// types that can be bound from the route, query or headers. Anything else is bound from the body
+var simpleTypes = map[string]bool{
+	"string": true, "String": true, "char": true, "Char": true, "bool": true, "Boolean": true,
+	"int": true, "Int32": true, "long": true, "Int64": true, "short": true, "Int16": true, "byte": true, "Byte": true,
+	"uint": true, "UInt32": true, "ulong": true, "UInt64": true, "ushort": true, "UInt16": true, "sbyte": true, "SByte": true,
+	"float": true, "Single": true, "double": true, "Double": true, "decimal": true, "Decimal": true,
+	"Guid": true, "DateTime": true, "DateTimeOffset": true, "DateOnly": true, "TimeOnly": true, "TimeSpan": true, "Uri": true,
+}
+
+// parameters supplied by the framework, these are not part of the request
+var frameworkParameterTypes = map[string]bool{
+	"CancellationToken": true, "HttpContext": true, "HttpRequest": true, "HttpResponse": true, "ClaimsPrincipal": true,
+}
+
+var formFileTypes = map[string]bool{
+	"IFormFile": true, "IFormFileCollection": true, "IEnumerable<IFormFile>": true, "List<IFormFile>": true, "IFormFile[]": true,
+}
+
+// controllerRoute is a route template of an action and the http methods it accepts (none means any)
+type controllerRoute struct {
+	template string
+	methods  []string
+}
+
+// isController reports whether the type is an asp.net core controller,
+// either marked as one or deriving from a (framework or custom) controller base class
+func isController(declaration csharp.TypeDeclaration) bool {
+	if declaration.Kind != "class" || declaration.HasModifier("abstract") || declaration.HasModifier("static") {
+		return false
+	}
+	if _, nonController := declaration.Attribute("NonController"); nonController {
+		return false
+	}
+	if _, marked := declaration.Attribute("ApiController", "Controller"); marked {
+		return true
+	}
+	for _, baseType := range declaration.BaseTypes {
+		if strings.HasSuffix(shortTypeName(baseType), "Controller") || shortTypeName(baseType) == "ControllerBase" {
+			return true
+		}
+	}
+	return false
+}
+
+// shortTypeName drops the namespace and generic arguments of a type name, e.g. Microsoft.AspNetCore.Mvc.ControllerBase -> ControllerBase
+func shortTypeName(typeName string) string {
+	if i := strings.Index(typeName, "<"); i > -1 {
+		typeName = typeName[:i]
+	}
+	return typeName[strings.LastIndex(typeName, ".")+1:]
+}
+
+// combineRoutes appends the action template to the controller template,
+// action templates starting with / or ~/ are absolute and ignore the controller template
+func combineRoutes(controllerTemplate string, actionTemplate string) string {
+	if strings.HasPrefix(actionTemplate, "~/") || strings.HasPrefix(actionTemplate, "/") {
+		return strings.Trim(strings.TrimPrefix(actionTemplate, "~"), "/")
+	}
+
+	var parts = []string{}
+	for _, part := range []string{controllerTemplate, actionTemplate} {
+		if part = strings.Trim(part, "/"); len(part) > 0 {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// replaceRouteTokens replaces the [controller], [action] and [area] tokens in a route template
+func replaceRouteTokens(template string, controller string, action string, area string) string {
+	return routeTokenRegex.ReplaceAllStringFunc(template, func(token string) string {
+		switch strings.ToLower(token) {
+		case "[controller]":
+			return controller
+		case "[action]":
+			return action
+		}
+		return area
+	})
+}
+
+// templateValue returns the route template argument of a Route/Http* attribute
+func templateValue(attribute csharp.Attribute, typeName string, constants *csharp.Constants) (string, bool) {
+	if template, exists := attribute.Argument(0, "template"); exists {
+		return expressionValue(template, typeName, constants), true
+	}
+	return "", false
+}
+
+// actionRoutes collects the route templates (relative to the controller) and http methods of an action.
+// Returns nothing if the action isn't routable with attribute routing
+func actionRoutes(method csharp.Method, controllerHasRoute bool, constants *csharp.Constants) []controllerRoute {
+	var routes = []controllerRoute{}
+	var routeTemplates = []string{}
+	var methods = []string{} // the http methods without their own template
+	for _, attribute := range method.Attributes {
+		var name = attribute.ShortName()
+		if httpMethod, isHttpMethod := httpMethodAttributes[name]; isHttpMethod {
+			if template, exists := templateValue(attribute, method.TypeName, constants); exists {
+				routes = append(routes, controllerRoute{template: template, methods: []string{httpMethod}})
+			} else {
+				methods = append(methods, httpMethod)
+			}
+			continue
+		}
+
+		switch name {
+		case "AcceptVerbs":
+			var verbs = []string{}
+			for _, argument := range attribute.Arguments {
+				if len(argument.Name) < 1 {
+					verbs = append(verbs, stringListValue(argument)...)
+				}
+			}
+			for i := range verbs {
+				verbs[i] = strings.ToLower(verbs[i])
+			}
+			if route, exists := attribute.Argument(-1, "Route"); exists {
+				routes = append(routes, controllerRoute{template: expressionValue(route, method.TypeName, constants), methods: verbs})
+			} else {
+				methods = append(methods, verbs...)
+			}
+		case "Route":
+			if template, exists := templateValue(attribute, method.TypeName, constants); exists {
+				routeTemplates = append(routeTemplates, template)
+			}
+		}
+	}
+
+	// http methods without a template use the templates from the [Route] attributes of the action (or the controller).
+	// Without either the action is reached through the conventional route, e.g. [HttpGet] on an action of a controller without a [Route]
+	if len(routeTemplates) < 1 && len(methods) > 0 && !controllerHasRoute {
+		routeTemplates = append(routeTemplates, conventionalRouteTemplate)
+	} else if len(routeTemplates) < 1 && (len(methods) > 0 || (len(routes) < 1 && controllerHasRoute)) {
+		routeTemplates = append(routeTemplates, "")
+	}
+	if len(routes) < 1 || len(methods) > 0 {
+		for _, template := range routeTemplates {
+			routes = append(routes, controllerRoute{template: template, methods: methods})
+		}
+	}
+	return routes
+}
+
+// controllerAuthentication collects the auth requirements from the [Authorize] attributes (policies, roles and schemes),
+// returns false if anonymous access is allowed
+func controllerAuthentication(attributes []csharp.Attribute, endpoint *data.EndpointMetaData) bool {
+	var anonymous = false
+	for _, attribute := range attributes {
+		switch attribute.ShortName() {
+		case "Authorize":
+			var requirements = []string{}
+			if policy, exists := attribute.Argument(0, "Policy"); exists {
+				requirements = append(requirements, stringValue(policy))
+			}
+			for _, property := range []string{"Roles", "AuthenticationSchemes"} {
+				if value, exists := attribute.Argument(-1, property); exists {
+					for _, requirement := range strings.Split(stringValue(value), ",") {
+						requirements = append(requirements, strings.TrimSpace(requirement))
+					}
+				}
+			}
+			if len(requirements) < 1 {
+				requirements = append(requirements, "Authorize")
+			}
+			endpoint.Authentication = append(endpoint.Authentication, requirements...)
+		case "AllowAnonymous":
+			anonymous = true
+		default:
+			searchAuthentication(attribute.Text, endpoint)
+		}
+	}
+	return !anonymous
+}
+
+// parseActionParameters collects the query, header and body parameters of an action from their binding
+// attributes. Parameters without one are inferred the way [ApiController] does: simple types that aren't in the route
+// are bound from the query, complex types from the body
+func parseActionParameters(method csharp.Method, endpoint *data.EndpointMetaData) {
+	for _, parameter := range method.Parameters {
+		var typeName = strings.TrimSuffix(parameter.Type, "?")
+		var shortType = shortTypeName(typeName)
+		var name = parameter.Name
+		var _, isRequired = parameter.Attribute("Required", "BindRequired")
+		var required = isRequired || (len(parameter.Default) < 1 && !strings.HasSuffix(parameter.Type, "?"))
+
+		var source = ""
+		var binding, hasBinding = parameter.Attribute("FromBody", "FromForm", "FromQuery", "FromHeader", "FromRoute", "FromServices", "FromKeyedServices")
+		if hasBinding {
+			source = binding.ShortName()
+			if bindingName, exists := binding.Argument(-1, "Name"); exists {
+				name = stringValue(bindingName)
+			}
+		} else {
+			switch {
+			case frameworkParameterTypes[shortType]:
+				continue
+			case formFileTypes[typeName] || formFileTypes[shortType]:
+				source = "FromForm"
+			case simpleTypes[shortType] || strings.HasSuffix(typeName, "[]") && simpleTypes[strings.TrimSuffix(shortType, "[]")]:
+				source = "FromQuery"
+				if _, inRoute := endpoint.PathParameters[name]; inRoute {
+					source = "FromRoute"
+				}
+			default:
+				source = "FromBody"
+			}
+		}
+
+		switch source {
+		case "FromBody":
+			endpoint.RequestBody = &data.RequestBodyMetaData{ContentType: "application/json", TypeName: typeName, Required: required}
+		case "FromForm":
+			if endpoint.RequestBody == nil {
+				endpoint.RequestBody = &data.RequestBodyMetaData{ContentType: "multipart/form-data", TypeName: typeName, Required: required}
+			}
+		case "FromQuery", "FromHeader":
+			endpoint.Parameters = append(endpoint.Parameters, data.ParameterMetaData{
+				Name:     name,
+				In:       strings.ToLower(strings.TrimPrefix(source, "From")),
+				Type:     typeName,
+				Required: required,
+				Source:   "binding",
+			})
+		}
+	}
+}
+
+// parseControllers collects the actions of the asp.net core controllers declared in the file
+func parseControllers(file csharp.File, constants *csharp.Constants, types *csharp.Types) []data.EndpointMetaData {
+	var endpoints = []data.EndpointMetaData{}
+	for _, controller := range file.Types {
+		if !isController(controller) {
+			continue
+		}
+
+		var controllerName = strings.TrimSuffix(controller.Name, "Controller")
+		var area = ""
+		if areaAttribute, exists := controller.Attribute("Area"); exists {
+			if areaName, exists := areaAttribute.Argument(0, "areaName"); exists {
+				area = expressionValue(areaName, controller.Name, constants)
+			}
+		}
+
+		var controllerTemplates = []string{}
+		for _, attribute := range controller.Attributes {
+			if attribute.ShortName() == "Route" {
+				if template, exists := templateValue(attribute, controller.Name, constants); exists {
+					controllerTemplates = append(controllerTemplates, template)
+				}
+			}
+		}
+		var controllerHasRoute = len(controllerTemplates) > 0
+		if !controllerHasRoute {
+			controllerTemplates = append(controllerTemplates, "")
+		}
+
+		for _, method := range file.Methods {
+			if method.TypeName != controller.Name || method.Namespace != controller.Namespace {
+				continue
+			}
+			if method.IsConstructor || method.HasModifier("static") || !method.HasModifier("public") {
+				continue
+			}
+			if _, nonAction := method.Attribute("NonAction"); nonAction {
+				continue
+			}
+
+			var actionName = strings.TrimSuffix(method.Name, "Async")
+			if actionNameAttribute, exists := method.Attribute("ActionName"); exists {
+				if name, exists := actionNameAttribute.Argument(0, "name"); exists {
+					actionName = expressionValue(name, controller.Name, constants)
+				}
+			}
+
+			// group the methods by the route they end up on, so each route is documented once
+			var routes = []controllerRoute{}
+			for _, actionRoute := range actionRoutes(method, controllerHasRoute, constants) {
+				for _, controllerTemplate := range controllerTemplates {
+					var route = combineRoutes(controllerTemplate, actionRoute.template)
+					// the tokens can be empty, e.g. [area] of a controller without an area
+					route = utils.NormalizeRouteTemplate(strings.Trim(replaceRouteTokens(route, controllerName, actionName, area), "/"))
+
+					var existing = -1
+					for i := range routes {
+						if routes[i].template == route {
+							existing = i
+						}
+					}
+					if existing < 0 {
+						routes = append(routes, controllerRoute{template: route})
+						existing = len(routes) - 1
+					}
+					for _, httpMethod := range actionRoute.methods {
+						if !slices.Contains(routes[existing].methods, httpMethod) {
+							routes[existing].methods = append(routes[existing].methods, httpMethod)
+						}
+					}
+				}
+			}
+
+			for i, route := range routes {
+				var endpoint = data.EndpointMetaData{
+					Name:        controllerName + "_" + actionName,
+					ClassName:   qualifiedTypeName(controller.Namespace, controller.Name),
+					Route:       route.template,
+					Methods:     route.methods,
+					TriggerType: data.TriggerType["Http"],
+				}
+				if i > 0 {
+					// actions on several routes, keep the names (and ids) unique
+					endpoint.Name += "_" + strconv.Itoa(i+1)
+				}
+				endpoint.PathParameters = utils.ExtractPathVars(endpoint.Route)
+
+				var requiresAuth = controllerAuthentication(controller.Attributes, &endpoint)
+				requiresAuth = controllerAuthentication(method.Attributes, &endpoint) && requiresAuth
+				if !requiresAuth {
+					endpoint.Authentication = nil
+				}
+				for _, attribute := range method.Attributes {
+					searchOpenApi(attribute.Text, &endpoint)
+				}
+				parseActionParameters(method, &endpoint)
+				searchBodyParameters(method.Body, method.TypeName, constants, &endpoint)
+				searchResponses(method, file, constants, types, &endpoint)
+				searchComments(method, file.Comments, &endpoint)
+
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+	}
+	return endpoints
+}
@@ -0,0 +1,389 @@
+package parsers
+
+import (
+	"documentApi/csharp"
+	"documentApi/data"
+	"documentApi/utils"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TODO: some of these regex (\w)s should probably be [a-zA-Z0-9_] or similar
+// TODO: consider parsing the top level route path for a given controller if exists
+
+const DefaultAuth = "DocsToken"
+
+// the attributes that mark a method as a function, [Function] for the isolated worker model and
+// [FunctionName] for the in-process model
+var functionAttributes = map[string]bool{
+	"Function": true, "FunctionName": true,
+}
+
+// the methods that read the request body as a model, e.g. await req.ReadFromJsonAsync<Item>()
+var readBodyMethods = []string{"ReadBodyAs", "ReadFromJsonAsync", "ReadAsAsync"}
+
+// the auth modes for the function keys required by the authLevel of an http trigger, anonymous functions don't need one
+var functionKeyAuthentication = map[string]string{
+	"function": "FunctionKey",
+	"admin":    "AdminKey",
+}
+
+var authenticationRegex = regexp.MustCompile(`\[Require(?<type>DocsTokenGroups|S2SToken|DocsToken|PlatformApiAuth|IdToken)(?:\((?<groups>[^)]*)\))?\]`)
+
+// triggerType returns the trigger type for a trigger attribute, e.g. HttpTrigger -> http
+func triggerType(attribute csharp.Attribute) (string, bool) {
+	var name = attribute.ShortName()
+	if !strings.HasSuffix(name, "Trigger") {
+		return "", false
+	}
+	if triggerType, exists := data.TriggerType[strings.TrimSuffix(name, "Trigger")]; exists {
+		return triggerType, true
+	}
+	return data.TriggerType["UNKNOWN"], true
+}
+
+// parseFunctionHeader collects the trigger metadata from the attributes on the function parameters
+func parseFunctionHeader(method csharp.Method, constants *csharp.Constants, endpoint *data.EndpointMetaData) {
+	endpoint.TriggerType = data.TriggerType["UNKNOWN"]
+
+	for _, parameter := range method.Parameters {
+		for _, attribute := range parameter.Attributes {
+			var trigger, isTrigger = triggerType(attribute)
+			if !isTrigger {
+				continue
+			}
+			endpoint.TriggerType = trigger
+
+			switch attribute.ShortName() {
+			case "HttpTrigger":
+				// the methods are the string arguments that follow the (optional) auth level
+				for _, argument := range attribute.Arguments {
+					if len(argument.Name) > 0 || len(argument.Tokens) != 1 || argument.Tokens[0].Kind != csharp.String {
+						continue
+					}
+					endpoint.Methods = append(endpoint.Methods, stringValue(argument))
+				}
+				// AuthorizationLevel.Function and Admin require a function key, e.g. [HttpTrigger(AuthorizationLevel.Function, "get")]
+				if authLevel, exists := attribute.Argument(0, "authLevel"); exists {
+					var _, level, _ = strings.Cut(authLevel.Text, "AuthorizationLevel.")
+					if auth, exists := functionKeyAuthentication[strings.ToLower(level)]; exists {
+						endpoint.Authentication = append(endpoint.Authentication, auth)
+					}
+				}
+				// pull out the route and path vars (if any)
+				if route, exists := attribute.Argument(-1, "Route"); exists && route.Text != "null" {
+					endpoint.Route = expressionValue(route, method.TypeName, constants)
+				}
+				if len(endpoint.Route) < 1 {
+					// without a route the function is served on its name, e.g. api/GetItems
+					endpoint.Route = endpoint.Name
+				}
+				endpoint.Route = utils.NormalizeRouteTemplate(endpoint.Route)
+				endpoint.PathParameters = utils.ExtractPathVars(endpoint.Route)
+			case "TimerTrigger":
+				// pull out the cron expression
+				if schedule, exists := attribute.Argument(0, "schedule"); exists {
+					endpoint.Interval = expressionValue(schedule, method.TypeName, constants)
+				}
+			default:
+				endpoint.Binding = parseTriggerBinding(attribute, method.TypeName, constants)
+			}
+		}
+	}
+}
+
+// parseTriggerBinding collects what a (non http) trigger listens to from its attribute, e.g. the queue name and connection of a [QueueTrigger]
+func parseTriggerBinding(attribute csharp.Attribute, typeName string, constants *csharp.Constants) *data.TriggerBinding {
+	var binding = &data.TriggerBinding{}
+	var positional = []csharp.Argument{}
+	for _, argument := range attribute.Arguments {
+		if len(argument.Name) < 1 {
+			positional = append(positional, argument)
+			continue
+		}
+		setBindingProperty(binding, argument.Name, expressionValue(argument, typeName, constants))
+	}
+	for i, name := range triggerParameterNames(attribute.ShortName(), len(positional)) {
+		if i < len(positional) {
+			setBindingProperty(binding, name, expressionValue(positional[i], typeName, constants))
+		}
+	}
+	return bindingOrNil(binding)
+}
+
+func searchAuthentication(line string, endpoint *data.EndpointMetaData) {
+	var authenticationMatch = authenticationRegex.FindStringSubmatch(line)
+	if len(authenticationMatch) > 1 {
+		if len(authenticationMatch) > 2 && len(authenticationMatch[2]) > 0 {
+			// TODO: write function to split by regex
+			var singleSpaced = strings.Join(strings.Fields(authenticationMatch[2]), " ") // attributes can span multiple lines
+			var noCommaSpace = strings.ReplaceAll(singleSpaced, ", ", ",")
+			var noSpace = strings.ReplaceAll(noCommaSpace, " ", ",")
+			var noQuotes = strings.ReplaceAll(noSpace, "\"", "") // this will make it hard to determine if is a docs token group vs "arbitrary string" ... if that's a concern
+			var modes = strings.Split(noQuotes, ",")
+			endpoint.Authentication = append(endpoint.Authentication, modes...)
+		} else {
+			endpoint.Authentication = append(endpoint.Authentication, authenticationMatch[1])
+		}
+	}
+}
+
+// searchOpenApi collects the metadata declared through the OpenApi* attributes of the function
+func searchOpenApi(line string, endpoint *data.EndpointMetaData) {
+	var attribute, isAttribute = csharp.ParseAttribute(line)
+	if !isAttribute {
+		return
+	}
+
+	switch attribute.ShortName() {
+	case "OpenApiOperation":
+		if summary, exists := attribute.Argument(-1, "Summary"); exists {
+			endpoint.Summary = stringValue(summary)
+		}
+		if description, exists := attribute.Argument(-1, "Description"); exists {
+			endpoint.Description = stringValue(description)
+		} else if len(endpoint.Description) < 1 {
+			endpoint.Description = endpoint.Summary
+		}
+		if tags, exists := attribute.Argument(1, "tags"); exists {
+			endpoint.Tags = append(endpoint.Tags, stringListValue(tags)...)
+			// tags is a params array, so any remaining positional args are also tags
+			for position := 2; ; position++ {
+				tag, exists := attribute.Argument(position, "")
+				if !exists {
+					break
+				}
+				endpoint.Tags = append(endpoint.Tags, stringListValue(tag)...)
+			}
+		}
+	case "OpenApiParameter":
+		name, exists := attribute.Argument(0, "name")
+		if !exists {
+			return
+		}
+		var parameter = data.ParameterMetaData{
+			Name:   stringValue(name),
+			In:     "path", // this is the default location for the attribute
+			Source: "attribute",
+		}
+		if in, exists := attribute.Argument(-1, "In"); exists {
+			parameter.In = strings.ToLower(enumValue(in))
+		}
+		// path parameters are already collected from the route
+		if parameter.In == "path" {
+			return
+		}
+		if parameterType, exists := attribute.Argument(-1, "Type"); exists {
+			parameter.Type = typeofValue(parameterType)
+		}
+		if required, exists := attribute.Argument(-1, "Required"); exists {
+			parameter.Required = boolValue(required)
+		}
+		if description, exists := attribute.Argument(-1, "Description"); exists {
+			parameter.Description = stringValue(description)
+		}
+		endpoint.Parameters = append(endpoint.Parameters, parameter)
+	case "OpenApiRequestBody":
+		var requestBody = data.RequestBodyMetaData{}
+		if contentType, exists := attribute.Argument(0, "contentType"); exists {
+			requestBody.ContentType = stringValue(contentType)
+		}
+		if bodyType, exists := attribute.Argument(1, "bodyType"); exists {
+			requestBody.TypeName = typeofValue(bodyType)
+		}
+		if required, exists := attribute.Argument(-1, "Required"); exists {
+			requestBody.Required = boolValue(required)
+		}
+		if description, exists := attribute.Argument(-1, "Description"); exists {
+			requestBody.Description = stringValue(description)
+		}
+		if example, exists := attribute.Argument(-1, "Example"); exists {
+			requestBody.Example = typeofValue(example)
+		}
+		endpoint.RequestBody = &requestBody
+	case "OpenApiResponseWithBody", "OpenApiResponseWithoutBody":
+		statusCode, exists := attribute.Argument(0, "statusCode")
+		if !exists {
+			return
+		}
+		var responseCode = data.ResponseCode{}
+		if responseCode.StatusCode, exists = statusCodeValue(statusCode); !exists {
+			return
+		}
+		if attribute.ShortName() == "OpenApiResponseWithBody" {
+			if contentType, exists := attribute.Argument(1, "contentType"); exists {
+				responseCode.ContentType = stringValue(contentType)
+			}
+			if bodyType, exists := attribute.Argument(2, "bodyType"); exists {
+				responseCode.TypeName = typeofValue(bodyType)
+			}
+		}
+		if description, exists := attribute.Argument(-1, "Description"); exists {
+			responseCode.Description = stringValue(description)
+		}
+		endpoint.ResponseCodes = append(endpoint.ResponseCodes, responseCode)
+	}
+}
+
+// indexFiles collects the string constants and the types declared in all the files,
+// so they can be used to resolve routes, the bodies of the endpoints and the helpers of their (partial) classes
+func indexFiles(entries []data.FileMetaData) (*csharp.Constants, *csharp.Types, []Diagnostic) {
+	var constants = csharp.NewConstants()
+	var types = csharp.NewTypes()
+	var diagnostics = []Diagnostic{}
+	for _, entry := range entries {
+		fileData, err := os.ReadFile(entry.Path)
+		if err != nil {
+			diagnostics = append(diagnostics, newDiagnostic(SeverityWarning, entry.Path, "Error reading file to index constants and types: "+err.Error()))
+			continue
+		}
+		var file = csharp.Parse(csharp.StripComments(string(fileData)))
+		constants.Add(file)
+		types.Add(file)
+	}
+	return constants, types, diagnostics
+}
+
+// countFunctionAttributes counts the [Function(...)] and [FunctionName(...)] attributes in the source, regardless of what they are attached to
+func countFunctionAttributes(src string) int {
+	var tokens = csharp.Lex(src)
+	var count = 0
+	for i := 1; i+1 < len(tokens); i++ {
+		if functionAttributes[tokens[i].Text] && (tokens[i-1].Is("[") || tokens[i-1].Is(",")) && tokens[i+1].Is("(") {
+			count++
+		}
+	}
+	return count
+}
+
+// parseFunctions collects the azure functions declared in the file
+func parseFunctions(file csharp.File, constants *csharp.Constants, types *csharp.Types) []data.EndpointMetaData {
+	var endpoints = []data.EndpointMetaData{}
+	for _, method := range file.Methods {
+		// if this method has no function attribute, it's probably a regular function/method
+		var functionAttribute, isFunction = method.Attribute("Function", "FunctionName")
+		if !isFunction {
+			continue
+		}
+
+		var currentEndpoint = data.EndpointMetaData{Name: method.Name, ClassName: qualifiedTypeName(method.Namespace, method.TypeName)}
+		if name, exists := functionAttribute.Argument(0, "name"); exists {
+			currentEndpoint.Name = expressionValue(name, method.TypeName, constants)
+		}
+
+		for _, attribute := range method.Attributes {
+			searchAuthentication(attribute.Text, &currentEndpoint)
+			searchOpenApi(attribute.Text, &currentEndpoint)
+		}
+
+		parseFunctionHeader(method, constants, &currentEndpoint)
+		searchRequestBody(method, &currentEndpoint)
+		searchBodyParameters(method.Body, method.TypeName, constants, &currentEndpoint)
+		searchResponses(method, file, constants, types, &currentEndpoint)
+		searchComments(method, file.Comments, &currentEndpoint)
+		endpoints = append(endpoints, currentEndpoint)
+	}
+
+	return endpoints
+}
+
+// searchRequestBody finds the type of the request body when it isn't documented with [OpenApiRequestBody],
+// either from a [FromBody] parameter or from the body being read in the function, e.g. await req.ReadFromJsonAsync<Item>()
+func searchRequestBody(method csharp.Method, endpoint *data.EndpointMetaData) {
+	if endpoint.RequestBody != nil {
+		return
+	}
+	for _, parameter := range method.Parameters {
+		if _, fromBody := parameter.Attribute("FromBody"); fromBody {
+			endpoint.RequestBody = &data.RequestBodyMetaData{ContentType: "application/json", TypeName: strings.TrimSuffix(parameter.Type, "?"), Required: !strings.HasSuffix(parameter.Type, "?")}
+			return
+		}
+	}
+	if typeName, exists := csharp.GenericArgument(method.Body, readBodyMethods...); exists {
+		endpoint.RequestBody = &data.RequestBodyMetaData{ContentType: "application/json", TypeName: typeName}
+	}
+}
+
+func qualifiedTypeName(namespace string, typeName string) string {
+	if len(namespace) > 0 {
+		return namespace + "." + typeName
+	}
+	return typeName
+}
+
+// TODO: break this up into smaller functions to write separate unit tests for each?
+func parse(targetFile data.FileMetaData, constants *csharp.Constants, types *csharp.Types, logger *logrus.Logger) ([]data.EndpointMetaData, []Diagnostic) {
+	fileData, err := os.ReadFile(targetFile.Path)
+	if err != nil {
+		return []data.EndpointMetaData{}, []Diagnostic{newDiagnostic(SeverityError, targetFile.Path, "Error reading file: "+err.Error())}
+	}
+
+	// blank out commented and disabled code
+	var fileDataString string = csharp.StripComments(string(fileData))
+
+	var functionCount = countFunctionAttributes(fileDataString)
+	// controllers either derive from a *Controller class or are marked with [ApiController]/[Controller]
+	var mayHaveControllers = strings.Contains(fileDataString, "Controller")
+	// minimal apis are mapped with app.MapGet(...), group.MapPost(...) etc.
+	var mayHaveMinimalApis = strings.Contains(fileDataString, ".Map")
+	if functionCount == 0 && !mayHaveControllers && !mayHaveMinimalApis {
+		logger.Debug("No functions found in file: " + targetFile.Path)
+		return []data.EndpointMetaData{}, []Diagnostic{}
+	}
+	logger.Debug("Found " + strconv.Itoa(functionCount) + " functions in file: " + targetFile.Path)
+
+	var file = csharp.Parse(fileDataString)
+	file.Comments = csharp.CommentBlocks(string(fileData))
+	var endpoints = []data.EndpointMetaData{}
+	var diagnostics = []Diagnostic{}
+	if functionCount > 0 {
+		var functions = parseFunctions(file, constants, types)
+		if functionCount != len(functions) {
+			// should this be a hard fail?
+			diagnostics = append(diagnostics, newDiagnostic(SeverityWarning, targetFile.Path, "Documented "+strconv.Itoa(len(functions))+" functions, but expected "+strconv.Itoa(functionCount)))
+		}
+		endpoints = append(endpoints, functions...)
+	}
+	if mayHaveControllers {
+		endpoints = append(endpoints, parseControllers(file, constants, types)...)
+	}
+	if mayHaveMinimalApis {
+		endpoints = append(endpoints, parseMinimalApis(fileDataString, file, constants, types)...)
+	}
+
+	for i := range endpoints {
+		endpoints[i].FilePath = targetFile.Path
+		endpoints[i].Id = endpoints[i].GenerateId()
+	}
+
+	return endpoints, diagnostics
+}
+
+type CSharpParser struct{}
+
+func (c CSharpParser) Name() string {
+	return "csharp"
+}
+
+func (c CSharpParser) Extensions() []string {
+	return []string{".cs"}
+}
+
+// Parse documents the azure functions (isolated worker and in-process), controller actions and minimal apis declared in the files.
+// Routes can be built from constants, and bodies and their examples from models declared in any of the files, so they are all indexed first
+func (c CSharpParser) Parse(files []data.FileMetaData, logger *logrus.Logger) ([]data.EndpointMetaData, []Diagnostic) {
+	var constants, types, diagnostics = indexFiles(files)
+	logger.Debug("Found constants: " + strconv.Itoa(constants.Len()))
+	logger.Debug("Found types: " + strconv.Itoa(types.Len()))
+
+	var endpoints, parseDiagnostics = parseEach(files, logger, func(file data.FileMetaData, logger *logrus.Logger) ([]data.EndpointMetaData, []Diagnostic) {
+		return parse(file, constants, types, logger)
+	})
+	describeBodies(endpoints, types)
+	exampleBodies(endpoints, types, constants)
+	return endpoints, append(diagnostics, parseDiagnostics...)
+}
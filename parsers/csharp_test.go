@@ -0,0 +1,691 @@
+package parsers
+
+import (
+	"documentApi/csharp"
+	"documentApi/data"
+	"documentApi/utils"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var _ = os.Setenv("ENV", "test")
+var _, testLogger = utils.SetupLogger("test.log")
+
+// readTestMethod parses the test file and returns the method with the given name
+func readTestMethod(filePath string, name string) csharp.Method {
+	fileData, _ := os.ReadFile(filePath)
+	for _, method := range csharp.Parse(string(fileData)).Methods {
+		if method.Name == name {
+			return method
+		}
+	}
+	return csharp.Method{}
+}
+
+func Test_parseFunctionHeader_ReturnsExpectedFunctionMetaData(t *testing.T) {
+	// Arrange
+	var method = readTestMethod("../test_assets/one_endpoint.cs", "GetDashboardSummary")
+	var endpoint data.EndpointMetaData
+
+	// Act
+	parseFunctionHeader(method, nil, &endpoint)
+
+	// Assert
+	utils.AssertStringEqual(t, data.TriggerType["Http"], endpoint.TriggerType)
+	utils.AssertEqual(t, 1, len(endpoint.Methods))
+	utils.AssertStringEqual(t, "get", endpoint.Methods[0])
+	utils.AssertStringEqual(t, "dashboard-summary/{param}", endpoint.Route)
+	utils.AssertEqual(t, 1, len(endpoint.PathParameters))
+	utils.AssertMapContains(t, endpoint.PathParameters, "param")
+
+}
+
+func Test_parseFunctionHeader_ReturnsExpectedFunctionMetaDataFromSingleLineHeader(t *testing.T) {
+	// Arrange
+	var method = readTestMethod("../test_assets/one_endpoint_one_line_header.cs", "GetDashboardSummary")
+	var endpoint data.EndpointMetaData
+
+	// Act
+	parseFunctionHeader(method, nil, &endpoint)
+
+	// Assert
+	utils.AssertStringEqual(t, data.TriggerType["Http"], endpoint.TriggerType)
+	utils.AssertEqual(t, 1, len(endpoint.Methods))
+	utils.AssertStringEqual(t, "get", endpoint.Methods[0])
+	utils.AssertStringEqual(t, "dashboard-summary", endpoint.Route)
+}
+
+func Test_parseFunctionHeader_NormalizesTheRouteTemplate(t *testing.T) {
+	// Arrange
+	var method = readTestMethod("../test_assets/route_templates.cs", "GetItemVersion")
+	var endpoint data.EndpointMetaData
+
+	// Act
+	parseFunctionHeader(method, nil, &endpoint)
+
+	// Assert
+	utils.AssertStringEqual(t, "items/{id}/versions/{version}", endpoint.Route)
+	utils.AssertEqual(t, 2, len(endpoint.PathParameters))
+	utils.AssertMapContains(t, endpoint.PathParameters, "id")
+	utils.AssertMapContains(t, endpoint.PathParameters, "version")
+}
+
+func Test_parseFunctionHeader_MapsTheAuthorizationLevelToFunctionKeys(t *testing.T) {
+	tests := []struct {
+		name          string
+		filePath      string
+		method        string
+		expectedAuths []string
+	}{
+		{"Isolated function level", "../test_assets/function_keys.cs", "GetKeyedItem", []string{"FunctionKey"}},
+		{"Isolated named admin level", "../test_assets/function_keys.cs", "PurgeKeyedItems", []string{"AdminKey"}},
+		{"Isolated anonymous level", "../test_assets/function_keys.cs", "GetPublicItem", []string{}},
+		{"In-process function level", "../test_assets/in_process_functions.cs", "GetItems", []string{"FunctionKey"}},
+		{"In-process admin level", "../test_assets/in_process_functions.cs", "GetItem", []string{"AdminKey"}},
+		{"In-process anonymous level", "../test_assets/in_process_functions.cs", "CreateLegacyItem", []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			var method = readTestMethod(test.filePath, test.method)
+			var endpoint = data.EndpointMetaData{Authentication: []string{}}
+
+			// Act
+			parseFunctionHeader(method, nil, &endpoint)
+
+			// Assert
+			utils.AssertSliceEqual(t, test.expectedAuths, endpoint.Authentication)
+		})
+	}
+}
+
+func Test_parseFunctionHeader_ReturnsExpectedTimerMetaData(t *testing.T) {
+	// Arrange
+	var method = readTestMethod("../test_assets/complex_signatures.cs", "Cleanup")
+	var endpoint data.EndpointMetaData
+
+	// Act
+	parseFunctionHeader(method, nil, &endpoint)
+
+	// Assert
+	utils.AssertStringEqual(t, data.TriggerType["Timer"], endpoint.TriggerType)
+	utils.AssertStringEqual(t, "0 */5 * * * *", endpoint.Interval)
+}
+
+func Test_parse_ReturnsTriggersWithComplexSignatures(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{
+		Name: "complex_signatures.cs",
+		Path: "../test_assets/complex_signatures.cs",
+	}
+
+	// Act
+	var endpoints, _ = parse(testFile, nil, nil, testLogger)
+
+	// Assert
+	utils.AssertEqual(t, 4, len(endpoints))
+	utils.AssertStringEqual(t, "GetGeneric", endpoints[0].Name)
+	utils.AssertStringEqual(t, "generic/{id}", endpoints[0].Route)
+	utils.AssertSliceEqual(t, []string{"get", "post"}, endpoints[0].Methods)
+	utils.AssertStringEqual(t, "Returns (a) [list] of \"things\"", endpoints[0].Summary)
+	utils.AssertStringEqual(t, "MultiLineAttributes", endpoints[1].Name)
+	utils.AssertStringEqual(t, "multi/line", endpoints[1].Route)
+	utils.AssertSliceEqual(t, []string{"Read"}, endpoints[1].Authentication)
+	utils.AssertEqual(t, 1, len(endpoints[1].Parameters))
+	utils.AssertStringEqual(t, "filter)]", endpoints[1].Parameters[0].Name)
+	utils.AssertStringEqual(t, "RawStrings", endpoints[2].Name)
+	utils.AssertStringEqual(t, "raw/{name}", endpoints[2].Route)
+	utils.AssertStringEqual(t, "C:\\temp\\\"file\"", endpoints[2].Summary)
+	utils.AssertStringEqual(t, "Cleanup", endpoints[3].Name)
+	utils.AssertStringEqual(t, data.TriggerType["Timer"], endpoints[3].TriggerType)
+}
+
+func Test_parse_ReturnsAllExistingHttpTriggers(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{
+		Name: "http_endpoints_and_helpers.cs",
+		Path: "../test_assets/http_endpoints_and_helpers.cs",
+	}
+
+	// Act
+	var endpoints, _ = parse(testFile, nil, nil, testLogger)
+
+	// Assert
+	utils.AssertEqual(t, 4, len(endpoints))
+}
+
+func Test_parse_ReturnsAllDataOnExistingHttpTriggers(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{
+		Name: "http_endpoints_and_helpers.cs",
+		Path: "../test_assets/http_endpoints_and_helpers.cs",
+	}
+
+	var expectedEndpoints []data.EndpointMetaData = make([]data.EndpointMetaData, 0, 4)
+	expectedEndpoints = append(expectedEndpoints, data.EndpointMetaData{
+		Name:           "GetInitialInfoAsync",
+		Route:          "sandbox/{moduleId}/info",
+		Methods:        []string{"get"},
+		PathParameters: map[string]string{"moduleId": ""},
+		TriggerType:    data.TriggerType["Http"],
+	})
+	expectedEndpoints = append(expectedEndpoints, data.EndpointMetaData{
+		Name:           "GetAsync",
+		Authentication: []string{"OperationType.Read"},
+		Route:          "sandbox/{moduleId}",
+		Methods:        []string{"get"},
+		PathParameters: map[string]string{"moduleId": ""},
+		TriggerType:    data.TriggerType["Http"],
+	})
+	expectedEndpoints = append(expectedEndpoints, data.EndpointMetaData{
+		Name:           "PreprovisionSandboxAsync",
+		Authentication: []string{"DocsToken"},
+		Route:          "sandbox/preprovision/{moduleId}",
+		Methods:        []string{"post"},
+		PathParameters: map[string]string{"moduleId": ""},
+		TriggerType:    data.TriggerType["Http"],
+	})
+	expectedEndpoints = append(expectedEndpoints, data.EndpointMetaData{
+		Name:        "VerifyModules",
+		Route:       "sandbox/verify",
+		Methods:     []string{"get"},
+		TriggerType: data.TriggerType["Http"],
+	})
+
+	// Act
+	var endpoints, _ = parse(testFile, nil, nil, testLogger)
+
+	// Assert
+	for i := range expectedEndpoints {
+		utils.AssertStringEqual(t, expectedEndpoints[i].Name, endpoints[i].Name)
+		utils.AssertStringEqual(t, expectedEndpoints[i].Route, endpoints[i].Route)
+		utils.AssertStringEqual(t, expectedEndpoints[i].TriggerType, endpoints[i].TriggerType)
+		utils.AssertEqual(t, len(expectedEndpoints[i].Methods), len(endpoints[i].Methods))
+		utils.AssertEqual(t, len(expectedEndpoints[i].PathParameters), len(endpoints[i].PathParameters))
+		utils.AssertEqual(t, len(expectedEndpoints[i].Authentication), len(endpoints[i].Authentication))
+		for j := range expectedEndpoints[i].Methods {
+			utils.AssertStringEqual(t, expectedEndpoints[i].Methods[j], endpoints[i].Methods[j])
+		}
+		for j := range expectedEndpoints[i].PathParameters {
+			utils.AssertStringEqual(t, expectedEndpoints[i].PathParameters[j], endpoints[i].PathParameters[j])
+		}
+		for j := range expectedEndpoints[i].Authentication {
+			utils.AssertStringEqual(t, expectedEndpoints[i].Authentication[j], endpoints[i].Authentication[j])
+		}
+	}
+}
+
+func Test_searchAuthentication_ReturnsExpectedAuthentication(t *testing.T) {
+	// Arrange
+	tests := []struct {
+		name          string
+		expectedAuths []string
+		authString    string
+	}{
+		{
+			name:          "DocsTokenGroups",
+			expectedAuths: []string{"Learn", "Dirt-box", "Config", "Admin", "SG"},
+			authString:    "[RequireDocsTokenGroups(\"Learn Dirt-box Config Admin SG\")]",
+		},
+		{
+			name:          "DocsToken Read",
+			expectedAuths: []string{"OperationType.Read"},
+			authString:    "[RequireDocsToken(OperationType.Read)]",
+		},
+		{
+			name:          "S2SToken",
+			expectedAuths: []string{"S2S.SkillLessons"},
+			authString:    "[RequireS2SToken(S2S.SkillLessons)]",
+		},
+		{
+			name:          "S2SToken Multiple",
+			expectedAuths: []string{"WLW", "S2S.Percentile"},
+			authString:    "[RequireS2SToken(\"WLW\", S2S.Percentile)]",
+		},
+		{
+			name:          "DocsToken",
+			expectedAuths: []string{"DocsToken"},
+			authString:    "[RequireDocsToken]",
+		},
+		{
+			name:          "PlatformToken",
+			expectedAuths: []string{"PlatformApiAuth"},
+			authString:    "[RequirePlatformApiAuth]",
+		},
+	}
+	var results = make([]data.EndpointMetaData, len(tests))
+
+	// Act && Assert
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			searchAuthentication(test.authString, &results[i])
+			utils.AssertSliceEqual(t, test.expectedAuths, results[i].Authentication)
+		})
+	}
+}
+
+func Test_searchOpenApi_ReturnsExpectedOperationMetaData(t *testing.T) {
+	// Arrange
+	var endpoint data.EndpointMetaData
+
+	// Act
+	searchOpenApi(`[OpenApiOperation(tags: ["Repo", "Sandbox"], Summary = "Get initial info")]`, &endpoint)
+
+	// Assert
+	utils.AssertStringEqual(t, "Get initial info", endpoint.Summary)
+	utils.AssertStringEqual(t, "Get initial info", endpoint.Description)
+	utils.AssertSliceEqual(t, []string{"Repo", "Sandbox"}, endpoint.Tags)
+}
+
+func Test_searchOpenApi_ReturnsExpectedParameters(t *testing.T) {
+	// Arrange
+	var endpoint data.EndpointMetaData
+	var lines = []string{
+		`[OpenApiParameter("moduleId", Required = true, Type = typeof(string), In = ParameterLocation.Path)]`,
+		`[OpenApiParameter("locale", Required = false, Type = typeof(string), In = ParameterLocation.Query)]`,
+		`[OpenApiParameter(name: "X-SID", Required = true, Type = typeof(int), In = ParameterLocation.Header, Description = "session, id")]`,
+	}
+
+	// Act
+	for _, line := range lines {
+		searchOpenApi(line, &endpoint)
+	}
+
+	// Assert
+	utils.AssertEqual(t, 2, len(endpoint.Parameters)) // path parameters come from the route
+	utils.AssertStringEqual(t, "locale", endpoint.Parameters[0].Name)
+	utils.AssertStringEqual(t, "query", endpoint.Parameters[0].In)
+	utils.AssertStringEqual(t, "string", endpoint.Parameters[0].Type)
+	utils.AssertStringEqual(t, "false", strconv.FormatBool(endpoint.Parameters[0].Required))
+	utils.AssertStringEqual(t, "X-SID", endpoint.Parameters[1].Name)
+	utils.AssertStringEqual(t, "header", endpoint.Parameters[1].In)
+	utils.AssertStringEqual(t, "int", endpoint.Parameters[1].Type)
+	utils.AssertStringEqual(t, "true", strconv.FormatBool(endpoint.Parameters[1].Required))
+	utils.AssertStringEqual(t, "session, id", endpoint.Parameters[1].Description)
+}
+
+func Test_searchOpenApi_ReturnsExpectedRequestBody(t *testing.T) {
+	// Arrange
+	var endpoint data.EndpointMetaData
+
+	// Act
+	searchOpenApi(`[OpenApiRequestBody("application/json", typeof(CreateRequestBody), Example = typeof(CreateRequestBodyExample))]`, &endpoint)
+
+	// Assert
+	if endpoint.RequestBody == nil {
+		t.Fatal("expected request body to be set")
+	}
+	utils.AssertStringEqual(t, "application/json", endpoint.RequestBody.ContentType)
+	utils.AssertStringEqual(t, "CreateRequestBody", endpoint.RequestBody.TypeName)
+}
+
+func Test_searchOpenApi_ReturnsExpectedResponseCodes(t *testing.T) {
+	// Arrange
+	var endpoint data.EndpointMetaData
+	var lines = []string{
+		`[OpenApiResponseWithBody(statusCode: HttpStatusCode.OK, "application/json", typeof(ExampleResponse), Example = typeof(ExampleResponse))]`,
+		`[OpenApiResponseWithoutBody(statusCode: HttpStatusCode.Unauthorized, Description = "Authorization required")]`,
+		`[OpenApiResponseWithoutBody(statusCode: HttpStatusCode.InternalServerError, Description = $"OperationFailure: {nameof(GetInitialInfoAsync)}")]`,
+		`[OpenApiResponseWithoutBody((HttpStatusCode)418)]`,
+	}
+
+	// Act
+	for _, line := range lines {
+		searchOpenApi(line, &endpoint)
+	}
+
+	// Assert
+	utils.AssertEqual(t, 4, len(endpoint.ResponseCodes))
+	utils.AssertEqual(t, 200, endpoint.ResponseCodes[0].StatusCode)
+	utils.AssertStringEqual(t, "application/json", endpoint.ResponseCodes[0].ContentType)
+	utils.AssertStringEqual(t, "ExampleResponse", endpoint.ResponseCodes[0].TypeName)
+	utils.AssertEqual(t, 401, endpoint.ResponseCodes[1].StatusCode)
+	utils.AssertStringEqual(t, "Authorization required", endpoint.ResponseCodes[1].Description)
+	utils.AssertEqual(t, 500, endpoint.ResponseCodes[2].StatusCode)
+	utils.AssertStringEqual(t, "OperationFailure: GetInitialInfoAsync", endpoint.ResponseCodes[2].Description)
+	utils.AssertEqual(t, 418, endpoint.ResponseCodes[3].StatusCode)
+}
+
+func Test_parse_IgnoresCommentedOutAndDisabledTriggers(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{
+		Name: "commented_out_endpoints.cs",
+		Path: "../test_assets/commented_out_endpoints.cs",
+	}
+
+	// Act
+	var endpoints, _ = parse(testFile, nil, nil, testLogger)
+
+	// Assert
+	utils.AssertEqual(t, 2, len(endpoints))
+	utils.AssertStringEqual(t, "LiveEndpoint", endpoints[0].Name)
+	utils.AssertStringEqual(t, "live", endpoints[0].Route)
+	utils.AssertStringEqual(t, "See https://contoso.com/* for details", endpoints[0].Summary)
+	utils.AssertStringEqual(t, "ReleaseEndpoint", endpoints[1].Name)
+	utils.AssertStringEqual(t, "release", endpoints[1].Route)
+	utils.AssertSliceEqual(t, []string{"DocsToken"}, endpoints[1].Authentication)
+}
+
+func Test_parse_ResolvesRoutesBuiltFromConstants(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{
+		Name: "route_constants.cs",
+		Path: "../test_assets/route_constants.cs",
+	}
+	var constants, _, _ = indexFiles([]data.FileMetaData{testFile})
+
+	// Act
+	var endpoints, _ = parse(testFile, constants, nil, testLogger)
+
+	// Assert
+	utils.AssertEqual(t, 4, len(endpoints))
+	utils.AssertStringEqual(t, "GetItems", endpoints[0].Name)
+	utils.AssertStringEqual(t, "catalog/items", endpoints[0].Route)
+	utils.AssertStringEqual(t, "catalog/items/{itemId}/details", endpoints[1].Route)
+	utils.AssertMapContains(t, endpoints[1].PathParameters, "itemId")
+	utils.AssertStringEqual(t, "catalog/local/GetLocal/{id}", endpoints[2].Route)
+	utils.AssertMapContains(t, endpoints[2].PathParameters, "id")
+	utils.AssertStringEqual(t, "Routes.Circular", endpoints[3].Route) // unresolvable routes are kept as written
+}
+
+func Test_parse_SetsEndpointIdentity(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{
+		Name: "route_constants.cs",
+		Path: "../test_assets/route_constants.cs",
+	}
+
+	// Act
+	var endpoints, _ = parse(testFile, nil, nil, testLogger)
+
+	// Assert
+	utils.AssertStringEqual(t, "Repo.Functions.RouteTriggers", endpoints[0].ClassName)
+	utils.AssertStringEqual(t, "Repo.Functions.RouteTriggers/GetItems/GET", endpoints[0].Id)
+}
+
+func Test_parse_ReturnsControllerActions(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{
+		Name: "items_controller.cs",
+		Path: "../test_assets/items_controller.cs",
+	}
+
+	// Act
+	var endpoints, _ = parse(testFile, nil, nil, testLogger)
+
+	// Assert
+	var byName = make(map[string]data.EndpointMetaData)
+	var names = []string{}
+	for _, endpoint := range endpoints {
+		byName[endpoint.Name] = endpoint
+		names = append(names, endpoint.Name)
+	}
+	utils.AssertSliceEqual(t, []string{"Items_GetAll", "Items_GetById", "Items_Create", "Items_Update", "Items_Delete", "Items_Health", "Items_Download", "Items_Upload", "Reports_Index", "Reports_Summary"}, names)
+
+	var getAll = byName["Items_GetAll"]
+	utils.AssertStringEqual(t, "api/Items", getAll.Route)
+	utils.AssertSliceEqual(t, []string{"get"}, getAll.Methods)
+	utils.AssertSliceEqual(t, []string{"ReadItems"}, getAll.Authentication)
+	utils.AssertStringEqual(t, "Repo.Api.Controllers.ItemsController", getAll.ClassName)
+	utils.AssertEqual(t, 2, len(getAll.Parameters))
+	utils.AssertStringEqual(t, "page", getAll.Parameters[0].Name)
+	utils.AssertStringEqual(t, "query", getAll.Parameters[1].In)
+	if getAll.Parameters[1].Required {
+		t.Errorf("expected the nullable query parameter to be optional")
+	}
+
+	var getById = byName["Items_GetById"]
+	utils.AssertStringEqual(t, "api/Items/{id}", getById.Route)
+	utils.AssertMapContains(t, getById.PathParameters, "id")
+	utils.AssertEqual(t, 0, len(getById.Parameters))
+
+	var create = byName["Items_Create"]
+	utils.AssertSliceEqual(t, []string{"post"}, create.Methods)
+	utils.AssertSliceEqual(t, []string{"ReadItems", "Admin", "Editor"}, create.Authentication)
+	utils.AssertStringEqual(t, "CreateItemRequest", create.RequestBody.TypeName)
+	utils.AssertStringEqual(t, "X-Correlation-Id", create.Parameters[0].Name)
+	utils.AssertStringEqual(t, "header", create.Parameters[0].In)
+
+	var update = byName["Items_Update"]
+	utils.AssertSliceEqual(t, []string{"put", "patch"}, update.Methods)
+	utils.AssertStringEqual(t, "UpdateItemRequest", update.RequestBody.TypeName)
+
+	utils.AssertStringEqual(t, "api/admin/items/{id}", byName["Items_Delete"].Route)
+	utils.AssertEqual(t, 0, len(byName["Items_Health"].Authentication))
+	utils.AssertStringEqual(t, "api/Items/Download", byName["Items_Download"].Route)
+	utils.AssertStringEqual(t, "multipart/form-data", byName["Items_Upload"].RequestBody.ContentType)
+
+	utils.AssertStringEqual(t, "Admin/Reports/Index/{slug}", byName["Reports_Index"].Route)
+	utils.AssertStringEqual(t, "Admin/Reports", byName["Reports_Summary"].Route)
+	utils.AssertEqual(t, 0, len(byName["Reports_Summary"].Methods))
+}
+
+func Test_parse_FallsBackToTheConventionalRouteOfControllerActions(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{
+		Name: "conventional_controller.cs",
+		Path: "../test_assets/conventional_controller.cs",
+	}
+
+	// Act
+	var endpoints, _ = parse(testFile, nil, nil, testLogger)
+
+	// Assert
+	var routes = []string{}
+	for _, endpoint := range endpoints {
+		routes = append(routes, endpoint.Name+" "+strings.Join(endpoint.Methods, ",")+" "+endpoint.Route)
+	}
+	// actions without an http method attribute aren't documented
+	utils.AssertSliceEqual(t, []string{"Legacy_Index get Legacy/Index", "Legacy_Save post Legacy/Save", "Users_List get Admin/Users/List"}, routes)
+}
+
+func Test_parse_ReturnsMinimalApiEndpoints(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{
+		Name: "minimal_api.cs",
+		Path: "../test_assets/minimal_api.cs",
+	}
+
+	// Act
+	var endpoints, _ = parse(testFile, nil, nil, testLogger)
+
+	// Assert
+	var byName = make(map[string]data.EndpointMetaData)
+	var names = []string{}
+	for _, endpoint := range endpoints {
+		byName[endpoint.Name] = endpoint
+		names = append(names, endpoint.Name)
+	}
+	utils.AssertSliceEqual(t, []string{"MapGet_health", "ListItems", "MapGet_api_items_id", "MapPost_api_items", "UpdateItem", "MapDelete_admin_items_id", "MapGet_api_reports_slug"}, names)
+
+	var health = byName["MapGet_health"]
+	utils.AssertStringEqual(t, "health", health.Route)
+	utils.AssertStringEqual(t, "Program", health.ClassName)
+	utils.AssertStringEqual(t, "GET /health", health.Description)
+	utils.AssertEqual(t, 0, len(health.Authentication))
+
+	var list = byName["ListItems"]
+	utils.AssertStringEqual(t, "api/items", list.Route)
+	utils.AssertStringEqual(t, "", list.Description)
+	utils.AssertSliceEqual(t, []string{"get"}, list.Methods)
+	utils.AssertSliceEqual(t, []string{"ReadItems"}, list.Authentication)
+	utils.AssertSliceEqual(t, []string{"Items"}, list.Tags)
+	utils.AssertEqual(t, 2, len(list.Parameters))
+	utils.AssertStringEqual(t, "page", list.Parameters[0].Name)
+	if list.Parameters[1].Required {
+		t.Errorf("expected the nullable query parameter to be optional")
+	}
+
+	var get = byName["MapGet_api_items_id"]
+	utils.AssertMapContains(t, get.PathParameters, "id")
+	utils.AssertEqual(t, 0, len(get.Parameters))
+	utils.AssertStringEqual(t, "Get an item", get.Summary)
+
+	var create = byName["MapPost_api_items"]
+	utils.AssertSliceEqual(t, []string{"ReadItems", "Admin"}, create.Authentication)
+	utils.AssertStringEqual(t, "CreateItemRequest", create.RequestBody.TypeName)
+	utils.AssertStringEqual(t, "X-Correlation-Id", create.Parameters[0].Name)
+	utils.AssertStringEqual(t, "header", create.Parameters[0].In)
+	utils.AssertEqual(t, 201, create.ResponseCodes[0].StatusCode)
+	utils.AssertStringEqual(t, "Item", create.ResponseCodes[0].TypeName)
+
+	var update = byName["UpdateItem"]
+	utils.AssertSliceEqual(t, []string{"put", "patch"}, update.Methods)
+	utils.AssertStringEqual(t, "UpdateItemRequest", update.RequestBody.TypeName)
+
+	utils.AssertSliceEqual(t, []string{"Owner", "Authorize"}, byName["MapDelete_admin_items_id"].Authentication)
+
+	var report = byName["MapGet_api_reports_slug"]
+	utils.AssertStringEqual(t, "ReportEndpoints", report.ClassName)
+	utils.AssertEqual(t, 0, len(report.Parameters))
+}
+
+func Test_parse_ReturnsInProcessFunctions(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{
+		Name: "in_process_functions.cs",
+		Path: "../test_assets/in_process_functions.cs",
+	}
+
+	// Act
+	var endpoints, _ = parse(testFile, nil, nil, testLogger)
+
+	// Assert
+	utils.AssertEqual(t, 4, len(endpoints))
+	var routes = []string{}
+	for _, endpoint := range endpoints {
+		routes = append(routes, endpoint.Route)
+	}
+	// functions without a route (or a null one) are served on their function name
+	utils.AssertSliceEqual(t, []string{"GetLegacyItems", "CreateLegacyItem", "legacy/items/{id}", ""}, routes)
+	utils.AssertStringEqual(t, "GetLegacyItems", endpoints[0].Name)
+	utils.AssertSliceEqual(t, []string{"get"}, endpoints[0].Methods)
+	utils.AssertSliceEqual(t, []string{"post"}, endpoints[1].Methods)
+	utils.AssertMapContains(t, endpoints[2].PathParameters, "id")
+	utils.AssertSliceEqual(t, []string{"FunctionKey"}, endpoints[0].Authentication)
+	utils.AssertEqual(t, 0, len(endpoints[1].Authentication))
+	utils.AssertSliceEqual(t, []string{"AdminKey"}, endpoints[2].Authentication)
+	utils.AssertStringEqual(t, data.TriggerType["Timer"], endpoints[3].TriggerType)
+	utils.AssertStringEqual(t, "0 0 3 * * *", endpoints[3].Interval)
+}
+
+func Test_parse_ReturnsTriggerBindings(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{
+		Name: "background_triggers.cs",
+		Path: "../test_assets/background_triggers.cs",
+	}
+	var constants, _, _ = indexFiles([]data.FileMetaData{testFile})
+
+	// Act
+	var endpoints, _ = parse(testFile, constants, nil, testLogger)
+
+	// Assert
+	var byName = make(map[string]data.EndpointMetaData)
+	for _, endpoint := range endpoints {
+		byName[endpoint.Name] = endpoint
+	}
+	utils.AssertEqual(t, 10, len(endpoints))
+
+	var tests = []struct {
+		name        string
+		triggerType string
+		binding     *data.TriggerBinding
+	}{
+		{"ProcessOrder", data.TriggerType["Queue"], &data.TriggerBinding{QueueName: "orders", Connection: "StorageConnection"}},
+		{"ProcessInvoice", data.TriggerType["ServiceBus"], &data.TriggerBinding{QueueName: "invoices", Connection: "ServiceBusConnection"}},
+		{"ProcessNotification", data.TriggerType["ServiceBus"], &data.TriggerBinding{TopicName: "notifications", SubscriptionName: "email", Connection: "ServiceBusConnection"}},
+		{"ResizeImage", data.TriggerType["Blob"], &data.TriggerBinding{Path: "images/{name}", Connection: "StorageConnection"}},
+		{"ReadTelemetry", data.TriggerType["EventHub"], &data.TriggerBinding{EventHubName: "telemetry", Connection: "EventHubConnection", ConsumerGroup: "docs"}},
+		{"SyncItems", data.TriggerType["CosmosDB"], &data.TriggerBinding{DatabaseName: "catalog", ContainerName: "items", Connection: "CosmosConnection", LeaseContainerName: "leases"}},
+		{"OnEvent", data.TriggerType["EventGrid"], nil},
+		{"RunOrchestrator", data.TriggerType["Orchestration"], nil},
+		{"SayHello", data.TriggerType["Activity"], nil},
+		{"Counter", data.TriggerType["Entity"], &data.TriggerBinding{Name: "counter"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var endpoint = byName[test.name]
+			utils.AssertStringEqual(t, test.triggerType, endpoint.TriggerType)
+			if (test.binding == nil) != (endpoint.Binding == nil) || test.binding != nil && *test.binding != *endpoint.Binding {
+				t.Errorf("expected binding %+v, got %+v", test.binding, endpoint.Binding)
+			}
+		})
+	}
+}
+
+func Test_Parse_ResolvesBodySchemasFromModels(t *testing.T) {
+	// Arrange
+	var files = []data.FileMetaData{
+		{Name: "OrderFunctions.cs", Path: "../test_assets/models_app/OrderFunctions.cs"},
+		{Name: "Models.cs", Path: "../test_assets/models_app/Models.cs"},
+	}
+
+	// Act
+	var endpoints, _ = CSharpParser{}.Parse(files, testLogger)
+
+	// Assert
+	var byName = make(map[string]data.EndpointMetaData)
+	for _, endpoint := range endpoints {
+		byName[endpoint.Name] = endpoint
+	}
+	utils.AssertEqual(t, 5, len(endpoints))
+
+	var tests = []struct {
+		name     string
+		typeName string
+		title    string // empty if the type can't be resolved
+	}{
+		{"CreateOrder", "CreateOrderRequest", "CreateOrderRequest"}, // [OpenApiRequestBody]
+		{"UpdateOrder", "Order", "Order"},                           // ReadFromJsonAsync<T>
+		{"ImportCategories", "List<Category>", ""},                  // [FromBody]
+		{"TagOrder", "TagRequest", ""},                              // ReadBodyAs<T> of a type that isn't in the repo
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requestBody = byName[test.name].RequestBody
+			if requestBody == nil {
+				t.Fatal("expected a request body")
+			}
+			utils.AssertStringEqual(t, "application/json", requestBody.ContentType)
+			utils.AssertStringEqual(t, test.typeName, requestBody.TypeName)
+			if test.name == "TagOrder" {
+				if requestBody.Schema != nil {
+					t.Errorf("expected no schema, got %+v", requestBody.Schema)
+				}
+				return
+			}
+			utils.AssertStringEqual(t, test.title, requestBody.Schema.Title)
+		})
+	}
+
+	var responseCodes = byName["ListOrders"].ResponseCodes
+	utils.AssertEqual(t, 1, len(responseCodes))
+	utils.AssertStringEqual(t, "PagedResult<Order>", responseCodes[0].Schema.Title)
+	utils.AssertSliceEqual(t, []string{"array"}, byName["ImportCategories"].RequestBody.Schema.Type)
+}
+
+func Test_Parse_GeneratesExampleBodies(t *testing.T) {
+	// Act
+	var endpoints, _ = CSharpParser{}.Parse(modelsAppFiles, testLogger)
+
+	// Assert
+	var byName = make(map[string]data.EndpointMetaData)
+	for _, endpoint := range endpoints {
+		byName[endpoint.Name] = endpoint
+	}
+	// from the OpenApiExample class
+	utils.AssertStringEqual(t, "CreateOrderRequestExample", byName["CreateOrder"].RequestBody.Example)
+	if !strings.HasPrefix(byName["CreateOrder"].Body, "{\n  \"customerId\": \"customer-42\",") {
+		t.Errorf("expected the example body, got %s", byName["CreateOrder"].Body)
+	}
+	// placeholders from the schema
+	if !strings.Contains(byName["UpdateOrder"].Body, `"status": "Pending"`) {
+		t.Errorf("expected a placeholder body, got %s", byName["UpdateOrder"].Body)
+	}
+	utils.AssertStringEqual(t, "", byName["TagOrder"].Body)
+	utils.AssertStringEqual(t, "", byName["ListOrders"].Body)
+}
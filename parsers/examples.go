@@ -0,0 +1,403 @@
+package parsers
+
+import (
+	"bytes"
+	"documentApi/csharp"
+	"documentApi/data"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// the placeholder values of the string formats, used in the bodies built from a schema
+var formatPlaceholders = map[string]string{
+	"uuid":      "00000000-0000-0000-0000-000000000000",
+	"date-time": "2024-01-01T00:00:00Z",
+	"date":      "2024-01-01",
+	"time":      "00:00:00",
+	"uri":       "https://example.com",
+	"byte":      "",
+}
+
+// jsonObject is a json object that keeps its members in the order they are added, e.g. as they are written in an initializer
+type jsonObject []jsonMember
+
+type jsonMember struct {
+	name  string
+	value any
+}
+
+// set adds the member, or replaces the value of the member with the same name
+func (o jsonObject) set(name string, value any) jsonObject {
+	for i := range o {
+		if o[i].name == name {
+			o[i].value = value
+			return o
+		}
+	}
+	return append(o, jsonMember{name: name, value: value})
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("{")
+	for i, member := range o {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+		name, err := marshalJson(member.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := marshalJson(member.value)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(name)
+		buffer.WriteString(":")
+		buffer.Write(value)
+	}
+	buffer.WriteString("}")
+	return buffer.Bytes(), nil
+}
+
+// marshalJson serializes v without escaping html characters (e.g. <, > and & in example strings)
+func marshalJson(v any) ([]byte, error) {
+	var buffer bytes.Buffer
+	var encoder = json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buffer.Bytes(), "\n"), nil
+}
+
+// indentedJson serializes the body the way it is shown in the collections, indented with two spaces
+func indentedJson(v any) (string, bool) {
+	var compact, err = marshalJson(v)
+	if err != nil {
+		return "", false
+	}
+	var buffer bytes.Buffer
+	if err := json.Indent(&buffer, compact, "", "  "); err != nil {
+		return "", false
+	}
+	return buffer.String(), true
+}
+
+// placeholderValue builds a value of the schema's type, for the bodies that don't have an example
+func placeholderValue(schema *data.JsonSchema) any {
+	if schema == nil {
+		return nil
+	}
+	switch {
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	case schema.Type.Is("object"):
+		if schema.AdditionalProperties != nil {
+			return jsonObject{{name: "key", value: placeholderValue(schema.AdditionalProperties)}}
+		}
+		var object = jsonObject{}
+		for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+			object = append(object, jsonMember{name: name, value: placeholderValue(schema.Properties[name])})
+		}
+		return object
+	case schema.Type.Is("array"):
+		if schema.Items == nil {
+			return []any{}
+		}
+		return []any{placeholderValue(schema.Items)}
+	case schema.Type.Is("string"):
+		if placeholder, exists := formatPlaceholders[schema.Format]; exists {
+			return placeholder
+		}
+		return "string"
+	case schema.Type.Is("integer"), schema.Type.Is("number"):
+		return 0
+	case schema.Type.Is("boolean"):
+		return false
+	}
+	return nil
+}
+
+// exampleBuilder converts the object and collection initializers of an example class to the json they are serialized as
+type exampleBuilder struct {
+	types     *csharp.Types
+	constants *csharp.Constants
+	className string // the example class, constants are resolved from it
+}
+
+// exampleBody returns the json of the example declared by an OpenApiExample<T> class, the object it builds in its Build method
+func exampleBody(exampleType string, namespace string, types *csharp.Types, constants *csharp.Constants) (string, bool) {
+	var model, exists = types.Lookup(qualifiedTypeReference(csharp.ParseTypeName(exampleType)), namespace)
+	if !exists {
+		return "", false
+	}
+
+	// the type of the example is the type argument of the OpenApiExample<T> base class
+	var bodyType = ""
+	for _, baseType := range model.Declaration.BaseTypes {
+		if parsed := csharp.ParseTypeName(baseType); parsed.Name == "OpenApiExample" && len(parsed.Arguments) == 1 {
+			bodyType = parsed.Arguments[0]
+		}
+	}
+	if len(bodyType) < 1 {
+		return "", false
+	}
+
+	var builder = exampleBuilder{types: types, constants: constants, className: model.Declaration.Name}
+	var schema, _ = typeSchema(bodyType, model.Declaration.Namespace, types)
+	for _, method := range model.Methods {
+		if method.Name != "Build" {
+			continue
+		}
+		for _, expression := range csharp.NewExpressions(method.Body) {
+			// target typed new() and new[] are the examples passed straight to OpenApiExampleResolver.Resolve
+			var targetTyped = len(expression.TypeName) < 1 && (len(expression.Members) > 0 || len(expression.Items) > 0)
+			if !targetTyped && csharp.ParseTypeName(expression.TypeName).Name != csharp.ParseTypeName(bodyType).Name {
+				continue
+			}
+			return indentedJson(builder.value(expression, bodyType, model.Declaration.Namespace, schema))
+		}
+	}
+	return "", false
+}
+
+func qualifiedTypeReference(typeName csharp.TypeName) string {
+	if len(typeName.Qualifier) > 0 {
+		return typeName.Qualifier + "." + typeName.Name
+	}
+	return typeName.Name
+}
+
+// value converts the expression assigned to a member of the type (referenced from the namespace) to its json value.
+// Expressions that can't be evaluated get a placeholder value of the schema's type
+func (e *exampleBuilder) value(expression csharp.Expression, typeName string, namespace string, schema *data.JsonSchema) any {
+	if expression.IsNew && len(expression.TypeName) > 0 {
+		typeName = expression.TypeName
+	}
+	var isPrimitive = schema != nil && len(schema.Type) > 0 && !schema.Type.Is("object") && !schema.Type.Is("array")
+	if (expression.IsNew || expression.IsInitializer() || expression.IsCollection()) && !isPrimitive {
+		return e.initializerValue(expression, typeName, namespace, schema)
+	}
+	return e.literalValue(expression.Tokens, schema)
+}
+
+// initializerValue converts an object creation, initializer or collection expression to a json object or array
+func (e *exampleBuilder) initializerValue(expression csharp.Expression, typeName string, namespace string, schema *data.JsonSchema) any {
+	var parsed = csharp.ParseTypeName(typeName)
+	var hasIndexers = false
+	for _, member := range expression.Members {
+		hasIndexers = hasIndexers || len(member.Index) > 0
+	}
+	var isDictionary = dictionaryTypes[parsed.Name] || hasIndexers || schema != nil && schema.AdditionalProperties != nil
+	var isArray = len(parsed.Element) > 0 || collectionTypes[parsed.Name] || expression.IsCollection() || schema != nil && schema.Type.Is("array")
+
+	var itemSchema, valueSchema *data.JsonSchema
+	if schema != nil {
+		itemSchema, valueSchema = schema.Items, schema.AdditionalProperties
+	}
+	switch {
+	case isDictionary:
+		var valueType = ""
+		if len(parsed.Arguments) == 2 {
+			valueType = parsed.Arguments[1]
+		}
+		var object = jsonObject{}
+		for _, member := range expression.Members {
+			if len(member.Index) > 0 {
+				object = object.set(e.keyValue(member.Index), e.value(member.Value, valueType, namespace, valueSchema))
+			}
+		}
+		// { "key", value } pairs of a collection initializer
+		for _, item := range expression.Items {
+			if len(item.Items) == 2 {
+				object = object.set(e.keyValue(item.Items[0].Tokens), e.value(item.Items[1], valueType, namespace, valueSchema))
+			}
+		}
+		return object
+	case isArray:
+		var elementType = parsed.Element
+		if len(parsed.Arguments) == 1 {
+			elementType = parsed.Arguments[0]
+		}
+		var values = []any{}
+		for _, item := range expression.Items {
+			values = append(values, e.value(item, elementType, namespace, itemSchema))
+		}
+		return values
+	}
+
+	var model, exists = e.types.Lookup(qualifiedTypeReference(parsed), namespace)
+	if len(expression.Members) < 1 && (!exists || len(expression.Arguments) < 1) {
+		// e.g. new Item() without an initializer
+		if schema != nil {
+			return placeholderValue(schema)
+		}
+		return jsonObject{}
+	}
+
+	var object = jsonObject{}
+	if exists && model.Declaration.Kind == "record" {
+		// positional record parameters
+		for i, argument := range expression.Arguments {
+			if i < len(model.Declaration.Parameters) {
+				var parameter = model.Declaration.Parameters[i]
+				var name, _ = jsonProperty(csharp.Field{Name: parameter.Name, Attributes: parameter.Attributes})
+				object = object.set(name, e.value(argument, parameter.Type, model.Declaration.Namespace, propertySchema(schema, name)))
+			}
+		}
+	}
+	for _, member := range expression.Members {
+		var name, memberType, memberNamespace = camelCase(member.Name), "", namespace
+		if exists {
+			if property, declaredIn, found := e.findProperty(model, member.Name, 0); found {
+				if isJsonIgnored(property) {
+					continue
+				}
+				name, _ = jsonProperty(property)
+				memberType, memberNamespace = property.Type, declaredIn
+			}
+		}
+		object = object.set(name, e.value(member.Value, memberType, memberNamespace, propertySchema(schema, name)))
+	}
+	return object
+}
+
+func propertySchema(schema *data.JsonSchema, name string) *data.JsonSchema {
+	if schema == nil {
+		return nil
+	}
+	return schema.Properties[name]
+}
+
+// findProperty finds the property (or record parameter) of the model with the c# name, including the inherited ones.
+// Returns the namespace of the type it is declared in
+func (e *exampleBuilder) findProperty(model *csharp.Model, name string, depth int) (csharp.Field, string, bool) {
+	for _, member := range model.Members {
+		if member.Name == name {
+			return member, model.Declaration.Namespace, true
+		}
+	}
+	for _, parameter := range model.Declaration.Parameters {
+		if parameter.Name == name && model.Declaration.Kind == "record" {
+			return csharp.Field{Name: parameter.Name, Type: parameter.Type, Attributes: parameter.Attributes, IsProperty: true}, model.Declaration.Namespace, true
+		}
+	}
+	if depth > 10 { // guards against inheritance cycles
+		return csharp.Field{}, "", false
+	}
+	for _, baseType := range model.Declaration.BaseTypes {
+		if base, exists := e.types.Lookup(qualifiedTypeReference(csharp.ParseTypeName(baseType)), model.Declaration.Namespace); exists {
+			if property, declaredIn, found := e.findProperty(base, name, depth+1); found {
+				return property, declaredIn, true
+			}
+		}
+	}
+	return csharp.Field{}, "", false
+}
+
+// keyValue returns the dictionary key of an indexer or collection initializer pair
+func (e *exampleBuilder) keyValue(tokens []csharp.Token) string {
+	if value, ok := e.constants.Evaluate(tokens, e.className); ok {
+		return value
+	}
+	var key, _ = e.literalValue(tokens, nil).(string)
+	return key
+}
+
+// literalValue converts a literal, constant, enum member or a value parsed from a single string literal
+// (e.g. Guid.Parse("...") or new Uri("...")) to its json value
+func (e *exampleBuilder) literalValue(tokens []csharp.Token, schema *data.JsonSchema) any {
+	if len(tokens) < 1 {
+		return placeholderValue(schema)
+	}
+
+	if len(tokens) == 2 && tokens[0].Is("-") && tokens[1].Kind == csharp.Number {
+		if number, ok := numberValue("-" + tokens[1].Text); ok {
+			return number
+		}
+	}
+	if len(tokens) == 1 {
+		var token = tokens[0]
+		switch {
+		case token.Kind == csharp.Number:
+			if number, ok := numberValue(token.Text); ok {
+				return number
+			}
+		case token.Kind == csharp.Char:
+			return strings.Trim(token.Text, "'")
+		case token.Is("true"), token.Is("false"):
+			return token.Is("true")
+		case token.Is("null"):
+			return nil
+		}
+	}
+
+	// enum members are serialized as their name when the enum is converted to a string
+	var last = tokens[len(tokens)-1]
+	if schema != nil && last.Kind == csharp.Identifier && slices.Contains(schema.Enum, last.Text) {
+		return last.Text
+	}
+	if value, ok := e.constants.Evaluate(tokens, e.className); ok {
+		return value
+	}
+
+	var literals = []string{}
+	for _, token := range tokens {
+		if value, isString := csharp.StringValue(token); isString {
+			literals = append(literals, value)
+		}
+	}
+	if len(literals) == 1 && (schema == nil || schema.Type.Is("string")) {
+		return literals[0]
+	}
+	return placeholderValue(schema)
+}
+
+// numberValue converts a c# numeric literal to a json number, e.g. 9.99m, 1_000 or 0xFF
+func numberValue(text string) (json.Number, bool) {
+	text = strings.ReplaceAll(text, "_", "")
+	var lower = strings.ToLower(text)
+	if strings.HasPrefix(strings.TrimPrefix(lower, "-"), "0x") || strings.HasPrefix(strings.TrimPrefix(lower, "-"), "0b") {
+		var value, err = strconv.ParseInt(strings.TrimRight(lower, "ul"), 0, 64)
+		return json.Number(strconv.FormatInt(value, 10)), err == nil
+	}
+
+	text = strings.TrimRight(text, "mMdDfFlLuU")
+	var value, err = strconv.ParseFloat(text, 64)
+	if err != nil {
+		return "", false
+	}
+	if strings.HasPrefix(strings.TrimPrefix(text, "-"), ".") || strings.HasSuffix(text, ".") {
+		return json.Number(strconv.FormatFloat(value, 'f', -1, 64)), true
+	}
+	return json.Number(text), true
+}
+
+// exampleBodies fills in the example json body of the endpoints that read json, from their OpenApiExample class
+// when there is one, otherwise with placeholder values built from the schema of the body
+func exampleBodies(endpoints []data.EndpointMetaData, types *csharp.Types, constants *csharp.Constants) {
+	for i := range endpoints {
+		var requestBody = endpoints[i].RequestBody
+		if requestBody == nil || !strings.Contains(strings.ToLower(requestBody.ContentType), "json") {
+			continue
+		}
+
+		if len(requestBody.Example) > 0 {
+			var namespace = ""
+			if dot := strings.LastIndex(endpoints[i].ClassName, "."); dot > -1 {
+				namespace = endpoints[i].ClassName[:dot]
+			}
+			if body, exists := exampleBody(requestBody.Example, namespace, types, constants); exists {
+				endpoints[i].Body = body
+				continue
+			}
+		}
+		if requestBody.Schema != nil {
+			endpoints[i].Body, _ = indentedJson(placeholderValue(requestBody.Schema))
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package parsers
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"testing"
+)
+
+var modelsAppFiles = []data.FileMetaData{
+	{Name: "OrderFunctions.cs", Path: "../test_assets/models_app/OrderFunctions.cs"},
+	{Name: "Models.cs", Path: "../test_assets/models_app/Models.cs"},
+	{Name: "Examples.cs", Path: "../test_assets/models_app/Examples.cs"},
+}
+
+func Test_exampleBody_ConvertsTheObjectInitializers(t *testing.T) {
+	var tests = []struct {
+		name     string
+		example  string
+		expected string
+	}{
+		{"nested objects, records and constants", "CreateOrderRequestExample", `{
+  "customerId": "customer-42",
+  "lines": [
+    {
+      "sku": "SKU-1",
+      "quantity": 2,
+      "unitPrice": 9.99
+    },
+    {
+      "sku": "SKU-2",
+      "quantity": 1000,
+      "unitPrice": -0.5
+    }
+  ],
+  "shippingAddress": {
+    "line1": "1 Main St",
+    "city": "Springfield"
+  },
+  "express": true,
+  "deliverBy": "2024-05-01"
+}`},
+		{"inherited members, enums, dictionaries and arrays", "Repo.Orders.Examples.OrderExample", `{
+  "id": "3f2504e0-4f89-11d3-9a0c-0305e82c3301",
+  "order_number": "A-1001",
+  "customerId": "customer-7",
+  "status": "Shipped",
+  "discount": null,
+  "metadata": {
+    "channel": "web",
+    "campaign": "spring"
+  },
+  "tags": [
+    "gift",
+    "priority"
+  ]
+}`},
+	}
+
+	var constants, types, _ = indexFiles(modelsAppFiles)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			body, exists := exampleBody(test.example, "Repo.Orders", types, constants)
+
+			// Assert
+			if !exists {
+				t.Fatal("expected the example to be found")
+			}
+			utils.AssertStringEqual(t, test.expected, body)
+		})
+	}
+}
+
+func Test_exampleBody_ReturnsFalseForUnknownExamples(t *testing.T) {
+	// Arrange
+	var constants, types, _ = indexFiles(modelsAppFiles)
+
+	// Act
+	_, exists := exampleBody("MissingExample", "Repo.Orders", types, constants)
+
+	// Assert
+	if exists {
+		t.Error("expected no example body")
+	}
+}
+
+func Test_placeholderValue_ReturnsValuesOfTheSchemaType(t *testing.T) {
+	// Arrange
+	var schema = &data.JsonSchema{Type: data.SchemaType{"object"}, Properties: map[string]*data.JsonSchema{
+		"when":   {Type: data.SchemaType{"string"}, Format: "date-time"},
+		"count":  {Type: data.SchemaType{"integer"}},
+		"status": {Type: data.SchemaType{"string"}, Enum: []string{"Open", "Closed"}},
+		"tags":   {Type: data.SchemaType{"array"}, Items: &data.JsonSchema{Type: data.SchemaType{"string"}}},
+		"extra":  {Type: data.SchemaType{"object"}, AdditionalProperties: &data.JsonSchema{Type: data.SchemaType{"boolean"}}},
+		"any":    {},
+	}}
+
+	// Act
+	body, _ := indentedJson(placeholderValue(schema))
+
+	// Assert
+	utils.AssertStringEqual(t, `{
+  "any": null,
+  "count": 0,
+  "extra": {
+    "key": false
+  },
+  "status": "Open",
+  "tags": [
+    "string"
+  ],
+  "when": "2024-01-01T00:00:00Z"
+}`, body)
+}
+
+func Test_numberValue_ConvertsNumericLiterals(t *testing.T) {
+	var tests = []struct {
+		literal  string
+		expected string
+	}{
+		{"42", "42"},
+		{"9.99m", "9.99"},
+		{"1_000L", "1000"},
+		{"0xFF", "255"},
+		{"-2.5f", "-2.5"},
+		{".5d", "0.5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.literal, func(t *testing.T) {
+			// Act
+			number, ok := numberValue(test.literal)
+
+			// Assert
+			if !ok {
+				t.Fatal("expected a number")
+			}
+			utils.AssertStringEqual(t, test.expected, string(number))
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package parsers
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// bindingTriggerType returns the trigger type for a function.json binding, e.g. httpTrigger -> http
+func bindingTriggerType(binding data.FunctionBinding) (string, bool) {
+	if !strings.HasSuffix(binding.Type, "Trigger") {
+		return "", false
+	}
+	var name = strings.TrimSuffix(binding.Type, "Trigger")
+	for key, triggerType := range data.TriggerType {
+		if strings.EqualFold(key, name) {
+			return triggerType, true
+		}
+	}
+	return data.TriggerType["UNKNOWN"], true
+}
+
+// isGeneratedFunctionJson reports whether the function.json was generated from a compiled (dotnet) function,
+// those are documented from their source instead
+func isGeneratedFunctionJson(function data.FunctionJson) bool {
+	return strings.HasSuffix(strings.ToLower(function.ScriptFile), ".dll")
+}
+
+// parseFunctionJson documents the function declared by the function.json of a script based function app (node, python, powershell etc.).
+// The function is named after the folder it is in, which is also used as the route when the http trigger doesn't set one
+func parseFunctionJson(targetFile data.FileMetaData, logger *logrus.Logger) ([]data.EndpointMetaData, []Diagnostic) {
+	fileData, err := os.ReadFile(targetFile.Path)
+	if err != nil {
+		return []data.EndpointMetaData{}, []Diagnostic{newDiagnostic(SeverityError, targetFile.Path, "Error reading file: "+err.Error())}
+	}
+
+	var function data.FunctionJson
+	if err := json.Unmarshal(fileData, &function); err != nil {
+		return []data.EndpointMetaData{}, []Diagnostic{newDiagnostic(SeverityWarning, targetFile.Path, "Error parsing function json file: "+err.Error())}
+	}
+	if function.Disabled || isGeneratedFunctionJson(function) {
+		logger.Debug("Skipping function json file: " + targetFile.Path)
+		return []data.EndpointMetaData{}, []Diagnostic{}
+	}
+
+	var folder = utils.Dir(targetFile.Path)
+	var endpoint = data.EndpointMetaData{Name: utils.Base(folder), FilePath: folder}
+	for _, binding := range function.Bindings {
+		var trigger, isTrigger = bindingTriggerType(binding)
+		if !isTrigger {
+			continue // input and output bindings
+		}
+		endpoint.TriggerType = trigger
+
+		switch trigger {
+		case data.TriggerType["Http"]:
+			for _, method := range binding.Methods {
+				endpoint.Methods = append(endpoint.Methods, strings.ToLower(method))
+			}
+			endpoint.Route = endpoint.Name
+			if binding.Route != nil && len(*binding.Route) > 0 {
+				endpoint.Route = utils.NormalizeRouteTemplate(*binding.Route)
+			}
+			endpoint.PathParameters = utils.ExtractPathVars(endpoint.Route)
+
+			// the authLevel defaults to function when missing
+			var authLevel = strings.ToLower(binding.AuthLevel)
+			if len(authLevel) < 1 {
+				authLevel = "function"
+			}
+			if auth, exists := functionKeyAuthentication[authLevel]; exists {
+				endpoint.Authentication = append(endpoint.Authentication, auth)
+			}
+		case data.TriggerType["Timer"]:
+			endpoint.Interval = binding.Schedule
+		default:
+			var triggerBinding = &data.TriggerBinding{}
+			for name, value := range binding.Properties {
+				if text, isString := value.(string); isString {
+					setBindingProperty(triggerBinding, name, text)
+				}
+			}
+			endpoint.Binding = bindingOrNil(triggerBinding)
+		}
+	}
+
+	if len(endpoint.TriggerType) < 1 {
+		return []data.EndpointMetaData{}, []Diagnostic{newDiagnostic(SeverityWarning, targetFile.Path, "No trigger binding found in function json file")}
+	}
+	endpoint.Id = endpoint.GenerateId()
+	return []data.EndpointMetaData{endpoint}, []Diagnostic{}
+}
+
+type FunctionJsonParser struct{}
+
+func (f FunctionJsonParser) Name() string {
+	return "functionjson"
+}
+
+func (f FunctionJsonParser) Extensions() []string {
+	return []string{"function.json"}
+}
+
+func (f FunctionJsonParser) Parse(files []data.FileMetaData, logger *logrus.Logger) ([]data.EndpointMetaData, []Diagnostic) {
+	return parseEach(files, logger, parseFunctionJson)
+}
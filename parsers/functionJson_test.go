@@ -0,0 +1,73 @@
+package parsers
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"testing"
+)
+
+func Test_parseFunctionJson_ReturnsScriptFunctionTriggers(t *testing.T) {
+	var tests = []struct {
+		folder         string
+		triggerType    string
+		route          string
+		methods        []string
+		authentication []string
+		interval       string
+	}{
+		{"GetItems", data.TriggerType["Http"], "items/{id}", []string{"get", "post"}, []string{}, ""},
+		{"ProcessOrder", data.TriggerType["Http"], "ProcessOrder", []string{}, []string{"FunctionKey"}, ""},
+		{"Cleanup", data.TriggerType["Timer"], "", []string{}, []string{}, "0 */5 * * * *"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.folder, func(t *testing.T) {
+			// Arrange
+			var folder = "../test_assets/script_functions/" + test.folder
+			var testFile = data.FileMetaData{Name: "function.json", Path: folder + "/function.json"}
+
+			// Act
+			var endpoints, _ = parseFunctionJson(testFile, testLogger)
+
+			// Assert
+			if !utils.AssertEqual(t, 1, len(endpoints)) {
+				return
+			}
+			var endpoint = endpoints[0]
+			utils.AssertStringEqual(t, test.folder, endpoint.Name)
+			utils.AssertStringEqual(t, folder, endpoint.FilePath)
+			utils.AssertStringEqual(t, test.triggerType, endpoint.TriggerType)
+			utils.AssertStringEqual(t, test.route, endpoint.Route)
+			utils.AssertSliceEqual(t, test.methods, endpoint.Methods)
+			utils.AssertSliceEqual(t, test.authentication, endpoint.Authentication)
+			utils.AssertStringEqual(t, test.interval, endpoint.Interval)
+		})
+	}
+}
+
+func Test_parseFunctionJson_SkipsGeneratedFunctionJson(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{Name: "function.json", Path: "../test_assets/script_functions/CompiledItems/function.json"}
+
+	// Act
+	var endpoints, _ = parseFunctionJson(testFile, testLogger)
+
+	// Assert
+	utils.AssertEqual(t, 0, len(endpoints))
+}
+
+func Test_parseFunctionJson_ReturnsTriggerBinding(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{Name: "function.json", Path: "../test_assets/script_functions/ProcessInvoice/function.json"}
+
+	// Act
+	var endpoints, _ = parseFunctionJson(testFile, testLogger)
+
+	// Assert
+	utils.AssertEqual(t, 1, len(endpoints))
+	utils.AssertStringEqual(t, data.TriggerType["ServiceBus"], endpoints[0].TriggerType)
+	utils.AssertStringEqual(t, "invoices", endpoints[0].Binding.TopicName)
+	utils.AssertStringEqual(t, "archive", endpoints[0].Binding.SubscriptionName)
+	utils.AssertStringEqual(t, "ServiceBusConnection", endpoints[0].Binding.Connection)
+	utils.AssertStringEqual(t, "", endpoints[0].Binding.QueueName)
+}
@@ -0,0 +1,355 @@
+package parsers
+
+import (
+	"documentApi/csharp"
+	"documentApi/data"
+	"documentApi/utils"
+	"regexp"
+	"strings"
+)
+
+// the route builder methods that map a handler to an http method
+var mapMethods = map[string]string{
+	"MapGet": "get", "MapPost": "post", "MapPut": "put", "MapDelete": "delete", "MapPatch": "patch",
+}
+
+// interfaces (e.g. IItemRepository, ILogger<Program>) are resolved from the container, not the request
+var serviceInterfaceRegex = regexp.MustCompile(`^I[A-Z]\w*(<.*>)?$`)
+var serviceTypeSuffixes = []string{"Db", "DbContext", "Context", "Service", "Repository", "Client", "Factory", "Logger", "Options"}
+
+// the characters of a route that can't be part of an identifier, e.g. "/" and "{" in api/items/{id}
+var nonIdentifierRegex = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// routeConventions is the metadata added to a route group or endpoint through the builder methods,
+// e.g. .RequireAuthorization("Admin").WithTags("Items")
+type routeConventions struct {
+	authentication []string
+	anonymous      bool
+	tags           []string
+	name           string
+	summary        string
+	description    string
+	requestBody    *data.RequestBodyMetaData
+	responseCodes  []data.ResponseCode
+}
+
+// routeGroup is a group created with MapGroup, its prefix and conventions apply to every endpoint mapped on it
+type routeGroup struct {
+	prefix      string
+	conventions routeConventions
+}
+
+// isMinimalApiChain reports whether the chain maps an endpoint or a route group
+func isMinimalApiChain(chain csharp.Chain) bool {
+	for _, invocation := range chain.Invocations {
+		if _, isMap := mapMethods[invocation.Name]; isMap || invocation.Name == "MapMethods" || invocation.Name == "MapGroup" {
+			return true
+		}
+		// app.Map("/pattern", handler) maps all methods, other Map methods (e.g. automapper) don't take a pattern and handler
+		if invocation.Name == "Map" && len(invocation.Arguments) == 2 && len(invocation.TypeArguments) < 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// joinRoutes appends the pattern to the group prefix, unlike controller routes a leading slash is not absolute
+func joinRoutes(prefix string, pattern string) string {
+	var parts = []string{}
+	for _, part := range []string{prefix, pattern} {
+		if part = strings.Trim(part, "/"); len(part) > 0 {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// applyConvention applies a builder method to the conventions, returns false if it's not one that is documented
+func applyConvention(invocation csharp.Invocation, typeName string, constants *csharp.Constants, conventions *routeConventions) bool {
+	var firstArgument, hasArgument = csharp.Argument{}, len(invocation.Arguments) > 0
+	if hasArgument {
+		firstArgument = invocation.Arguments[0]
+	}
+
+	switch invocation.Name {
+	case "RequireAuthorization":
+		var policies = []string{}
+		for _, argument := range invocation.Arguments {
+			if isPolicyName(argument) {
+				policies = append(policies, expressionValue(argument, typeName, constants))
+			}
+		}
+		if len(policies) < 1 {
+			policies = append(policies, "Authorize") // the default policy or a policy built inline
+		}
+		conventions.authentication = append(append([]string{}, conventions.authentication...), policies...)
+	case "AllowAnonymous":
+		conventions.anonymous = true
+	case "WithTags":
+		var tags = append([]string{}, conventions.tags...)
+		for _, argument := range invocation.Arguments {
+			tags = append(tags, expressionValue(argument, typeName, constants))
+		}
+		conventions.tags = tags
+	case "WithName":
+		if hasArgument {
+			conventions.name = expressionValue(firstArgument, typeName, constants)
+		}
+	case "WithSummary":
+		if hasArgument {
+			conventions.summary = expressionValue(firstArgument, typeName, constants)
+		}
+	case "WithDescription":
+		if hasArgument {
+			conventions.description = expressionValue(firstArgument, typeName, constants)
+		}
+	case "Accepts":
+		var requestBody = &data.RequestBodyMetaData{TypeName: invocation.TypeArguments, ContentType: "application/json", Required: true}
+		if hasArgument {
+			requestBody.ContentType = expressionValue(firstArgument, typeName, constants)
+		}
+		conventions.requestBody = requestBody
+	case "Produces", "ProducesProblem", "ProducesValidationProblem":
+		var responseCode = data.ResponseCode{StatusCode: 200, TypeName: invocation.TypeArguments}
+		switch invocation.Name {
+		case "ProducesProblem":
+			responseCode.StatusCode = 500
+			responseCode.ContentType = "application/problem+json"
+		case "ProducesValidationProblem":
+			responseCode.StatusCode = 400
+			responseCode.ContentType = "application/problem+json"
+		}
+		if statusCode, exists := argumentAt(invocation.Arguments, 0, "statusCode"); exists {
+			if code, isCode := statusCodeValue(statusCode); isCode {
+				responseCode.StatusCode = code
+			}
+		}
+		if contentType, exists := argumentAt(invocation.Arguments, 1, "contentType"); exists {
+			responseCode.ContentType = expressionValue(contentType, typeName, constants)
+		} else if len(responseCode.TypeName) > 0 && len(responseCode.ContentType) < 1 {
+			responseCode.ContentType = "application/json"
+		}
+		conventions.responseCodes = append(append([]data.ResponseCode{}, conventions.responseCodes...), responseCode)
+	default:
+		return false
+	}
+	return true
+}
+
+// isPolicyName reports whether the RequireAuthorization argument is a policy name (a string or a constant),
+// rather than a policy built inline, e.g. policy => policy.RequireRole("Admin")
+func isPolicyName(argument csharp.Argument) bool {
+	for _, token := range argument.Tokens {
+		if token.Kind != csharp.String && token.Kind != csharp.Identifier && !token.Is(".") {
+			return false
+		}
+	}
+	return len(argument.Tokens) > 0 && !argument.Tokens[0].Is("new")
+}
+
+// isServiceType reports whether the parameter type looks like a service injected from the container,
+// minimal apis bind these without a [FromServices] attribute
+func isServiceType(typeName string) bool {
+	if serviceInterfaceRegex.MatchString(typeName) {
+		return true
+	}
+	for _, suffix := range serviceTypeSuffixes {
+		if strings.HasSuffix(typeName, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// argumentAt returns an invocation argument by its position or name
+func argumentAt(arguments []csharp.Argument, position int, name string) (csharp.Argument, bool) {
+	for _, argument := range arguments {
+		if argument.Name == name {
+			return argument, true
+		}
+	}
+	if position < len(arguments) && len(arguments[position].Name) < 1 {
+		return arguments[position], true
+	}
+	return csharp.Argument{}, false
+}
+
+// enclosingMethod returns the method whose body contains the offset, false for top level statements
+func enclosingMethod(file csharp.File, offset int) (csharp.Method, bool) {
+	for _, method := range file.Methods {
+		if len(method.Body) > 0 && method.Body[0].Offset <= offset && offset <= method.Body[len(method.Body)-1].Offset {
+			return method, true
+		}
+	}
+	return csharp.Method{}, false
+}
+
+// minimalApiHandler finds the name, parameters and attributes of the handler, either a lambda or a method group
+func minimalApiHandler(src string, file csharp.File, handler csharp.Argument) (string, csharp.Method) {
+	if lambda, isLambda := csharp.ParseLambda(src, handler); isLambda {
+		return "", csharp.Method{Parameters: lambda.Parameters, Attributes: lambda.Attributes, Body: lambda.Body}
+	}
+
+	// a method group, e.g. GetItems or ItemHandlers.GetItems
+	if len(handler.Tokens) < 1 || handler.Tokens[len(handler.Tokens)-1].Kind != csharp.Identifier {
+		return "", csharp.Method{}
+	}
+	var name = handler.Tokens[len(handler.Tokens)-1].Text
+	for _, method := range file.Methods {
+		if method.Name == name {
+			return name, method
+		}
+	}
+	return name, csharp.Method{}
+}
+
+// requestParameters drops the handler parameters that are not bound from the request: untyped lambda parameters
+// (their type can't be known) and injected services
+func requestParameters(handler csharp.Method) csharp.Method {
+	var parameters = []csharp.Parameter{}
+	for _, parameter := range handler.Parameters {
+		if len(parameter.Type) < 1 {
+			continue
+		}
+		if _, hasBinding := parameter.Attribute("FromBody", "FromForm", "FromQuery", "FromHeader", "FromRoute"); !hasBinding && isServiceType(shortTypeName(parameter.Type)) {
+			continue
+		}
+		parameters = append(parameters, parameter)
+	}
+	handler.Parameters = parameters
+	return handler
+}
+
+// parseMinimalApis collects the endpoints mapped with the minimal api route builder methods (app.MapGet etc.).
+// Groups assigned to a variable (var items = app.MapGroup("/items")) are tracked so their prefix and conventions
+// apply to the endpoints mapped on them
+func parseMinimalApis(src string, file csharp.File, constants *csharp.Constants, types *csharp.Types) []data.EndpointMetaData {
+	var endpoints = []data.EndpointMetaData{}
+	var groups = make(map[string]routeGroup)
+
+	for _, chain := range csharp.Chains(src) {
+		var group, onGroup = groups[chain.Root]
+		if !onGroup && !isMinimalApiChain(chain) {
+			continue
+		}
+
+		// top level statements end up in the Program class
+		var className, typeName = "Program", "Program"
+		if method, inMethod := enclosingMethod(file, chain.Offset); inMethod {
+			className, typeName = qualifiedTypeName(method.Namespace, method.TypeName), method.TypeName
+		}
+
+		var createsGroup = false
+		var mapName string
+		var endpoint *data.EndpointMetaData
+		var handlerMethod csharp.Method
+		var conventions = routeConventions{}
+		for _, invocation := range chain.Invocations {
+			if endpoint != nil {
+				applyConvention(invocation, typeName, constants, &conventions)
+				continue
+			}
+
+			var httpMethod, isMap = mapMethods[invocation.Name]
+			var methods = []string{httpMethod}
+			var handlerPosition = 1
+			switch {
+			case invocation.Name == "MapGroup":
+				createsGroup = true
+				if prefix, exists := argumentAt(invocation.Arguments, 0, "prefix"); exists {
+					group.prefix = joinRoutes(group.prefix, expressionValue(prefix, typeName, constants))
+				}
+				continue
+			case invocation.Name == "MapMethods":
+				methods = []string{}
+				if httpMethods, exists := argumentAt(invocation.Arguments, 1, "httpMethods"); exists {
+					for _, method := range stringListValue(httpMethods) {
+						methods = append(methods, strings.ToLower(method))
+					}
+				}
+				handlerPosition = 2
+				isMap = true
+			case invocation.Name == "Map" && len(invocation.Arguments) == 2:
+				methods = nil // any method
+				isMap = true
+			}
+			if !isMap {
+				applyConvention(invocation, typeName, constants, &group.conventions)
+				continue
+			}
+
+			mapName = invocation.Name
+			var pattern, _ = argumentAt(invocation.Arguments, 0, "pattern")
+			var handler, _ = argumentAt(invocation.Arguments, handlerPosition, "handler")
+			var route = utils.NormalizeRouteTemplate(joinRoutes(group.prefix, expressionValue(pattern, typeName, constants)))
+			endpoint = &data.EndpointMetaData{
+				ClassName:      className,
+				Route:          route,
+				Methods:        methods,
+				PathParameters: utils.ExtractPathVars(route),
+				TriggerType:    data.TriggerType["Http"],
+			}
+
+			var handlerName string
+			handlerName, handlerMethod = minimalApiHandler(src, file, handler)
+			endpoint.Name = handlerName
+			if !controllerAuthentication(handlerMethod.Attributes, endpoint) {
+				conventions.anonymous = true
+			}
+			for _, attribute := range handlerMethod.Attributes {
+				searchOpenApi(attribute.Text, endpoint)
+			}
+			parseActionParameters(requestParameters(handlerMethod), endpoint)
+			searchBodyParameters(handlerMethod.Body, handlerMethod.TypeName, constants, endpoint)
+		}
+
+		if endpoint == nil {
+			// a route group, keep track of it for the endpoints mapped on it later on.
+			// Conventions added to an existing group (items.RequireAuthorization();) update it
+			if len(chain.Assignee) > 0 {
+				groups[chain.Assignee] = group
+			} else if onGroup && !createsGroup {
+				groups[chain.Root] = group
+			}
+			continue
+		}
+
+		if len(conventions.name) > 0 {
+			endpoint.Name = conventions.name
+		}
+		var unnamed = len(endpoint.Name) < 1
+		if unnamed {
+			// a lambda without a name, fall back to the map method and route, e.g. MapGet_api_items_id
+			endpoint.Name = strings.Trim(mapName+"_"+nonIdentifierRegex.ReplaceAllString(endpoint.Route, "_"), "_")
+		}
+		endpoint.Authentication = append(append(endpoint.Authentication, group.conventions.authentication...), conventions.authentication...)
+		if group.conventions.anonymous || conventions.anonymous {
+			endpoint.Authentication = nil
+		}
+		endpoint.Tags = append(append(endpoint.Tags, group.conventions.tags...), conventions.tags...)
+		if len(conventions.summary) > 0 {
+			endpoint.Summary = conventions.summary
+		}
+		if len(conventions.description) > 0 {
+			endpoint.Description = conventions.description
+		}
+		if unnamed && len(endpoint.Description) < 1 {
+			// describe lambdas by the method and route their name is made of, e.g. GET /api/items/{id}
+			var methods = strings.ToUpper(strings.Join(endpoint.Methods, ","))
+			if len(methods) < 1 {
+				methods = "ANY"
+			}
+			endpoint.Description = methods + " /" + endpoint.Route
+		}
+		if conventions.requestBody != nil {
+			endpoint.RequestBody = conventions.requestBody
+		}
+		endpoint.ResponseCodes = append(append(endpoint.ResponseCodes, group.conventions.responseCodes...), conventions.responseCodes...)
+		searchResponses(handlerMethod, file, constants, types, endpoint)
+		searchComments(handlerMethod, file.Comments, endpoint)
+
+		endpoints = append(endpoints, *endpoint)
+	}
+
+	return endpoints
+}
@@ -0,0 +1,133 @@
+package parsers
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// the extensions of the files that can register node (v4 programming model) functions
+var nodeExtensions = []string{".js", ".mjs", ".cjs", ".ts", ".mts", ".cts"}
+
+// app.http('name', { ... }), app.timer('name', { ... }) etc. on the app exported by @azure/functions
+var nodeRegistrationRegex = regexp.MustCompile(`(?:^|[^.\w$])app\.(http|get|post|put|patch|deleteRequest|timer|storageQueue|storageBlob|serviceBusQueue|serviceBusTopic|eventHub|eventGrid|cosmosDB)\s*\(`)
+
+// the trigger types of the app registration methods
+var nodeTriggerTypes = map[string]string{
+	"http": "Http", "get": "Http", "post": "Http", "put": "Http", "patch": "Http", "deleteRequest": "Http",
+	"timer": "Timer", "storageQueue": "Queue", "storageBlob": "Blob", "serviceBusQueue": "ServiceBus", "serviceBusTopic": "ServiceBus",
+	"eventHub": "EventHub", "eventGrid": "EventGrid", "cosmosDB": "CosmosDB",
+}
+
+// the http methods of the shorthand registration methods, e.g. app.get('name', handler)
+var nodeHttpMethods = map[string]string{
+	"get": "get", "post": "post", "put": "put", "patch": "patch", "deleteRequest": "delete",
+}
+
+func isNodeFile(name string) bool {
+	if strings.HasSuffix(name, ".d.ts") {
+		return false
+	}
+	for _, extension := range nodeExtensions {
+		if strings.HasSuffix(name, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNodeFunctions collects the functions registered with the node v4 programming model (@azure/functions),
+// e.g. app.http('getItems', { methods: ['GET'], route: 'items', authLevel: 'anonymous', handler: getItems })
+func parseNodeFunctions(targetFile data.FileMetaData, logger *logrus.Logger) ([]data.EndpointMetaData, []Diagnostic) {
+	if !isNodeFile(targetFile.Name) {
+		return []data.EndpointMetaData{}, []Diagnostic{} // type declarations
+	}
+
+	fileData, err := os.ReadFile(targetFile.Path)
+	if err != nil {
+		return []data.EndpointMetaData{}, []Diagnostic{newDiagnostic(SeverityError, targetFile.Path, "Error reading file: "+err.Error())}
+	}
+
+	var src = stripScriptComments(string(fileData), "//", true)
+	if !strings.Contains(src, "@azure/functions") {
+		return []data.EndpointMetaData{}, []Diagnostic{}
+	}
+
+	var endpoints = []data.EndpointMetaData{}
+	for _, match := range nodeRegistrationRegex.FindAllStringSubmatchIndex(src, -1) {
+		var method = src[match[2]:match[3]]
+		var open = match[1] - 1
+		var arguments = splitScriptArguments(src[open+1 : scriptClosing(src, open)])
+
+		var name, isName = "", len(arguments) > 1
+		if isName {
+			name, isName = scriptString(arguments[0].Value)
+		}
+		if !isName {
+			continue // not a function registration, those take a name and the options (or handler)
+		}
+
+		var endpoint = data.EndpointMetaData{Name: name, TriggerType: data.TriggerType[nodeTriggerTypes[method]]}
+		var options = []scriptArgument{}
+		if len(arguments) > 1 && strings.HasPrefix(arguments[1].Value, "{") {
+			var value = arguments[1].Value
+			options = splitScriptArguments(value[1 : len(value)-1])
+		}
+
+		switch endpoint.TriggerType {
+		case data.TriggerType["Http"]:
+			if httpMethod, isShorthand := nodeHttpMethods[method]; isShorthand {
+				endpoint.Methods = []string{httpMethod}
+			} else if methods, exists := scriptArgumentAt(options, -1, "methods"); exists {
+				for _, httpMethod := range scriptList(methods) {
+					endpoint.Methods = append(endpoint.Methods, strings.ToLower(httpMethod))
+				}
+			}
+
+			endpoint.Route = endpoint.Name
+			if route, exists := scriptArgumentAt(options, -1, "route"); exists {
+				if route, isString := scriptString(route); isString && len(route) > 0 {
+					endpoint.Route = utils.NormalizeRouteTemplate(route)
+				}
+			}
+			endpoint.PathParameters = utils.ExtractPathVars(endpoint.Route)
+
+			// the v4 model defaults to anonymous
+			if authLevel, exists := scriptArgumentAt(options, -1, "authLevel"); exists {
+				if auth, exists := functionKeyAuthentication[strings.ToLower(scriptEnumValue(authLevel))]; exists {
+					endpoint.Authentication = append(endpoint.Authentication, auth)
+				}
+			}
+		case data.TriggerType["Timer"]:
+			if schedule, exists := scriptArgumentAt(options, -1, "schedule"); exists {
+				endpoint.Interval = scriptEnumValue(schedule)
+			}
+		default:
+			endpoint.Binding = scriptTriggerBinding(options)
+		}
+
+		endpoint.FilePath = targetFile.Path
+		endpoint.Id = endpoint.GenerateId()
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return endpoints, []Diagnostic{}
+}
+
+type NodeParser struct{}
+
+func (p NodeParser) Name() string {
+	return "node"
+}
+
+func (p NodeParser) Extensions() []string {
+	return nodeExtensions
+}
+
+func (p NodeParser) Parse(files []data.FileMetaData, logger *logrus.Logger) ([]data.EndpointMetaData, []Diagnostic) {
+	return parseEach(files, logger, parseNodeFunctions)
+}
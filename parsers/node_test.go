@@ -0,0 +1,39 @@
+package parsers
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"testing"
+)
+
+func Test_parseNodeFunctions_ReturnsRegisteredFunctions(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{Name: "items.ts", Path: "../test_assets/node_functions/items.ts"}
+
+	// Act
+	var endpoints, _ = parseNodeFunctions(testFile, testLogger)
+
+	// Assert
+	var names = []string{}
+	for _, endpoint := range endpoints {
+		names = append(names, endpoint.Name)
+	}
+	utils.AssertSliceEqual(t, []string{"getItems", "updateItem", "deleteItem", "cleanupItems", "processItem"}, names)
+
+	utils.AssertStringEqual(t, "items", endpoints[0].Route)
+	utils.AssertSliceEqual(t, []string{"get"}, endpoints[0].Methods)
+	utils.AssertEqual(t, 0, len(endpoints[0].Authentication))
+
+	utils.AssertStringEqual(t, "items/{id}", endpoints[1].Route)
+	utils.AssertSliceEqual(t, []string{"put", "patch"}, endpoints[1].Methods)
+	utils.AssertSliceEqual(t, []string{"FunctionKey"}, endpoints[1].Authentication)
+	utils.AssertMapContains(t, endpoints[1].PathParameters, "id")
+
+	utils.AssertSliceEqual(t, []string{"delete"}, endpoints[2].Methods)
+	utils.AssertStringEqual(t, data.TriggerType["Timer"], endpoints[3].TriggerType)
+	utils.AssertStringEqual(t, "0 */5 * * * *", endpoints[3].Interval)
+	utils.AssertStringEqual(t, data.TriggerType["Queue"], endpoints[4].TriggerType)
+	utils.AssertStringEqual(t, "items", endpoints[4].Binding.QueueName)
+	utils.AssertStringEqual(t, "AzureWebJobsStorage", endpoints[4].Binding.Connection)
+	utils.AssertStringEqual(t, testFile.Path, endpoints[4].FilePath)
+}
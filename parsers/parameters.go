@@ -0,0 +1,65 @@
+package parsers
+
+import (
+	"documentApi/csharp"
+	"documentApi/data"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// the request members holding the query string, including the helpers that parse it into a collection
+var queryMembers = []string{"Query", "ParseQueryString", "ParseQuery", "GetQueryParameterDictionary"}
+
+var headerMembers = []string{"Headers"}
+
+// the methods that read a key of the query string or headers, the others (e.g. Add or Remove) don't read the request
+var lookupMethods = map[string]bool{
+	"Get": true, "GetValues": true, "TryGetValues": true, "TryGetValue": true, "GetValueOrDefault": true,
+	"Contains": true, "ContainsKey": true, "GetCommaSeparatedValues": true,
+}
+
+// searchBodyParameters records the query parameters and headers read in the handler body, e.g. req.Query["locale"]
+// or req.Headers.TryGetValues("X-SID", out var values). typeName is the class of the handler, used to resolve constant keys
+func searchBodyParameters(body []csharp.Token, typeName string, constants *csharp.Constants, endpoint *data.EndpointMetaData) {
+	for _, access := range csharp.KeyAccesses(body, append(queryMembers, headerMembers...)...) {
+		// writes to the response headers are not parameters
+		if access.IsWrite || len(access.Method) > 0 && !lookupMethods[access.Method] || strings.Contains(strings.ToLower(access.Receiver), "response") {
+			continue
+		}
+		var name, ok = constants.Evaluate(access.Key, typeName)
+		if !ok || len(name) < 1 {
+			continue
+		}
+		var in = "query"
+		if slices.Contains(headerMembers, access.Member) {
+			in = "header"
+		}
+		addParameter(endpoint, data.ParameterMetaData{Name: name, In: in, Type: "string", Source: "code"})
+	}
+
+	// the claims are read from the bearer token, unless the endpoint already documents how it is authenticated.
+	// Function keys are sent in their own header, they don't document the token
+	for _, auth := range endpoint.Authentication {
+		if !slices.Contains(slices.Collect(maps.Values(functionKeyAuthentication)), auth) {
+			return
+		}
+	}
+	for i := 0; i+1 < len(body); i++ {
+		if body[i].Is("GetClaimsPrincipal") && body[i+1].Is("(") {
+			addParameter(endpoint, data.ParameterMetaData{Name: "Authorization", In: "header", Type: "string", Source: "code"})
+			return
+		}
+	}
+}
+
+// addParameter adds the parameter unless it is already declared, names are compared case insensitively like the
+// query string and headers are. Declared parameters (e.g. with [OpenApiParameter]) are kept as they are
+func addParameter(endpoint *data.EndpointMetaData, parameter data.ParameterMetaData) {
+	for _, existing := range endpoint.Parameters {
+		if existing.In == parameter.In && strings.EqualFold(existing.Name, parameter.Name) {
+			return
+		}
+	}
+	endpoint.Parameters = append(endpoint.Parameters, parameter)
+}
@@ -0,0 +1,51 @@
+package parsers
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"strconv"
+	"testing"
+)
+
+func Test_Parse_InfersParametersFromHandlerBodies(t *testing.T) {
+	// Arrange
+	var files = []data.FileMetaData{{Name: "request_parameters.cs", Path: "../test_assets/request_parameters.cs"}}
+
+	// Act
+	var endpoints, _ = CSharpParser{}.Parse(files, testLogger)
+
+	// Assert
+	utils.AssertEqual(t, 2, len(endpoints))
+	var tests = []struct {
+		endpoint   int
+		parameters []string // name (in, source, required)
+	}{
+		// declared parameters are kept as they are, the ones only read in the body are optional
+		{0, []string{"locale (query, attribute, true)", "X-SID (header, attribute, false)", "q (query, code, false)", "page (query, code, false)", "X-Tenant-Id (header, code, false)", "Authorization (header, code, false)"}},
+		// no Authorization header for authenticated endpoints and no response headers
+		{1, []string{"format (query, code, false)"}},
+	}
+	for _, test := range tests {
+		t.Run(endpoints[test.endpoint].Name, func(t *testing.T) {
+			var parameters = []string{}
+			for _, parameter := range endpoints[test.endpoint].Parameters {
+				parameters = append(parameters, parameter.Name+" ("+parameter.In+", "+parameter.Source+", "+strconv.FormatBool(parameter.Required)+")")
+			}
+			utils.AssertSliceEqual(t, test.parameters, parameters)
+		})
+	}
+}
+
+func Test_addParameter_KeepsDeclaredParameters(t *testing.T) {
+	// Arrange
+	var endpoint = data.EndpointMetaData{Parameters: []data.ParameterMetaData{{Name: "X-SID", In: "header", Type: "int", Source: "attribute"}}}
+
+	// Act
+	addParameter(&endpoint, data.ParameterMetaData{Name: "x-sid", In: "header", Type: "string", Source: "code"})
+	addParameter(&endpoint, data.ParameterMetaData{Name: "x-sid", In: "query", Type: "string", Source: "code"})
+
+	// Assert
+	utils.AssertEqual(t, 2, len(endpoint.Parameters))
+	utils.AssertStringEqual(t, "int", endpoint.Parameters[0].Type)
+	utils.AssertStringEqual(t, "query", endpoint.Parameters[1].In)
+}
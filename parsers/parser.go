@@ -0,0 +1,57 @@
+package parsers
+
+import (
+	"documentApi/data"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Parser interface {
+	Name() string
+	// Extensions are the suffixes of the file names the parser reads, e.g. ".cs" or "function.json"
+	Extensions() []string
+	Parse(files []data.FileMetaData, logger *logrus.Logger) ([]data.EndpointMetaData, []Diagnostic)
+}
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Diagnostic is a problem found while parsing a file, the endpoints it declares may be missing or incomplete
+type Diagnostic struct {
+	Severity string `json:"severity"`
+	FilePath string `json:"filePath"`
+	Message  string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	return d.FilePath + ": " + d.Message
+}
+
+func newDiagnostic(severity string, filePath string, message string) Diagnostic {
+	return Diagnostic{Severity: severity, FilePath: filePath, Message: message}
+}
+
+// parseEach parses the files one by one, for the parsers that don't need to know about the other files
+func parseEach(files []data.FileMetaData, logger *logrus.Logger, parseFile func(data.FileMetaData, *logrus.Logger) ([]data.EndpointMetaData, []Diagnostic)) ([]data.EndpointMetaData, []Diagnostic) {
+	var endpoints = []data.EndpointMetaData{}
+	var diagnostics = []Diagnostic{}
+	for _, file := range files {
+		var fileEndpoints, fileDiagnostics = parseFile(file, logger)
+		endpoints = append(endpoints, fileEndpoints...)
+		diagnostics = append(diagnostics, fileDiagnostics...)
+	}
+	return endpoints, diagnostics
+}
+
+// Handles reports whether the parser reads the file
+func Handles(parser Parser, fileName string) bool {
+	for _, extension := range parser.Extensions() {
+		if strings.HasSuffix(fileName, extension) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package parsers
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// a decorator on an app or blueprint (@app.route(...), @bp.timer_trigger(...)) or the function it decorates
+var pythonDecoratorRegex = regexp.MustCompile(`(?m)^[ \t]*(?:@\w+\.(\w+)\s*\(|(?:async[ \t]+)?def[ \t]+(\w+)\s*\()`)
+
+// the auth level of the http functions in the app, e.g. func.FunctionApp(http_auth_level=func.AuthLevel.ANONYMOUS)
+var pythonAppRegex = regexp.MustCompile(`\b(?:FunctionApp|Blueprint)\s*\(`)
+
+// the trigger types of the python v2 trigger decorators
+var pythonTriggerTypes = map[string]string{
+	"route": "Http", "timer_trigger": "Timer", "schedule": "Timer", "queue_trigger": "Queue", "blob_trigger": "Blob",
+	"service_bus_queue_trigger": "ServiceBus", "service_bus_topic_trigger": "ServiceBus", "event_hub_message_trigger": "EventHub",
+	"event_grid_trigger": "EventGrid", "cosmos_db_trigger": "CosmosDB", "cosmos_db_trigger_v3": "CosmosDB",
+	"orchestration_trigger": "Orchestration", "activity_trigger": "Activity", "entity_trigger": "Entity",
+}
+
+// pythonFunction is a function decorated with a trigger, while its decorators are being read
+type pythonFunction struct {
+	name       string
+	trigger    string
+	arguments  []scriptArgument // of the trigger decorator
+	hasTrigger bool
+}
+
+// pythonAuthLevel returns the http_auth_level of the FunctionApp (or Blueprint), functions need a function key by default
+func pythonAuthLevel(src string) string {
+	if match := pythonAppRegex.FindStringIndex(src); match != nil {
+		var open = match[1] - 1
+		if authLevel, exists := scriptArgumentAt(splitScriptArguments(src[open+1:scriptClosing(src, open)]), -1, "http_auth_level"); exists {
+			return strings.ToLower(scriptEnumValue(authLevel))
+		}
+	}
+	return "function"
+}
+
+// parsePythonFunctions collects the functions declared with the python v2 programming model decorators,
+// e.g. @app.route(route="items/{id}", methods=["GET"]) or @app.timer_trigger(schedule="0 */5 * * * *", arg_name="timer")
+func parsePythonFunctions(targetFile data.FileMetaData, logger *logrus.Logger) ([]data.EndpointMetaData, []Diagnostic) {
+	fileData, err := os.ReadFile(targetFile.Path)
+	if err != nil {
+		return []data.EndpointMetaData{}, []Diagnostic{newDiagnostic(SeverityError, targetFile.Path, "Error reading file: "+err.Error())}
+	}
+
+	var src = stripScriptComments(string(fileData), "#", false)
+	if !strings.Contains(src, "azure.functions") {
+		return []data.EndpointMetaData{}, []Diagnostic{}
+	}
+	var appAuthLevel = pythonAuthLevel(src)
+
+	var endpoints = []data.EndpointMetaData{}
+	var function = pythonFunction{}
+	for _, match := range pythonDecoratorRegex.FindAllStringSubmatchIndex(src, -1) {
+		if match[4] > -1 {
+			// the decorated function, the decorators above it are complete
+			if function.hasTrigger {
+				if len(function.name) < 1 {
+					function.name = src[match[4]:match[5]]
+				}
+				endpoints = append(endpoints, pythonEndpoint(function, appAuthLevel, targetFile))
+			}
+			function = pythonFunction{}
+			continue
+		}
+
+		var decorator = src[match[2]:match[3]]
+		var open = match[1] - 1
+		var arguments = splitScriptArguments(src[open+1 : scriptClosing(src, open)])
+		if decorator == "function_name" {
+			if name, exists := scriptArgumentAt(arguments, 0, "name"); exists {
+				function.name = scriptEnumValue(name)
+			}
+		} else if _, isTrigger := pythonTriggerTypes[decorator]; isTrigger {
+			function.trigger, function.arguments, function.hasTrigger = decorator, arguments, true
+		}
+	}
+
+	return endpoints, []Diagnostic{}
+}
+
+func pythonEndpoint(function pythonFunction, appAuthLevel string, targetFile data.FileMetaData) data.EndpointMetaData {
+	var endpoint = data.EndpointMetaData{Name: function.name, TriggerType: data.TriggerType[pythonTriggerTypes[function.trigger]], FilePath: targetFile.Path}
+
+	switch endpoint.TriggerType {
+	case data.TriggerType["Http"]:
+		if methods, exists := scriptArgumentAt(function.arguments, -1, "methods"); exists {
+			for _, method := range scriptList(methods) {
+				endpoint.Methods = append(endpoint.Methods, strings.ToLower(method))
+			}
+		}
+
+		endpoint.Route = endpoint.Name
+		if route, exists := scriptArgumentAt(function.arguments, 0, "route"); exists {
+			if route, isString := scriptString(route); isString && len(route) > 0 {
+				endpoint.Route = utils.NormalizeRouteTemplate(route)
+			}
+		}
+		endpoint.PathParameters = utils.ExtractPathVars(endpoint.Route)
+
+		var authLevel = appAuthLevel
+		if level, exists := scriptArgumentAt(function.arguments, -1, "auth_level"); exists {
+			authLevel = strings.ToLower(scriptEnumValue(level))
+		}
+		if auth, exists := functionKeyAuthentication[authLevel]; exists {
+			endpoint.Authentication = append(endpoint.Authentication, auth)
+		}
+	case data.TriggerType["Timer"]:
+		if schedule, exists := scriptArgumentAt(function.arguments, 1, "schedule"); exists {
+			endpoint.Interval = scriptEnumValue(schedule)
+		}
+	default:
+		endpoint.Binding = scriptTriggerBinding(function.arguments)
+	}
+
+	endpoint.Id = endpoint.GenerateId()
+	return endpoint
+}
+
+type PythonParser struct{}
+
+func (p PythonParser) Name() string {
+	return "python"
+}
+
+func (p PythonParser) Extensions() []string {
+	return []string{".py"}
+}
+
+func (p PythonParser) Parse(files []data.FileMetaData, logger *logrus.Logger) ([]data.EndpointMetaData, []Diagnostic) {
+	return parseEach(files, logger, parsePythonFunctions)
+}
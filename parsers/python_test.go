@@ -0,0 +1,38 @@
+package parsers
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"testing"
+)
+
+func Test_parsePythonFunctions_ReturnsDecoratedFunctions(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{Name: "function_app.py", Path: "../test_assets/python_functions/function_app.py"}
+
+	// Act
+	var endpoints, _ = parsePythonFunctions(testFile, testLogger)
+
+	// Assert
+	var names = []string{}
+	for _, endpoint := range endpoints {
+		names = append(names, endpoint.Name)
+	}
+	utils.AssertSliceEqual(t, []string{"get_item", "CreateItem", "cleanup", "process_item", "health"}, names)
+
+	utils.AssertStringEqual(t, "items/{id}", endpoints[0].Route)
+	utils.AssertSliceEqual(t, []string{"get", "post"}, endpoints[0].Methods)
+	utils.AssertEqual(t, 0, len(endpoints[0].Authentication))
+
+	utils.AssertStringEqual(t, "items", endpoints[1].Route)
+	utils.AssertSliceEqual(t, []string{"post"}, endpoints[1].Methods)
+	utils.AssertSliceEqual(t, []string{"FunctionKey"}, endpoints[1].Authentication)
+
+	utils.AssertStringEqual(t, data.TriggerType["Timer"], endpoints[2].TriggerType)
+	utils.AssertStringEqual(t, "0 */5 * * * *", endpoints[2].Interval)
+	utils.AssertStringEqual(t, data.TriggerType["Queue"], endpoints[3].TriggerType)
+	utils.AssertStringEqual(t, "items", endpoints[3].Binding.QueueName)
+	utils.AssertStringEqual(t, "AzureWebJobsStorage", endpoints[3].Binding.Connection)
+	// without a route the function name is used
+	utils.AssertStringEqual(t, "health", endpoints[4].Route)
+}
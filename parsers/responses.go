@@ -0,0 +1,149 @@
+package parsers
+
+import (
+	"documentApi/csharp"
+	"documentApi/data"
+	"regexp"
+	"slices"
+)
+
+// the status codes of the methods that create a response, e.g. req.Ok(result), Results.NotFound() or BadRequest(error) in a controller
+var responseMethods = map[string]int{
+	"Ok": 200, "Created": 201, "CreatedAtAction": 201, "CreatedAtRoute": 201, "Accepted": 202, "AcceptedAtAction": 202, "NoContent": 204,
+	"BadRequest": 400, "ValidationProblem": 400, "Unauthorized": 401, "Forbidden": 403, "Forbid": 403, "NotFound": 404,
+	"Conflict": 409, "UnprocessableEntity": 422, "InternalServerError": 500,
+}
+
+// the status codes of the action results, e.g. new OkObjectResult(result)
+var responseTypes = map[string]int{
+	"OkResult": 200, "OkObjectResult": 200, "CreatedResult": 201, "CreatedAtActionResult": 201, "CreatedAtRouteResult": 201,
+	"AcceptedResult": 202, "NoContentResult": 204, "BadRequestResult": 400, "BadRequestObjectResult": 400,
+	"UnauthorizedResult": 401, "UnauthorizedObjectResult": 401, "ForbidResult": 403, "NotFoundResult": 404, "NotFoundObjectResult": 404,
+	"ConflictResult": 409, "ConflictObjectResult": 409, "UnprocessableEntityResult": 422, "UnprocessableEntityObjectResult": 422,
+}
+
+// error codes are identifiers, e.g. InvalidModule or Orders.NotFound, anything else is a message
+var errorCodeRegex = regexp.MustCompile(`^[A-Za-z][\w.-]*$`)
+
+// searchResponses records the status codes and named errors the handler responds with, following the calls to the
+// helper methods declared in the same class (including the other parts of a partial class, from the types index).
+// The response codes that are already declared are kept as they are
+func searchResponses(method csharp.Method, file csharp.File, constants *csharp.Constants, types *csharp.Types, endpoint *data.EndpointMetaData) {
+	searchMethodResponses(method, "", classMethods(method, file, types), constants, endpoint, map[string]bool{method.Name: true})
+}
+
+// classMethods returns the methods of the class the method is declared in, the ones declared in the file when the
+// class isn't indexed
+func classMethods(method csharp.Method, file csharp.File, types *csharp.Types) []csharp.Method {
+	if model, exists := types.Lookup(method.TypeName, method.Namespace); exists && model.Declaration.Namespace == method.Namespace {
+		return model.Methods
+	}
+	var methods = []csharp.Method{}
+	for _, candidate := range file.Methods {
+		if candidate.TypeName == method.TypeName && candidate.Namespace == method.Namespace {
+			methods = append(methods, candidate)
+		}
+	}
+	return methods
+}
+
+// searchMethodResponses searches the body of the handler or one of its helpers (from the methods of its class), visited
+// keeps track of the helpers that are already searched (including recursive calls)
+func searchMethodResponses(method csharp.Method, helper string, methods []csharp.Method, constants *csharp.Constants, endpoint *data.EndpointMetaData, visited map[string]bool) {
+	for _, call := range csharp.Calls(method.Body) {
+		if statusCode, arguments, isResponse := responseStatus(call); isResponse {
+			addResponseCode(endpoint, statusCode)
+			if errorCode, exists := errorCodeValue(arguments, method.TypeName, constants); exists && statusCode >= 400 {
+				errorCode.StatusCode, errorCode.Method = statusCode, helper
+				addErrorCode(endpoint, errorCode)
+			}
+			continue
+		}
+
+		// helpers are called unqualified (or on this), e.g. await PostAsync(req, moduleId)
+		if call.IsNew || len(call.Receiver) > 0 && call.Receiver != "this" || visited[call.Name] {
+			continue
+		}
+		visited[call.Name] = true
+		for _, candidate := range methods {
+			if candidate.Name == call.Name {
+				searchMethodResponses(candidate, candidate.Name, methods, constants, endpoint, visited)
+			}
+		}
+	}
+}
+
+// responseStatus returns the status code of a call creating a response or throwing an exception with a status code,
+// together with the arguments that follow the status code (the error code and message, if any)
+func responseStatus(call csharp.Call) (int, []csharp.Argument, bool) {
+	switch {
+	case call.IsThrown:
+		// e.g. throw new DocsApiException(HttpStatusCode.BadRequest, "MissingOid", "Oid is required for the user")
+		for i, argument := range call.Arguments {
+			var isStatusCode = argument.Name == "statusCode" || slices.ContainsFunc(argument.Tokens, func(token csharp.Token) bool { return token.Is("HttpStatusCode") })
+			if statusCode, exists := statusCodeValue(argument); exists && isStatusCode {
+				return statusCode, call.Arguments[i+1:], true
+			}
+		}
+	case call.IsNew:
+		if statusCode, exists := responseTypes[call.Name]; exists {
+			return statusCode, call.Arguments, true
+		}
+		if call.Name == "StatusCodeResult" && len(call.Arguments) > 0 {
+			var statusCode, exists = statusCodeValue(call.Arguments[0])
+			return statusCode, nil, exists
+		}
+	case call.Name == "CreateResponse":
+		// e.g. req.CreateResponse(HttpStatusCode.Created), the status code defaults to OK
+		if len(call.Arguments) < 1 {
+			return 200, nil, true
+		}
+		var statusCode, exists = statusCodeValue(call.Arguments[0])
+		return statusCode, nil, exists
+	case call.Name == "StatusCode" && len(call.Arguments) > 0:
+		var statusCode, exists = statusCodeValue(call.Arguments[0])
+		return statusCode, call.Arguments[1:], exists
+	default:
+		if statusCode, exists := responseMethods[call.Name]; exists {
+			return statusCode, call.Arguments, true
+		}
+	}
+	return 0, nil, false
+}
+
+// errorCodeValue returns the named error of the arguments of an error response, the error code followed by its message,
+// e.g. ("InvalidModule", "invalid module")
+func errorCodeValue(arguments []csharp.Argument, typeName string, constants *csharp.Constants) (data.ErrorCode, bool) {
+	if len(arguments) < 2 {
+		return data.ErrorCode{}, false
+	}
+	var code, ok = constants.Evaluate(arguments[0].Tokens, typeName)
+	if !ok || !errorCodeRegex.MatchString(code) {
+		return data.ErrorCode{}, false
+	}
+	var message, evaluated = constants.Evaluate(arguments[1].Tokens, typeName)
+	if !evaluated && len(arguments[1].Tokens) == 1 && arguments[1].Tokens[0].Kind == csharp.String {
+		message = stringValue(arguments[1]) // an interpolated message, e.g. $"Module {moduleId} is locked"
+	}
+	return data.ErrorCode{Code: code, Message: message}, true
+}
+
+// addResponseCode adds a response code inferred from the handler unless the status code is already declared
+func addResponseCode(endpoint *data.EndpointMetaData, statusCode int) {
+	for _, responseCode := range endpoint.ResponseCodes {
+		if responseCode.StatusCode == statusCode {
+			return
+		}
+	}
+	endpoint.ResponseCodes = append(endpoint.ResponseCodes, data.ResponseCode{StatusCode: statusCode, Source: "code"})
+}
+
+// addErrorCode adds the error code unless it is already added with the same status code, e.g. from another helper
+func addErrorCode(endpoint *data.EndpointMetaData, errorCode data.ErrorCode) {
+	for _, existing := range endpoint.ErrorCodes {
+		if existing.Code == errorCode.Code && existing.StatusCode == errorCode.StatusCode {
+			return
+		}
+	}
+	endpoint.ErrorCodes = append(endpoint.ErrorCodes, errorCode)
+}
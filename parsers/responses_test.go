@@ -0,0 +1,86 @@
+package parsers
+
+import (
+	"documentApi/data"
+	"documentApi/utils"
+	"strconv"
+	"testing"
+)
+
+func Test_Parse_InfersResponsesFromHandlersAndHelpers(t *testing.T) {
+	// Arrange
+	var files = []data.FileMetaData{{Name: "error_responses.cs", Path: "../test_assets/error_responses.cs"}}
+
+	// Act
+	var endpoints, _ = CSharpParser{}.Parse(files, testLogger)
+
+	// Assert
+	utils.AssertEqual(t, 2, len(endpoints))
+	var tests = []struct {
+		endpoint      int
+		responseCodes []string // statusCode (source)
+		errorCodes    []string // statusCode code (method) - message
+	}{
+		{0, []string{"200 ()", "400 (code)", "403 (code)", "404 (code)"}, []string{
+			"400 InvalidModule () - invalid module",
+			"400 MissingOid (GetOid) - Oid is required for the user",
+			"403 InvalidOid (ValidateOid) - Oid is not a guid",   // helpers of helpers, recursive calls are only searched once
+			"404 ModuleNotFound (FindModule) - Module not found", // called on this, the code is a constant
+		}},
+		{1, []string{"400 (code)", "403 (code)", "409 (code)", "204 (code)"}, []string{
+			"400 MissingOid (GetOid) - Oid is required for the user",
+			"403 InvalidOid (ValidateOid) - Oid is not a guid",
+			"409 ModuleLocked () - Module {moduleId} is locked",
+		}},
+	}
+	for _, test := range tests {
+		t.Run(endpoints[test.endpoint].Name, func(t *testing.T) {
+			var responseCodes = []string{}
+			for _, responseCode := range endpoints[test.endpoint].ResponseCodes {
+				responseCodes = append(responseCodes, strconv.Itoa(responseCode.StatusCode)+" ("+responseCode.Source+")")
+			}
+			var errorCodes = []string{}
+			for _, errorCode := range endpoints[test.endpoint].ErrorCodes {
+				errorCodes = append(errorCodes, strconv.Itoa(errorCode.StatusCode)+" "+errorCode.Code+" ("+errorCode.Method+") - "+errorCode.Message)
+			}
+			utils.AssertSliceEqual(t, test.responseCodes, responseCodes)
+			utils.AssertSliceEqual(t, test.errorCodes, errorCodes)
+		})
+	}
+}
+
+func Test_Parse_FollowsHelpersInOtherPartsOfPartialClasses(t *testing.T) {
+	// Arrange
+	var files = []data.FileMetaData{
+		{Name: "partial_responses.cs", Path: "../test_assets/partial_responses.cs"},
+		{Name: "partial_responses.Helpers.cs", Path: "../test_assets/partial_responses.Helpers.cs"},
+	}
+
+	// Act
+	var endpoints, _ = CSharpParser{}.Parse(files, testLogger)
+
+	// Assert
+	utils.AssertEqual(t, 1, len(endpoints))
+	utils.AssertEqual(t, 1, len(endpoints[0].ErrorCodes))
+	utils.AssertStringEqual(t, "ArchiveNotFound", endpoints[0].ErrorCodes[0].Code)
+	utils.AssertStringEqual(t, "FindArchive", endpoints[0].ErrorCodes[0].Method)
+	utils.AssertEqual(t, 2, len(endpoints[0].ResponseCodes))
+}
+
+func Test_parse_InfersResponsesOfControllerActions(t *testing.T) {
+	// Arrange
+	var testFile = data.FileMetaData{Name: "items_controller.cs", Path: "../test_assets/items_controller.cs"}
+
+	// Act
+	var endpoints, _ = parse(testFile, nil, nil, testLogger)
+
+	// Assert
+	var statusCodes = make(map[string][]int)
+	for _, endpoint := range endpoints {
+		for _, responseCode := range endpoint.ResponseCodes {
+			statusCodes[endpoint.Name] = append(statusCodes[endpoint.Name], responseCode.StatusCode)
+		}
+	}
+	utils.AssertEqual(t, 201, statusCodes["Items_Create"][0])
+	utils.AssertEqual(t, 204, statusCodes["Items_Delete"][0])
+}
@@ -0,0 +1,316 @@
+package parsers
+
+import (
+	"documentApi/csharp"
+	"documentApi/data"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// the json schema of the c# types that are serialized as a json primitive (or any json value)
+var primitiveSchemas = map[string]data.JsonSchema{
+	"string": {Type: data.SchemaType{"string"}}, "String": {Type: data.SchemaType{"string"}},
+	"char": {Type: data.SchemaType{"string"}}, "Char": {Type: data.SchemaType{"string"}},
+	"Guid":           {Type: data.SchemaType{"string"}, Format: "uuid"},
+	"DateTime":       {Type: data.SchemaType{"string"}, Format: "date-time"},
+	"DateTimeOffset": {Type: data.SchemaType{"string"}, Format: "date-time"},
+	"DateOnly":       {Type: data.SchemaType{"string"}, Format: "date"},
+	"TimeOnly":       {Type: data.SchemaType{"string"}, Format: "time"},
+	"TimeSpan":       {Type: data.SchemaType{"string"}},
+	"Uri":            {Type: data.SchemaType{"string"}, Format: "uri"},
+	"int":            {Type: data.SchemaType{"integer"}, Format: "int32"}, "Int32": {Type: data.SchemaType{"integer"}, Format: "int32"},
+	"short": {Type: data.SchemaType{"integer"}, Format: "int32"}, "Int16": {Type: data.SchemaType{"integer"}, Format: "int32"},
+	"ushort": {Type: data.SchemaType{"integer"}, Format: "int32"}, "UInt16": {Type: data.SchemaType{"integer"}, Format: "int32"},
+	"byte": {Type: data.SchemaType{"integer"}, Format: "int32"}, "Byte": {Type: data.SchemaType{"integer"}, Format: "int32"},
+	"sbyte": {Type: data.SchemaType{"integer"}, Format: "int32"}, "SByte": {Type: data.SchemaType{"integer"}, Format: "int32"},
+	"long": {Type: data.SchemaType{"integer"}, Format: "int64"}, "Int64": {Type: data.SchemaType{"integer"}, Format: "int64"},
+	"uint": {Type: data.SchemaType{"integer"}, Format: "int64"}, "UInt32": {Type: data.SchemaType{"integer"}, Format: "int64"},
+	"ulong": {Type: data.SchemaType{"integer"}, Format: "int64"}, "UInt64": {Type: data.SchemaType{"integer"}, Format: "int64"},
+	"float": {Type: data.SchemaType{"number"}, Format: "float"}, "Single": {Type: data.SchemaType{"number"}, Format: "float"},
+	"double": {Type: data.SchemaType{"number"}, Format: "double"}, "Double": {Type: data.SchemaType{"number"}, Format: "double"},
+	"decimal": {Type: data.SchemaType{"number"}, Format: "double"}, "Decimal": {Type: data.SchemaType{"number"}, Format: "double"},
+	"bool": {Type: data.SchemaType{"boolean"}}, "Boolean": {Type: data.SchemaType{"boolean"}},
+	"JObject": {Type: data.SchemaType{"object"}}, "JsonObject": {Type: data.SchemaType{"object"}}, "ExpandoObject": {Type: data.SchemaType{"object"}},
+	"JArray": {Type: data.SchemaType{"array"}}, "JsonArray": {Type: data.SchemaType{"array"}},
+	// any json value
+	"object": {}, "Object": {}, "dynamic": {}, "JToken": {}, "JsonElement": {}, "JsonNode": {}, "JsonDocument": {},
+}
+
+// the generic collections serialized as a json array of their type argument
+var collectionTypes = map[string]bool{
+	"List": true, "IList": true, "ICollection": true, "IEnumerable": true, "IReadOnlyList": true, "IReadOnlyCollection": true,
+	"HashSet": true, "ISet": true, "IReadOnlySet": true, "SortedSet": true, "Collection": true, "ReadOnlyCollection": true,
+	"ObservableCollection": true, "ImmutableArray": true, "ImmutableList": true, "IAsyncEnumerable": true, "LinkedList": true,
+	"Queue": true, "Stack": true, "ConcurrentBag": true,
+}
+
+// the generic dictionaries serialized as a json object with their values as properties
+var dictionaryTypes = map[string]bool{
+	"Dictionary": true, "IDictionary": true, "IReadOnlyDictionary": true, "ConcurrentDictionary": true,
+	"SortedDictionary": true, "ImmutableDictionary": true, "SortedList": true,
+}
+
+// the generic types serialized as their type argument
+var wrapperTypes = map[string]bool{
+	"Nullable": true, "Task": true, "ValueTask": true, "ActionResult": true,
+}
+
+// schemaBuilder builds the json schema of c# types from the models indexed across the repo
+type schemaBuilder struct {
+	types    *csharp.Types
+	building map[*csharp.Model]bool // guards against recursive models, e.g. a category with child categories
+}
+
+// typeSchema returns the json schema of the type referenced from code in the namespace.
+// Returns false if the type is not a known json type and isn't declared in the repo
+func typeSchema(typeName string, namespace string, types *csharp.Types) (*data.JsonSchema, bool) {
+	var builder = schemaBuilder{types: types, building: make(map[*csharp.Model]bool)}
+	return builder.schema(typeName, namespace, nil)
+}
+
+// schema builds the schema of the type, bindings are the schemas of the generic type parameters in scope
+func (b *schemaBuilder) schema(typeName string, namespace string, bindings map[string]*data.JsonSchema) (*data.JsonSchema, bool) {
+	var parsed = csharp.ParseTypeName(typeName)
+	var schema *data.JsonSchema
+	var resolved bool
+
+	var primitive, isPrimitive = primitiveSchemas[parsed.Name]
+	var binding, isBinding = bindings[parsed.Name]
+	switch {
+	case len(parsed.Element) > 0:
+		if element := csharp.ParseTypeName(parsed.Element); (element.Name == "byte" || element.Name == "Byte") && !element.Nullable {
+			// byte arrays are serialized as base64 strings
+			schema, resolved = &data.JsonSchema{Type: data.SchemaType{"string"}, Format: "byte"}, true
+			break
+		}
+		var items, _ = b.schema(parsed.Element, namespace, bindings)
+		schema, resolved = &data.JsonSchema{Type: data.SchemaType{"array"}, Items: items}, true
+	case isBinding && len(parsed.Qualifier) < 1 && len(parsed.Arguments) < 1:
+		var copied = *binding
+		schema, resolved = &copied, true
+	case wrapperTypes[parsed.Name] && len(parsed.Arguments) == 1:
+		schema, resolved = b.schema(parsed.Arguments[0], namespace, bindings)
+		parsed.Nullable = parsed.Nullable || parsed.Name == "Nullable"
+	case collectionTypes[parsed.Name] && len(parsed.Arguments) == 1:
+		var items, _ = b.schema(parsed.Arguments[0], namespace, bindings)
+		schema, resolved = &data.JsonSchema{Type: data.SchemaType{"array"}, Items: items}, true
+	case dictionaryTypes[parsed.Name] && len(parsed.Arguments) == 2:
+		var values, _ = b.schema(parsed.Arguments[1], namespace, bindings)
+		schema, resolved = &data.JsonSchema{Type: data.SchemaType{"object"}, AdditionalProperties: values}, true
+	case isPrimitive && len(parsed.Arguments) < 1:
+		schema, resolved = &primitive, true
+	default:
+		var qualifiedName = parsed.Name
+		if len(parsed.Qualifier) > 0 {
+			qualifiedName = parsed.Qualifier + "." + parsed.Name
+		}
+		if model, exists := b.types.Lookup(qualifiedName, namespace); exists {
+			var arguments = []*data.JsonSchema{}
+			for _, argument := range parsed.Arguments {
+				var argumentSchema, _ = b.schema(argument, namespace, bindings)
+				arguments = append(arguments, argumentSchema)
+			}
+			schema, resolved = b.modelSchema(model, strings.TrimSuffix(strings.TrimSpace(typeName), "?"), arguments), true
+		} else {
+			// unknown types (e.g. declared in a package) can be any json value
+			schema = &data.JsonSchema{Title: strings.TrimSuffix(strings.TrimSpace(typeName), "?")}
+		}
+	}
+
+	if parsed.Nullable && len(schema.Type) > 0 && !schema.Type.Is("null") {
+		schema.Type = append(append(data.SchemaType{}, schema.Type...), "null")
+	}
+	return schema, resolved
+}
+
+// modelSchema builds the schema of a class, struct, record, interface or enum declared in the repo
+func (b *schemaBuilder) modelSchema(model *csharp.Model, title string, arguments []*data.JsonSchema) *data.JsonSchema {
+	if model.Declaration.Kind == "enum" {
+		return enumSchema(model, title, hasStringEnumConverter(model.Declaration.Attributes))
+	}
+	if b.building[model] {
+		// a recursive reference, the properties are already described further up
+		return &data.JsonSchema{Title: title, Type: data.SchemaType{"object"}}
+	}
+	b.building[model] = true
+	defer delete(b.building, model)
+
+	var schema = &data.JsonSchema{Title: title, Type: data.SchemaType{"object"}, Properties: make(map[string]*data.JsonSchema)}
+	b.addProperties(schema, model, typeBindings(model, arguments))
+	return schema
+}
+
+// typeBindings maps the generic type parameters of the model to the schemas of the type arguments
+func typeBindings(model *csharp.Model, arguments []*data.JsonSchema) map[string]*data.JsonSchema {
+	var bindings = make(map[string]*data.JsonSchema)
+	for i, typeParameter := range model.Declaration.TypeParameters {
+		if i < len(arguments) && arguments[i] != nil {
+			bindings[typeParameter] = arguments[i]
+		} else {
+			bindings[typeParameter] = &data.JsonSchema{}
+		}
+	}
+	return bindings
+}
+
+// addProperties adds the serialized properties of the model to the schema, starting with the ones inherited from its base class
+func (b *schemaBuilder) addProperties(schema *data.JsonSchema, model *csharp.Model, bindings map[string]*data.JsonSchema) {
+	var declaration = model.Declaration
+	for _, baseType := range declaration.BaseTypes {
+		var parsed = csharp.ParseTypeName(baseType)
+		var qualifiedName = parsed.Name
+		if len(parsed.Qualifier) > 0 {
+			qualifiedName = parsed.Qualifier + "." + parsed.Name
+		}
+		var base, exists = b.types.Lookup(qualifiedName, declaration.Namespace)
+		// interfaces don't add any properties to the serialized class
+		if !exists || b.building[base] || base.Declaration.Kind == "interface" && declaration.Kind != "interface" {
+			continue
+		}
+		var arguments = []*data.JsonSchema{}
+		for _, argument := range parsed.Arguments {
+			var argumentSchema, _ = b.schema(argument, declaration.Namespace, bindings)
+			arguments = append(arguments, argumentSchema)
+		}
+		b.building[base] = true
+		b.addProperties(schema, base, typeBindings(base, arguments))
+		delete(b.building, base)
+	}
+
+	// the primary constructor parameters of records are its properties
+	if declaration.Kind == "record" {
+		for _, parameter := range declaration.Parameters {
+			var property = csharp.Field{Name: parameter.Name, Type: parameter.Type, Attributes: parameter.Attributes, IsProperty: true}
+			b.addProperty(schema, property, declaration.Namespace, bindings)
+		}
+	}
+
+	for _, member := range model.Members {
+		// fields are not serialized by System.Text.Json, interface members are implicitly public
+		if !member.IsProperty || member.Name == "this" || member.HasModifier("static") || !(member.HasModifier("public") || declaration.Kind == "interface") {
+			continue
+		}
+		b.addProperty(schema, member, declaration.Namespace, bindings)
+	}
+}
+
+// addProperty adds a property to the schema under its serialized name, unless it is ignored
+func (b *schemaBuilder) addProperty(schema *data.JsonSchema, property csharp.Field, namespace string, bindings map[string]*data.JsonSchema) {
+	if isJsonIgnored(property) {
+		return
+	}
+
+	var jsonName, required = jsonProperty(property)
+	var propertySchema, _ = b.schema(property.Type, namespace, bindings)
+	if hasStringEnumConverter(property.Attributes) {
+		var parsed = csharp.ParseTypeName(property.Type)
+		if model, exists := b.types.Lookup(parsed.Name, namespace); exists && model.Declaration.Kind == "enum" {
+			propertySchema = enumSchema(model, parsed.Name, true)
+			if parsed.Nullable {
+				propertySchema.Type = append(propertySchema.Type, "null")
+			}
+		}
+	}
+
+	schema.Properties[jsonName] = propertySchema
+	if required && !slices.Contains(schema.Required, jsonName) {
+		schema.Required = append(schema.Required, jsonName)
+	}
+}
+
+// isJsonIgnored reports whether the property is never serialized. Properties that are ignored only when they have
+// a (default) value are kept, e.g. Condition = JsonIgnoreCondition.WhenWritingNull
+func isJsonIgnored(property csharp.Field) bool {
+	var ignore, exists = property.Attribute("JsonIgnore")
+	if !exists {
+		return false
+	}
+	var condition, hasCondition = ignore.Argument(-1, "Condition")
+	return !hasCondition || enumValue(condition) == "Always"
+}
+
+// jsonProperty returns the name the property is serialized as and whether it is required
+func jsonProperty(property csharp.Field) (string, bool) {
+	var jsonName = camelCase(strings.TrimPrefix(property.Name, "@"))
+	var _, required = property.Attribute("Required", "JsonRequired")
+	required = required || property.HasModifier("required")
+	if attribute, exists := property.Attribute("JsonPropertyName"); exists {
+		if value, exists := attribute.Argument(0, "name"); exists {
+			jsonName = stringValue(value)
+		}
+	}
+	if attribute, exists := property.Attribute("JsonProperty"); exists {
+		if value, exists := attribute.Argument(0, "PropertyName"); exists {
+			jsonName = stringValue(value)
+		}
+		if value, exists := attribute.Argument(-1, "Required"); exists {
+			required = required || enumValue(value) == "Always" || enumValue(value) == "AllowNull"
+		}
+	}
+	return jsonName, required
+}
+
+// enumSchema describes an enum, which is serialized as its number unless it is converted to a string
+func enumSchema(model *csharp.Model, title string, asStrings bool) *data.JsonSchema {
+	if asStrings {
+		return &data.JsonSchema{Title: title, Type: data.SchemaType{"string"}, Enum: append([]string{}, model.Declaration.EnumMembers...)}
+	}
+	return &data.JsonSchema{Title: title, Type: data.SchemaType{"integer"}, Description: strings.Join(model.Declaration.EnumMembers, ", ")}
+}
+
+// hasStringEnumConverter reports whether the attributes convert an enum to its name, e.g. [JsonConverter(typeof(JsonStringEnumConverter))]
+func hasStringEnumConverter(attributes []csharp.Attribute) bool {
+	for _, attribute := range attributes {
+		if attribute.ShortName() != "JsonConverter" {
+			continue
+		}
+		var converterType, _ = attribute.Argument(0, "converterType")
+		return strings.HasSuffix(typeofValue(converterType), "StringEnumConverter")
+	}
+	return false
+}
+
+// camelCase converts a property name the way the System.Text.Json camel case naming policy does,
+// e.g. OrderId -> orderId, ID -> id and URLValue -> urlValue
+func camelCase(name string) string {
+	var runes = []rune(name)
+	for i := range runes {
+		if i == 1 && !unicode.IsUpper(runes[i]) {
+			break
+		}
+		if i > 0 && i+1 < len(runes) && !unicode.IsUpper(runes[i+1]) {
+			if runes[i+1] == ' ' {
+				runes[i] = unicode.ToLower(runes[i])
+			}
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// describeBodies resolves the schemas of the request and response bodies of the endpoints
+// from the c# types they reference, the types are looked up from the namespace of the endpoint's class
+func describeBodies(endpoints []data.EndpointMetaData, types *csharp.Types) {
+	for i := range endpoints {
+		var namespace = ""
+		if dot := strings.LastIndex(endpoints[i].ClassName, "."); dot > -1 {
+			namespace = endpoints[i].ClassName[:dot]
+		}
+
+		if requestBody := endpoints[i].RequestBody; requestBody != nil && len(requestBody.TypeName) > 0 {
+			if schema, resolved := typeSchema(requestBody.TypeName, namespace, types); resolved {
+				requestBody.Schema = schema
+			}
+		}
+		for j, responseCode := range endpoints[i].ResponseCodes {
+			if len(responseCode.TypeName) < 1 {
+				continue
+			}
+			if schema, resolved := typeSchema(responseCode.TypeName, namespace, types); resolved {
+				endpoints[i].ResponseCodes[j].Schema = schema
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package parsers
+
+import (
+	"documentApi/csharp"
+	"documentApi/data"
+	"documentApi/utils"
+	"os"
+	"sort"
+	"testing"
+)
+
+// readTestTypes indexes the types declared in the test file
+func readTestTypes(filePath string) *csharp.Types {
+	fileData, _ := os.ReadFile(filePath)
+	var types = csharp.NewTypes()
+	types.Add(csharp.Parse(csharp.StripComments(string(fileData))))
+	return types
+}
+
+func propertyNames(schema *data.JsonSchema) []string {
+	var names = []string{}
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func Test_typeSchema_ReturnsModelProperties(t *testing.T) {
+	// Arrange
+	var types = readTestTypes("../test_assets/models_app/Models.cs")
+
+	// Act
+	schema, resolved := typeSchema("Order", "Repo.Orders", types)
+
+	// Assert
+	if !resolved {
+		t.Fatal("expected the Order schema to be resolved")
+	}
+	utils.AssertStringEqual(t, "Order", schema.Title)
+	// inherited, renamed and required properties, without the ignored, static and private members
+	utils.AssertSliceEqual(t, []string{"createdAt", "customerId", "discount", "id", "lines", "metadata", "order_number", "shippingAddress", "status", "tags"}, propertyNames(schema))
+	utils.AssertSliceEqual(t, []string{"customerId"}, schema.Required)
+	utils.AssertSliceEqual(t, []string{"number", "null"}, schema.Properties["discount"].Type)
+	utils.AssertStringEqual(t, "uuid", schema.Properties["id"].Format)
+	utils.AssertSliceEqual(t, []string{"Pending", "Shipped", "Cancelled"}, schema.Properties["status"].Enum)
+	utils.AssertSliceEqual(t, []string{"string"}, schema.Properties["metadata"].AdditionalProperties.Type)
+
+	var address = schema.Properties["shippingAddress"]
+	utils.AssertSliceEqual(t, []string{"object", "null"}, address.Type)
+	utils.AssertSliceEqual(t, []string{"city", "line1", "postalCode"}, propertyNames(address))
+	utils.AssertSliceEqual(t, []string{"line1"}, address.Required)
+
+	var line = schema.Properties["lines"].Items
+	utils.AssertStringEqual(t, "OrderLine", line.Title)
+	utils.AssertSliceEqual(t, []string{"quantity", "sku", "unitPrice"}, propertyNames(line))
+	utils.AssertSliceEqual(t, []string{"unitPrice"}, line.Required)
+}
+
+func Test_typeSchema_ResolvesGenericAndRecursiveTypes(t *testing.T) {
+	// Arrange
+	var types = readTestTypes("../test_assets/models_app/Models.cs")
+
+	// Act
+	paged, _ := typeSchema("PagedResult<Order>", "Repo.Orders", types)
+	categories, _ := typeSchema("List<Category>", "Repo.Orders", types)
+
+	// Assert
+	utils.AssertStringEqual(t, "PagedResult<Order>", paged.Title)
+	utils.AssertSliceEqual(t, []string{"array"}, paged.Properties["items"].Type)
+	utils.AssertStringEqual(t, "Order", paged.Properties["items"].Items.Title)
+	utils.AssertSliceEqual(t, []string{"string", "null"}, paged.Properties["continuationToken"].Type)
+
+	var category = categories.Items
+	utils.AssertSliceEqual(t, []string{"children", "name", "priority"}, propertyNames(category))
+	// the recursive reference stops at the type
+	utils.AssertStringEqual(t, "Category", category.Properties["children"].Items.Title)
+	utils.AssertEqual(t, 0, len(category.Properties["children"].Items.Properties))
+	// enums without a string converter are serialized as numbers
+	utils.AssertSliceEqual(t, []string{"integer"}, category.Properties["priority"].Type)
+}
+
+func Test_typeSchema_ReturnsPrimitiveSchemas(t *testing.T) {
+	var tests = []struct {
+		name         string
+		typeName     string
+		expectedType []string
+		format       string
+		resolved     bool
+	}{
+		{"string", "string", []string{"string"}, "", true},
+		{"nullable long", "long?", []string{"integer", "null"}, "int64", true},
+		{"Nullable<T>", "System.Nullable<DateTime>", []string{"string", "null"}, "date-time", true},
+		{"byte array", "byte[]", []string{"string"}, "byte", true},
+		{"array", "int[]", []string{"array"}, "", true},
+		{"dynamic body", "JObject", []string{"object"}, "", true},
+		{"unknown type", "TagRequest", nil, "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act
+			schema, resolved := typeSchema(test.typeName, "", csharp.NewTypes())
+
+			// Assert
+			if test.resolved != resolved {
+				t.Fatalf("expected resolved to be %v, got %v", test.resolved, resolved)
+			}
+			utils.AssertSliceEqual(t, test.expectedType, schema.Type)
+			utils.AssertStringEqual(t, test.format, schema.Format)
+		})
+	}
+}
+
+func Test_camelCase_ReturnsSystemTextJsonNames(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected string
+	}{
+		{"OrderId", "orderId"},
+		{"ID", "id"},
+		{"URLValue", "urlValue"},
+		{"orderId", "orderId"},
+		{"X", "x"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			utils.AssertStringEqual(t, test.expected, camelCase(test.name))
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package parsers
+
+import (
+	"documentApi/data"
+	"regexp"
+	"strings"
+)
+
+// shared helpers for the code first models of the script based function apps (node v4 and python v2)
+
+var scriptArgumentNameRegex = regexp.MustCompile(`^\s*(\w+)\s*(:|=[^=])`)
+
+// scriptArgument is an argument of a call (or a property of an object literal), Name is empty for positional arguments
+type scriptArgument struct {
+	Name  string
+	Value string
+}
+
+var scriptClosers = map[byte]byte{'(': ')', '[': ']', '{': '}'}
+
+// scriptStringEnd returns the index after the string literal starting at i, supports ', ", ` and python's triple quotes
+func scriptStringEnd(src string, i int) int {
+	var quote = src[i : i+1]
+	if strings.HasPrefix(src[i:], strings.Repeat(quote, 3)) && quote != "`" {
+		quote = strings.Repeat(quote, 3)
+	}
+	for k := i + len(quote); k < len(src); k++ {
+		if src[k] == '\\' {
+			k++
+			continue
+		}
+		if strings.HasPrefix(src[k:], quote) {
+			return k + len(quote)
+		}
+	}
+	return len(src)
+}
+
+// stripScriptComments replaces the comments with whitespace, keeping the offsets and line breaks of the source.
+// lineComment is the marker of a line comment (// or #), block comments (/* */) are only stripped when blockComments is set
+func stripScriptComments(src string, lineComment string, blockComments bool) string {
+	var out = []byte(src)
+	var blank = func(start int, end int) {
+		for k := start; k < end && k < len(out); k++ {
+			if out[k] != '\n' && out[k] != '\r' {
+				out[k] = ' '
+			}
+		}
+	}
+
+	for i := 0; i < len(src); i++ {
+		switch {
+		case src[i] == '\'' || src[i] == '"' || src[i] == '`':
+			i = scriptStringEnd(src, i) - 1
+		case strings.HasPrefix(src[i:], lineComment):
+			var end = strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			blank(i, i+end)
+			i += end
+		case blockComments && strings.HasPrefix(src[i:], "/*"):
+			var end = strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				end = len(src) - i
+			} else {
+				end += 4
+			}
+			blank(i, i+end)
+			i += end - 1
+		}
+	}
+	return string(out)
+}
+
+// scriptClosing returns the index of the bracket closing the one at open, or len(src) if it is not closed.
+// The source is expected to be stripped of comments
+func scriptClosing(src string, open int) int {
+	var stack = []byte{}
+	for i := open; i < len(src); i++ {
+		switch c := src[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			i = scriptStringEnd(src, i) - 1
+		case scriptClosers[c] != 0:
+			stack = append(stack, scriptClosers[c])
+		case len(stack) > 0 && c == stack[len(stack)-1]:
+			stack = stack[:len(stack)-1]
+			if len(stack) < 1 {
+				return i
+			}
+		}
+	}
+	return len(src)
+}
+
+// splitScriptArguments splits the arguments of a call (or the properties of an object literal), without the brackets,
+// into their names and values. Named arguments use = (python) and properties use : (javascript)
+func splitScriptArguments(src string) []scriptArgument {
+	var arguments = []scriptArgument{}
+	var add = func(text string) {
+		if len(strings.TrimSpace(text)) < 1 {
+			return
+		}
+		var argument = scriptArgument{Value: strings.TrimSpace(text)}
+		if match := scriptArgumentNameRegex.FindStringSubmatchIndex(text); match != nil {
+			argument.Name = text[match[2]:match[3]]
+			argument.Value = strings.TrimSpace(text[match[4]+1:])
+		}
+		arguments = append(arguments, argument)
+	}
+
+	var start = 0
+	for i := 0; i < len(src); i++ {
+		switch c := src[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			i = scriptStringEnd(src, i) - 1
+		case scriptClosers[c] != 0:
+			i = scriptClosing(src, i)
+		case c == ',':
+			add(src[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(src) {
+		add(src[start:])
+	}
+	return arguments
+}
+
+// scriptArgumentAt returns an argument by its name or position
+func scriptArgumentAt(arguments []scriptArgument, position int, name string) (string, bool) {
+	for _, argument := range arguments {
+		if argument.Name == name {
+			return argument.Value, true
+		}
+	}
+	if position > -1 && position < len(arguments) && len(arguments[position].Name) < 1 {
+		return arguments[position].Value, true
+	}
+	return "", false
+}
+
+// scriptString returns the content of a string literal (python string prefixes like r"" are dropped), false if it isn't one
+func scriptString(value string) (string, bool) {
+	value = strings.TrimLeft(strings.TrimSpace(value), "rRuU")
+	if len(value) < 2 || !strings.ContainsAny(value[:1], "'\"`") || scriptStringEnd(value, 0) != len(value) {
+		return "", false
+	}
+	var quote = 1
+	if len(value) >= 6 && strings.HasPrefix(value, strings.Repeat(value[:1], 3)) && value[:1] != "`" {
+		quote = 3
+	}
+	return value[quote : len(value)-quote], true
+}
+
+// scriptList returns the items of a list literal, string items are unquoted and anything else (e.g. func.HttpMethod.GET)
+// is reduced to its last member
+func scriptList(value string) []string {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 || value[0] != '[' {
+		return []string{}
+	}
+	var items = []string{}
+	for _, item := range splitScriptArguments(value[1 : len(value)-1]) {
+		items = append(items, scriptEnumValue(item.Value))
+	}
+	return items
+}
+
+// scriptEnumValue returns the content of a string literal, or the last member of anything else, e.g. func.AuthLevel.ANONYMOUS -> ANONYMOUS
+func scriptEnumValue(value string) string {
+	if text, isString := scriptString(value); isString {
+		return text
+	}
+	return value[strings.LastIndex(value, ".")+1:]
+}
+
+// scriptTriggerBinding collects the binding properties from the (string) options of a trigger, e.g. queueName: 'orders'
+func scriptTriggerBinding(arguments []scriptArgument) *data.TriggerBinding {
+	var binding = &data.TriggerBinding{}
+	for _, argument := range arguments {
+		if value, isString := scriptString(argument.Value); isString && len(argument.Name) > 0 {
+			setBindingProperty(binding, argument.Name, value)
+		}
+	}
+	return bindingOrNil(binding)
+}
@@ -0,0 +1,44 @@
+using Microsoft.Azure.Functions.Worker;
+using Microsoft.DurableTask;
+
+namespace Repo.Functions
+{
+    public class BackgroundTriggers
+    {
+        private const string OrdersQueue = "orders";
+
+        [Function("ProcessOrder")]
+        public void ProcessOrder([QueueTrigger(OrdersQueue, Connection = "StorageConnection")] string message) { }
+
+        [Function("ProcessInvoice")]
+        public void ProcessInvoice([ServiceBusTrigger("invoices", Connection = "ServiceBusConnection", IsSessionsEnabled = true)] string message) { }
+
+        [Function("ProcessNotification")]
+        public void ProcessNotification([ServiceBusTrigger("notifications", "email", Connection = "ServiceBusConnection")] string message) { }
+
+        [Function("ResizeImage")]
+        public void ResizeImage([BlobTrigger("images/{name}", Connection = "StorageConnection")] Stream image, string name) { }
+
+        [Function("ReadTelemetry")]
+        public void ReadTelemetry([EventHubTrigger("telemetry", Connection = "EventHubConnection", ConsumerGroup = "docs")] string[] events) { }
+
+        [Function("SyncItems")]
+        public void SyncItems([CosmosDBTrigger(
+            databaseName: "catalog",
+            containerName: "items",
+            Connection = "CosmosConnection",
+            LeaseContainerName = "leases")] IReadOnlyList<Item> items) { }
+
+        [Function("OnEvent")]
+        public void OnEvent([EventGridTrigger] EventGridEvent gridEvent) { }
+
+        [Function(nameof(RunOrchestrator))]
+        public async Task RunOrchestrator([OrchestrationTrigger] TaskOrchestrationContext context) { }
+
+        [Function("SayHello")]
+        public string SayHello([ActivityTrigger] string name) => name;
+
+        [Function("Counter")]
+        public Task Counter([EntityTrigger(EntityName = "counter")] TaskEntityDispatcher dispatcher) => Task.CompletedTask;
+    }
+}
@@ -0,0 +1,54 @@
+using System.Net;
+
+namespace Repo.Functions
+{
+    public class CommentedTriggers(ILogger<CommentedTriggers> logger)
+    {
+        // https://learn.microsoft.com/azure/azure-functions
+        [Function("LiveEndpoint")]
+        [OpenApiOperation(tags: ["Repo"], Summary = "See https://contoso.com/* for details")]
+        public async Task<HttpResponseData> LiveEndpoint([HttpTrigger(AuthorizationLevel.Anonymous, "get", Route = "live")] HttpRequestData req)
+        {
+            var divider = "//";
+            var quote = '"';
+            return req.Ok(divider);
+        }
+
+        // [Function("LineCommentedEndpoint")]
+        // public async Task<HttpResponseData> LineCommentedEndpoint([HttpTrigger(AuthorizationLevel.Anonymous, "get", Route = "line")] HttpRequestData req)
+        // {
+        //     return req.Ok();
+        // }
+
+        /*
+        [Function("BlockCommentedEndpoint")]
+        public async Task<HttpResponseData> BlockCommentedEndpoint([HttpTrigger(AuthorizationLevel.Anonymous, "get", Route = "block")] HttpRequestData req)
+        {
+            return req.Ok();
+        }
+        */
+
+#if false
+        [Function("DisabledEndpoint")]
+        public async Task<HttpResponseData> DisabledEndpoint([HttpTrigger(AuthorizationLevel.Anonymous, "get", Route = "disabled")] HttpRequestData req)
+        {
+            return req.Ok();
+        }
+#endif
+
+#if DEBUG
+        [Function("DebugEndpoint")]
+        public async Task<HttpResponseData> DebugEndpoint([HttpTrigger(AuthorizationLevel.Anonymous, "get", Route = "debug")] HttpRequestData req)
+        {
+            return req.Ok();
+        }
+#else
+        [Function("ReleaseEndpoint")]
+        [RequireDocsToken]
+        public async Task<HttpResponseData> ReleaseEndpoint([HttpTrigger(AuthorizationLevel.Anonymous, "post", Route = "release")] HttpRequestData req)
+        {
+            return req.Ok();
+        }
+#endif
+    }
+}
@@ -0,0 +1,52 @@
+using System.Net;
+
+namespace Repo.Functions
+{
+    public sealed class ComplexTriggers(ILogger<ComplexTriggers> logger) : TriggerBase<ComplexTriggers>, IDisposable
+    {
+        private const string Separator = "]";
+
+        [Function(nameof(GetGeneric))]
+        [OpenApiOperation(tags: new[] { "Generic" }, Summary = "Returns (a) [list] of \"things\"")]
+        public async Task<ActionResult<IEnumerable<Dictionary<string, List<int>>>>> GetGeneric(
+            [HttpTrigger(AuthorizationLevel.Function, "get", "post", Route = "generic/{id}")] HttpRequestData req,
+            string id)
+        {
+            var closing = ")]";
+            var ch = ')';
+            return await Task.FromResult(new List<string> { closing });
+        }
+
+        [Function(
+            "MultiLineAttributes"
+        )]
+        [RequireDocsToken(
+            Read)]
+        [OpenApiParameter(
+            "filter)]",
+            In = ParameterLocation.Query,
+            Type = typeof(string))]
+        public Task<IActionResult<Foo>> MultiLineAttributes(
+            [HttpTrigger(
+                AuthorizationLevel.Anonymous,
+                "get",
+                Route = "multi/line")] HttpRequestData req) => Task.FromResult(Ok());
+
+        [Function("RawStrings")]
+        [OpenApiOperation(Summary = @"C:\temp\""file""")]
+        public async Task<HttpResponseData> RawStrings([HttpTrigger(AuthorizationLevel.Anonymous, "get", Route = """raw/{name}""")] HttpRequestData req, string name)
+        {
+            var json = """
+                { "a": ")]" }
+                """;
+            return req.Ok(json);
+        }
+
+        public (int Count, string Name) Helper(int count) => (count, "name");
+
+        [Function("Cleanup")]
+        public void Cleanup([TimerTrigger("0 */5 * * * *", RunOnStartup = false)] TimerInfo timer)
+        {
+        }
+    }
+}
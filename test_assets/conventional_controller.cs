@@ -0,0 +1,23 @@
+using Microsoft.AspNetCore.Mvc;
+
+namespace Repo.Web.Controllers
+{
+    public class LegacyController : Controller
+    {
+        [HttpGet]
+        public IActionResult Index() => View();
+
+        [HttpPost]
+        [ActionName("Save")]
+        public async Task<IActionResult> SaveAsync(SaveRequest request) => RedirectToAction(nameof(Index));
+
+        public IActionResult Details(int id) => View();
+    }
+
+    [Area("Admin")]
+    public class UsersController : Controller
+    {
+        [HttpGet]
+        public IActionResult List() => View();
+    }
+}
@@ -0,0 +1,48 @@
+using System.Net;
+using Microsoft.AspNetCore.Mvc;
+using Microsoft.Azure.Functions.Worker;
+using Microsoft.Azure.Functions.Worker.Http;
+
+namespace Repo.Functions
+{
+    public class ReportFunctions
+    {
+        // GET /api/v2/reports/{reportId}
+        /// <summary>
+        /// Gets the <see cref="Models.Report"/> of a module.
+        /// </summary>
+        /// <remarks>
+        /// Reports are cached for an hour.<para>Use <paramref name="rebuild"/> to rebuild it.</para>
+        /// </remarks>
+        /// <param name="reportId">The id of the report</param>
+        /// <param name="rebuild">Rebuilds the report when true</param>
+        /// <returns>The report</returns>
+        [Function("GetReport")]
+        public IActionResult GetReport([HttpTrigger(AuthorizationLevel.Function, "get", Route = "v2/reports/{reportId}")] HttpRequest req, string reportId, [FromQuery(Name = "refresh")] bool? rebuild)
+        {
+            var refresh = req.Query["refresh"];
+            return new OkObjectResult(reportId);
+        }
+
+        // TODO: paginate the reports
+        // [Function("ListReportsV1")]
+        /// <summary>Lists the reports</summary>
+        [Function("ListReports")]
+        [OpenApiOperation(operationId: "ListReports", Summary = "List reports", Description = "Lists the reports | all of them")]
+        public async Task<HttpResponseData> ListReports([HttpTrigger(AuthorizationLevel.Function, "get", Route = "reports")] HttpRequestData req)
+        {
+            var page = req.Query["page"];
+            return req.CreateResponse(HttpStatusCode.OK);
+        }
+
+        /// <summary>Creates a report</summary>
+        /// <param name="request">The modules to report on</param>
+        [Function("CreateReport")]
+        [OpenApiOperation(operationId: "CreateReport", Summary = "Create a report")]
+        [OpenApiRequestBody("application/json", typeof(CreateReportRequest))]
+        public async Task<HttpResponseData> CreateReport([HttpTrigger(AuthorizationLevel.Function, "post", Route = "reports")] HttpRequestData req, [FromBody] CreateReportRequest request)
+        {
+            return req.CreateResponse(HttpStatusCode.Created);
+        }
+    }
+}
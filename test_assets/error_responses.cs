@@ -0,0 +1,70 @@
+using System.Net;
+using System.Security.Claims;
+using Microsoft.Azure.Functions.Worker;
+using Microsoft.Azure.Functions.Worker.Http;
+
+namespace Repo.Functions
+{
+    public static class ErrorCodes
+    {
+        public const string ModuleNotFound = "ModuleNotFound";
+    }
+
+    public class ModuleFunctions(ILogger<ModuleFunctions> logger, IModuleStore store)
+    {
+        [Function("GetModule")]
+        [OpenApiResponseWithBody(statusCode: HttpStatusCode.OK, "application/json", typeof(Module))]
+        public async Task<HttpResponseData> GetModule([HttpTrigger(AuthorizationLevel.Anonymous, "get", Route = "modules/{moduleId}")] HttpRequestData req, string moduleId)
+        {
+            if (string.IsNullOrWhiteSpace(moduleId))
+            {
+                return req.BadRequest("InvalidModule", "invalid module");
+            }
+
+            var principal = req.GetClaimsPrincipal();
+            var oid = GetOid(principal);
+            var module = await this.FindModule(moduleId);
+            return req.Ok(module);
+        }
+
+        [Function("DeleteModule")]
+        public async Task<HttpResponseData> DeleteModule([HttpTrigger(AuthorizationLevel.Anonymous, "delete", Route = "modules/{moduleId}")] HttpRequestData req, string moduleId)
+        {
+            var oid = GetOid(req.GetClaimsPrincipal());
+            if (!await store.Delete(moduleId, oid))
+            {
+                throw new DocsApiException(HttpStatusCode.Conflict, "ModuleLocked", $"Module {moduleId} is locked");
+            }
+            return req.CreateResponse(HttpStatusCode.NoContent);
+        }
+
+        private async Task<Module> FindModule(string moduleId)
+        {
+            var module = await store.Find(moduleId);
+            if (module == null)
+            {
+                throw new DocsApiException(HttpStatusCode.NotFound, ErrorCodes.ModuleNotFound, "Module not found");
+            }
+            return module;
+        }
+
+        private static string GetOid(ClaimsPrincipal principal)
+        {
+            var oid = principal.Claims.GetValueByName("oid");
+            if (string.IsNullOrWhiteSpace(oid))
+            {
+                throw new DocsApiException(HttpStatusCode.BadRequest, "MissingOid", "Oid is required for the user");
+            }
+            return ValidateOid(oid);
+        }
+
+        private static string ValidateOid(string oid)
+        {
+            if (!Guid.TryParse(oid, out _))
+            {
+                throw new DocsApiException(statusCode: HttpStatusCode.Forbidden, "InvalidOid", "Oid is not a guid");
+            }
+            return GetOid(null);
+        }
+    }
+}
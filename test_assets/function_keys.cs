@@ -0,0 +1,28 @@
+using System.Net;
+using Microsoft.Azure.Functions.Worker;
+using Microsoft.Azure.Functions.Worker.Http;
+
+namespace Repo.Functions
+{
+    public class KeyFunctions
+    {
+        [Function("GetKeyedItem")]
+        public HttpResponseData GetKeyedItem([HttpTrigger(AuthorizationLevel.Function, "get", Route = "keys/items")] HttpRequestData req)
+        {
+            return req.CreateResponse(HttpStatusCode.OK);
+        }
+
+        [Function("PurgeKeyedItems")]
+        [RequireDocsToken]
+        public HttpResponseData PurgeKeyedItems([HttpTrigger(authLevel: AuthorizationLevel.Admin, "delete", Route = "keys/items")] HttpRequestData req)
+        {
+            return req.CreateResponse(HttpStatusCode.NoContent);
+        }
+
+        [Function("GetPublicItem")]
+        public HttpResponseData GetPublicItem([HttpTrigger(AuthorizationLevel.Anonymous, "get", Route = "public/items")] HttpRequestData req)
+        {
+            return req.CreateResponse(HttpStatusCode.OK);
+        }
+    }
+}
@@ -0,0 +1,42 @@
+using System.Threading.Tasks;
+using Microsoft.AspNetCore.Http;
+using Microsoft.AspNetCore.Mvc;
+using Microsoft.Azure.WebJobs;
+using Microsoft.Azure.WebJobs.Extensions.Http;
+using Microsoft.Extensions.Logging;
+
+namespace Repo.Legacy.Functions
+{
+    public static class LegacyFunctions
+    {
+        [FunctionName("GetLegacyItems")]
+        public static async Task<IActionResult> GetItems(
+            [HttpTrigger(AuthorizationLevel.Function, "get", Route = null)] HttpRequest req,
+            ILogger log)
+        {
+            return new OkObjectResult(await Task.FromResult("items"));
+        }
+
+        [FunctionName(nameof(CreateLegacyItem))]
+        public static IActionResult CreateLegacyItem(
+            [HttpTrigger(AuthorizationLevel.Anonymous, "post")] HttpRequest req,
+            ILogger log)
+        {
+            return new OkResult();
+        }
+
+        [FunctionName("GetLegacyItem")]
+        public static IActionResult GetItem(
+            [HttpTrigger(AuthorizationLevel.Admin, "get", Route = "legacy/items/{id}")] HttpRequest req,
+            string id,
+            ILogger log)
+        {
+            return new OkResult();
+        }
+
+        [FunctionName("PurgeLegacyItems")]
+        public static void Purge([TimerTrigger("0 0 3 * * *")] TimerInfo timer, ILogger log)
+        {
+        }
+    }
+}
@@ -0,0 +1,89 @@
+using Microsoft.AspNetCore.Authorization;
+using Microsoft.AspNetCore.Mvc;
+
+namespace Repo.Api.Controllers
+{
+    public abstract class BaseApiController : ControllerBase
+    {
+        [HttpGet("base")]
+        public IActionResult Base() => Ok();
+    }
+
+    [ApiController]
+    [Route("api/[controller]")]
+    [Authorize(Policy = "ReadItems")]
+    public class ItemsController : BaseApiController
+    {
+        private readonly IItemService _items;
+
+        public ItemsController(IItemService items)
+        {
+            _items = items;
+        }
+
+        [HttpGet]
+        public async Task<ActionResult<IEnumerable<Item>>> GetAllAsync([FromQuery] int page = 1, string? filter = null, CancellationToken cancellationToken = default)
+        {
+            return Ok(await _items.List(page, filter, cancellationToken));
+        }
+
+        [HttpGet("{id:int}")]
+        public async Task<ActionResult<Item>> GetById(int id)
+        {
+            return Ok(await _items.Get(id));
+        }
+
+        [HttpPost]
+        [Authorize(Roles = "Admin, Editor")]
+        public async Task<IActionResult> Create([FromBody] CreateItemRequest request, [FromHeader(Name = "X-Correlation-Id")] string correlationId)
+        {
+            return Created();
+        }
+
+        [HttpPut("{id}")]
+        [HttpPatch("{id}")]
+        public IActionResult Update(int id, UpdateItemRequest request) => NoContent();
+
+        [HttpDelete("/api/admin/items/{id}")]
+        public IActionResult Delete([FromRoute] int id) => NoContent();
+
+        [AllowAnonymous]
+        [HttpGet("health")]
+        public IActionResult Health() => Ok();
+
+        [Route("[action]")]
+        [HttpGet]
+        [ActionName("Download")]
+        public IActionResult Export() => File();
+
+        [HttpPost("upload")]
+        public IActionResult Upload(IFormFile file) => Ok();
+
+        [NonAction]
+        public void Helper()
+        {
+        }
+
+        private void Hidden()
+        {
+        }
+    }
+
+    [Area("Admin")]
+    [Route("[area]/[controller]")]
+    public class ReportsController : Controller
+    {
+        [HttpGet("[action]/{slug?}")]
+        public IActionResult Index(string slug) => View();
+
+        public IActionResult Summary() => View();
+    }
+
+    public class NotAController
+    {
+        [HttpGet("nope")]
+        public void Nope()
+        {
+        }
+    }
+}
@@ -0,0 +1,43 @@
+using Microsoft.AspNetCore.Authorization;
+using Microsoft.AspNetCore.Mvc;
+
+var builder = WebApplication.CreateBuilder(args);
+builder.Services.AddAuthorization();
+var app = builder.Build();
+
+app.MapGet("/health", () => Results.Ok()).AllowAnonymous();
+
+var items = app.MapGroup("/api/items")
+    .RequireAuthorization("ReadItems")
+    .WithTags("Items");
+
+items.MapGet("/", GetItems).WithName("ListItems");
+items.MapGet("/{id:int}", async (int id, IItemRepository repository) => await repository.Get(id))
+    .WithSummary("Get an item");
+items.MapPost("/", async ([FromBody] CreateItemRequest request, ItemDb db, [FromHeader(Name = "X-Correlation-Id")] string correlationId) =>
+    {
+        db.Add(request);
+        return Results.Created();
+    })
+    .RequireAuthorization("Admin")
+    .Produces<Item>(201);
+app.MapMethods("/api/items/{id}", new[] { "PUT", "PATCH" }, UpdateItem);
+
+// a group's conventions can be added after it's created
+var admin = app.MapGroup("admin");
+admin.RequireAuthorization();
+admin.MapDelete("items/{id}", [Authorize(Roles = "Owner")] (string id) => Results.NoContent());
+
+app.Run();
+
+static IResult GetItems(int page, string? filter, CancellationToken cancellationToken) => Results.Ok();
+
+static IResult UpdateItem(string id, UpdateItemRequest request) => Results.Ok();
+
+public static class ReportEndpoints
+{
+    public static void MapReports(this IEndpointRouteBuilder routes)
+    {
+        routes.MapGet("/api/reports/{slug}", (string slug, ILogger<Program> logger) => Results.Ok(slug));
+    }
+}
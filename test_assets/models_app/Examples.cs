@@ -0,0 +1,57 @@
+using System;
+using System.Collections.Generic;
+using Microsoft.Azure.WebJobs.Extensions.OpenApi.Core.Abstractions;
+using Microsoft.Azure.WebJobs.Extensions.OpenApi.Core.Resolvers;
+using Newtonsoft.Json.Serialization;
+using Repo.Orders.Models;
+
+namespace Repo.Orders.Examples
+{
+    public class CreateOrderRequestExample : OpenApiExample<CreateOrderRequest>
+    {
+        private const string DefaultCustomer = "customer-42";
+
+        public override IOpenApiExample<CreateOrderRequest> Build(NamingStrategy namingStrategy = null)
+        {
+            this.Examples.Add(
+                OpenApiExampleResolver.Resolve(
+                    "express",
+                    new CreateOrderRequest
+                    {
+                        CustomerId = DefaultCustomer,
+                        Lines = new List<OrderLine>
+                        {
+                            new OrderLine("SKU-1", 2, 9.99m),
+                            new("SKU-2", Quantity: 1_000, UnitPrice: -0.5m),
+                        },
+                        ShippingAddress = new Address { Street = "1 Main St", City = $"Spring{"field"}" },
+                        Express = true,
+                        DeliverBy = DateOnly.Parse("2024-05-01"),
+                    },
+                    namingStrategy));
+
+            return this;
+        }
+    }
+
+    public class OrderExample : OpenApiExample<Order>
+    {
+        public override IOpenApiExample<Order> Build(NamingStrategy namingStrategy = null)
+        {
+            var order = new Order
+            {
+                Id = Guid.Parse("3f2504e0-4f89-11d3-9a0c-0305e82c3301"),
+                OrderNumber = "A-1001",
+                CustomerId = "customer-7",
+                Status = OrderStatus.Shipped,
+                Discount = null,
+                Metadata = new Dictionary<string, string> { ["channel"] = "web", { "campaign", "spring" } },
+                Tags = new[] { "gift", "priority" },
+                InternalNotes = "not serialized",
+            };
+            this.Examples.Add(OpenApiExampleResolver.Resolve("shipped", order, namingStrategy));
+
+            return this;
+        }
+    }
+}
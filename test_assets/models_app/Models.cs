@@ -0,0 +1,84 @@
+using System;
+using System.Collections.Generic;
+using System.ComponentModel.DataAnnotations;
+using System.Text.Json.Serialization;
+using Newtonsoft.Json;
+
+namespace Repo.Orders.Models
+{
+    public abstract class Entity
+    {
+        public Guid Id { get; set; }
+        public DateTimeOffset CreatedAt { get; init; }
+    }
+
+    public partial class Order : Entity, IValidatableObject
+    {
+        [JsonPropertyName("order_number")]
+        public string OrderNumber { get; set; }
+        public required string CustomerId { get; set; }
+        public List<OrderLine> Lines { get; set; } = new();
+        public OrderStatus Status { get; set; }
+        public Address? ShippingAddress { get; set; }
+        public decimal? Discount { get; set; }
+        public Dictionary<string, string> Metadata { get; set; }
+
+        [JsonIgnore]
+        public string InternalNotes { get; set; }
+
+        public static Order Empty => new();
+        private int version;
+
+        public IEnumerable<ValidationResult> Validate(ValidationContext context) => Array.Empty<ValidationResult>();
+    }
+
+    public partial class Order
+    {
+        public string[] Tags { get; set; }
+    }
+
+    public record OrderLine([property: JsonPropertyName("sku")] string ProductSku, int Quantity, [property: Required] decimal UnitPrice);
+
+    public class Address
+    {
+        [JsonProperty("line1")]
+        [Required]
+        public string Street { get; set; }
+        public string City { get; set; }
+        public string? PostalCode { get; set; }
+    }
+
+    [JsonConverter(typeof(JsonStringEnumConverter))]
+    public enum OrderStatus
+    {
+        Pending,
+        Shipped = 2,
+        Cancelled
+    }
+
+    public enum Priority { Low, High }
+
+    public class PagedResult<T>
+    {
+        public IReadOnlyList<T> Items { get; set; }
+        public int Total { get; set; }
+        public string? ContinuationToken { get; set; }
+    }
+
+    public class Category
+    {
+        public string Name { get; set; }
+        public Priority Priority { get; set; }
+        public List<Category> Children { get; set; }
+    }
+
+    public class CreateOrderRequest
+    {
+        [JsonRequired]
+        public string CustomerId { get; set; }
+        public List<OrderLine> Lines { get; set; }
+        public Address ShippingAddress { get; set; }
+        public bool Express { get; set; }
+        public DateOnly? DeliverBy { get; set; }
+    }
+}
@@ -0,0 +1,58 @@
+using System.Net;
+using Microsoft.AspNetCore.Http;
+using Microsoft.AspNetCore.Mvc;
+using Microsoft.Azure.Functions.Worker;
+using Microsoft.Azure.Functions.Worker.Http;
+using Repo.Orders.Examples;
+
+namespace Repo.Orders
+{
+    using Repo.Orders.Models;
+
+    public class OrderFunctions(IOrderStore store)
+    {
+        [Function("CreateOrder")]
+        [OpenApiRequestBody("application/json", typeof(CreateOrderRequest), Required = true, Example = typeof(CreateOrderRequestExample))]
+        [OpenApiResponseWithBody(HttpStatusCode.Created, "application/json", typeof(Order))]
+        public async Task<HttpResponseData> CreateOrder([HttpTrigger(AuthorizationLevel.Function, "post", Route = "orders")] HttpRequestData req)
+        {
+            var request = await req.ReadFromJsonAsync<CreateOrderRequest>();
+            var order = await store.Create(request);
+            var response = req.CreateResponse(HttpStatusCode.Created);
+            await response.WriteAsJsonAsync(order);
+            return response;
+        }
+
+        [Function("UpdateOrder")]
+        public async Task<HttpResponseData> UpdateOrder([HttpTrigger(AuthorizationLevel.Function, "put", Route = "orders/{id}")] HttpRequestData req, string id)
+        {
+            var order = await req.ReadFromJsonAsync<Order>();
+            await store.Update(id, order);
+            return req.CreateResponse(HttpStatusCode.NoContent);
+        }
+
+        [Function("ListOrders")]
+        [OpenApiResponseWithBody(HttpStatusCode.OK, "application/json", typeof(PagedResult<Order>))]
+        public async Task<HttpResponseData> ListOrders([HttpTrigger(AuthorizationLevel.Function, "get", Route = "orders")] HttpRequestData req)
+        {
+            var response = req.CreateResponse(HttpStatusCode.OK);
+            await response.WriteAsJsonAsync(await store.List());
+            return response;
+        }
+
+        [Function("ImportCategories")]
+        public async Task<IActionResult> ImportCategories([HttpTrigger(AuthorizationLevel.Function, "post", Route = "categories")] HttpRequest req, [FromBody] List<Category> categories)
+        {
+            await store.Import(categories);
+            return new OkResult();
+        }
+
+        [Function("TagOrder")]
+        public async Task<HttpResponseData> TagOrder([HttpTrigger(AuthorizationLevel.Function, "post", Route = "orders/{id}/tags")] HttpRequestData req, string id)
+        {
+            var tag = await req.ReadBodyAs<TagRequest>();
+            await store.Tag(id, tag);
+            return req.CreateResponse(HttpStatusCode.OK);
+        }
+    }
+}
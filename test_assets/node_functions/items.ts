@@ -0,0 +1,35 @@
+import { app, HttpRequest, HttpResponseInit, InvocationContext, Timer } from '@azure/functions';
+
+export async function getItems(request: HttpRequest, context: InvocationContext): Promise<HttpResponseInit> {
+    const filter = request.query.get('filter');
+    return { body: `items (${filter})` };
+}
+
+app.http('getItems', {
+    methods: ['GET'],
+    route: 'items',
+    authLevel: 'anonymous',
+    handler: getItems,
+});
+
+app.http('updateItem', {
+    methods: ['PUT', 'PATCH'],
+    route: 'items/{id:int}',
+    authLevel: 'function',
+    handler: async (request, context) => ({ status: 204 }),
+});
+
+app.deleteRequest('deleteItem', { route: 'items/{id}', handler: async () => ({ status: 204 }) });
+
+// app.http('disabledItems', { route: 'disabled', handler: getItems });
+
+app.timer('cleanupItems', {
+    schedule: '0 */5 * * * *',
+    handler: (timer: Timer, context: InvocationContext) => context.log('cleanup'),
+});
+
+app.storageQueue('processItem', {
+    queueName: 'items',
+    connection: 'AzureWebJobsStorage',
+    handler: (item, context) => context.log(item),
+});
@@ -0,0 +1,17 @@
+using System.Net;
+
+namespace Repo.Functions
+{
+    public partial class ArchiveFunctions
+    {
+        private async Task<Archive> FindArchive(string archiveId)
+        {
+            var archive = await store.Find(archiveId);
+            if (archive == null)
+            {
+                throw new DocsApiException(HttpStatusCode.NotFound, "ArchiveNotFound", "Archive not found");
+            }
+            return archive;
+        }
+    }
+}
@@ -0,0 +1,16 @@
+using System.Net;
+using Microsoft.Azure.Functions.Worker;
+using Microsoft.Azure.Functions.Worker.Http;
+
+namespace Repo.Functions
+{
+    public partial class ArchiveFunctions(IArchiveStore store)
+    {
+        [Function("RestoreArchive")]
+        public async Task<HttpResponseData> RestoreArchive([HttpTrigger(AuthorizationLevel.Function, "post", Route = "archives/{archiveId}/restore")] HttpRequestData req, string archiveId)
+        {
+            var archive = await FindArchive(archiveId);
+            return req.Accepted(archive);
+        }
+    }
+}
@@ -0,0 +1,36 @@
+import azure.functions as func
+
+app = func.FunctionApp(http_auth_level=func.AuthLevel.ANONYMOUS)
+
+
+@app.route(route="items/{id:int}", methods=[func.HttpMethod.GET, "post"])
+def get_item(req: func.HttpRequest) -> func.HttpResponse:
+    return func.HttpResponse("item # {id}")
+
+
+@app.function_name(name="CreateItem")
+@app.route(route="items",
+           methods=["POST"],
+           auth_level=func.AuthLevel.FUNCTION)
+async def create_item(req: func.HttpRequest) -> func.HttpResponse:
+    return func.HttpResponse(status_code=201)
+
+
+# @app.route(route="disabled")
+def helper(value):
+    return value
+
+
+@app.timer_trigger(schedule="0 */5 * * * *", arg_name="timer", run_on_startup=False)
+def cleanup(timer: func.TimerRequest) -> None:
+    pass
+
+
+@app.queue_trigger(arg_name="msg", queue_name="items", connection="AzureWebJobsStorage")
+def process_item(msg: func.QueueMessage) -> None:
+    pass
+
+
+@app.route()
+def health(req: func.HttpRequest) -> func.HttpResponse:
+    return func.HttpResponse("ok")
@@ -0,0 +1,49 @@
+using System.Net;
+using System.Web;
+using Microsoft.AspNetCore.Http;
+using Microsoft.AspNetCore.Mvc;
+using Microsoft.Azure.Functions.Worker;
+using Microsoft.Azure.Functions.Worker.Http;
+
+namespace Repo.Functions
+{
+    public static class QueryKeys
+    {
+        public const string Page = "page";
+    }
+
+    public class SearchFunctions
+    {
+        private const string TenantHeader = "X-Tenant-Id";
+
+        [Function("Search")]
+        [OpenApiParameter("locale", Required = true, Type = typeof(string), In = ParameterLocation.Query)]
+        [OpenApiParameter("X-SID", Type = typeof(string), In = ParameterLocation.Header)]
+        public async Task<HttpResponseData> Search([HttpTrigger(AuthorizationLevel.Function, "get", Route = "search")] HttpRequestData req)
+        {
+            var locale = req.Query.Get("Locale") ?? "en";
+            var term = req.Query["q"];
+            var query = HttpUtility.ParseQueryString(req.Url.Query);
+            var page = int.Parse(query[QueryKeys.Page] ?? "1");
+            if (req.Headers.TryGetValues("X-SID", out var sessionIds) && req.Headers.TryGetValues(TenantHeader, out var tenants))
+            {
+                logger.LogInformation("Session {sessionId}", sessionIds.First());
+            }
+            var principal = req.GetClaimsPrincipal();
+
+            var response = req.CreateResponse(HttpStatusCode.OK);
+            response.Headers.Add("X-Total-Count", "0");
+            return response;
+        }
+
+        [Function("Export")]
+        [RequireDocsToken]
+        public IActionResult Export([HttpTrigger(AuthorizationLevel.Function, "get", Route = "export")] HttpRequest req)
+        {
+            var format = req.Query.TryGetValue("format", out var value) ? value.ToString() : "csv";
+            var principal = req.GetClaimsPrincipal();
+            req.HttpContext.Response.Headers["Content-Disposition"] = "attachment";
+            return new OkResult();
+        }
+    }
+}
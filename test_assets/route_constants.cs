@@ -0,0 +1,39 @@
+namespace Repo.Functions
+{
+    public static class Routes
+    {
+        public const string Prefix = "catalog";
+        public const string Items = Prefix + "/items";
+        public static readonly string Item = $"{Items}/{{itemId}}";
+        public const string Circular = Circular + "/loop";
+    }
+
+    public class RouteTriggers
+    {
+        private const string Local = "local";
+
+        [Function(nameof(GetItems))]
+        public Task<HttpResponseData> GetItems([HttpTrigger(AuthorizationLevel.Anonymous, "get", Route = Routes.Items)] HttpRequestData req)
+        {
+            return req.Ok();
+        }
+
+        [Function(nameof(GetItem))]
+        public Task<HttpResponseData> GetItem([HttpTrigger(AuthorizationLevel.Anonymous, "get", Route = Routes.Item + "/details")] HttpRequestData req, string itemId)
+        {
+            return req.Ok();
+        }
+
+        [Function("GetLocal")]
+        public Task<HttpResponseData> GetLocal([HttpTrigger(AuthorizationLevel.Anonymous, "get", Route = $"{Routes.Prefix}/{Local}/{nameof(GetLocal)}/{{id}}")] HttpRequestData req, string id)
+        {
+            return req.Ok();
+        }
+
+        [Function("GetCircular")]
+        public Task<HttpResponseData> GetCircular([HttpTrigger(AuthorizationLevel.Anonymous, "get", Route = Routes.Circular)] HttpRequestData req)
+        {
+            return req.Ok();
+        }
+    }
+}
@@ -0,0 +1,15 @@
+using System.Net;
+using Microsoft.Azure.Functions.Worker;
+using Microsoft.Azure.Functions.Worker.Http;
+
+namespace Repo.Functions
+{
+    public class ItemVersionFunctions
+    {
+        [Function("GetItemVersion")]
+        public HttpResponseData GetItemVersion([HttpTrigger(AuthorizationLevel.Function, "get", Route = "items/{id:int}/versions/{version:int?}")] HttpRequestData req, int id, int? version)
+        {
+            return req.CreateResponse(HttpStatusCode.OK);
+        }
+    }
+}
@@ -0,0 +1,34 @@
+using Microsoft.Azure.Functions.Worker;
+using Microsoft.Azure.Functions.Worker.Http;
+
+namespace Repo.Functions
+{
+    public class SettingsFunctions
+    {
+        [Function("Cleanup")]
+        public void Cleanup([TimerTrigger("%CleanupSchedule%")] TimerInfo timer)
+        {
+        }
+
+        [Function("ProcessOrder")]
+        public void ProcessOrder([QueueTrigger("%Orders:Queue%", Connection = "AzureWebJobsStorage")] string order)
+        {
+        }
+
+        [Function("ProcessInvoice")]
+        public void ProcessInvoice([BlobTrigger("%Invoices:Container%/{name}")] string invoice, string name)
+        {
+        }
+
+        [Function("GetReports")]
+        public HttpResponseData GetReports([HttpTrigger(AuthorizationLevel.Function, "get", Route = "%ReportsRoute%/{id}")] HttpRequestData req, string id)
+        {
+            return req.CreateResponse();
+        }
+
+        [Function("ArchiveOrders")]
+        public void ArchiveOrders([TimerTrigger("%ArchiveSchedule%")] TimerInfo timer)
+        {
+        }
+    }
+}
@@ -7,6 +7,9 @@ import (
 
 var pathVarRegex = regexp.MustCompile(`{([a-zA-Z0-9_]+)}`)
 
+// matches a route parameter with its catch all prefix, constraints, default value or optional marker, e.g. {id:int}, {slug?} or {*path}
+var routeParameterRegex = regexp.MustCompile(`{\*{0,2}([a-zA-Z0-9_]+)(?:[:=?][^}]*)?}`)
+
 // ReplacePathVars replaces the path variables in a route string with a colon followed by the variable name.
 // For example, for the route "/api/{id}/details", it will return "/api/:id/details".
 // This is the format used by the bruno and insomnia
@@ -29,6 +32,12 @@ func ReplacePathVars(route string) string {
 	return route
 }
 
+// NormalizeRouteTemplate strips the constraints, defaults, optional markers and catch all prefixes from the
+// parameters of an asp.net route template. For example, "/api/{id:int}/{*path}" will return "/api/{id}/{path}"
+func NormalizeRouteTemplate(route string) string {
+	return routeParameterRegex.ReplaceAllString(route, "{$1}")
+}
+
 // ExtractPathVars extracts the path variables from a route string.
 // It returns a map of the param names to their partial values (if exist).
 // For example, for the route "/api/{id}/details/user:{user}", it will return ["id" -> "", "user" -> "user:"].
@@ -135,3 +135,25 @@ func Test_GetPartialPathParamValue_ReturnsNonVariablePortionOfPathParam(t *testi
 		})
 	}
 }
+
+func Test_NormalizeRouteTemplate_StripsParameterModifiers(t *testing.T) {
+	tests := []struct {
+		name     string
+		route    string
+		expected string
+	}{
+		{name: "No parameters", route: "/api/items", expected: "/api/items"},
+		{name: "Plain parameter", route: "/api/{id}", expected: "/api/{id}"},
+		{name: "Constraint", route: "/api/{id:int}/details", expected: "/api/{id}/details"},
+		{name: "Multiple constraints", route: "/api/{id:int:min(1)}", expected: "/api/{id}"},
+		{name: "Optional", route: "/api/{slug?}", expected: "/api/{slug}"},
+		{name: "Default value", route: "/api/{page=1}", expected: "/api/{page}"},
+		{name: "Catch all", route: "/files/{*path}", expected: "/files/{path}"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			AssertStringEqual(t, test.expected, NormalizeRouteTemplate(test.route))
+		})
+	}
+}
@@ -15,6 +15,14 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// SkippedDirs are the directories GetFiles doesn't search in, they hold dependencies and tooling rather than source
+var SkippedDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	".venv":        true,
+	"__pycache__":  true,
+}
+
 // GetFiles returns a list of files and directories in the specified directory path.
 //
 // It filters the results based on the provided extensions, and whether to include folders or files.
@@ -25,7 +33,7 @@ import (
 // - exts: A slice of file extensions to filter the results.
 // - folders: A boolean indicating whether to include directories in the results.
 // - files: A boolean indicating whether to include files in the results.
-// - deep: A boolean indicating whether to search recursively in subdirectories.
+// - deep: A boolean indicating whether to search recursively in subdirectories (except the SkippedDirs).
 //
 // Returns:
 // - A slice of FileMetaData representing the files and directories found.
@@ -58,7 +66,7 @@ func GetFiles(dirPath string, exts []string, folders bool, files bool, deep bool
 		if entry.IsDir() && folders {
 			list = append(list, data.FileMetaData{Name: entry.Name(), Path: path.Join(dirPath, entry.Name())})
 		}
-		if entry.IsDir() && deep {
+		if entry.IsDir() && deep && !SkippedDirs[entry.Name()] {
 			subEntries, err := GetFiles(path.Join(dirPath, entry.Name()), exts, folders, files, deep)
 			if err != nil { // maybe a sub folder is not accessible, should we hard fail?
 				return list, err
@@ -246,6 +254,9 @@ func HasParent(p string, parent string) bool {
 		if Base(p) == Base(parent) {
 			return true
 		}
+		if Dir(p) == p { // reached the root of an absolute path
+			break
+		}
 		p = Dir(p)
 	}
 
@@ -135,6 +135,12 @@ func Test_HasParent_ReturnsCorrectBoolean(t *testing.T) {
 			parent:   "/home/user/project",
 			expected: true,
 		},
+		{
+			name:     "Absolute path without parent",
+			path:     "/home/user/project/src",
+			parent:   "other",
+			expected: false,
+		},
 		{
 			name:     "Path has direct parent (windows)",
 			path:     "C:\\home\\user\\project",